@@ -0,0 +1,128 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptOutcome is one runner's result from a concurrent accept.
+type acceptOutcome struct {
+	RunnerID uuid.UUID
+	Result   *application.BookingDTO
+	Err      error
+}
+
+// raceAccepts has every runner accept the booking at the same moment.
+func raceAccepts(svc *application.BookingService, bookingID uuid.UUID, runners []uuid.UUID) []acceptOutcome {
+	outcomes := make([]acceptOutcome, len(runners))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, runnerID := range runners {
+		wg.Add(1)
+		go func(i int, runnerID uuid.UUID) {
+			defer wg.Done()
+			<-start
+			result, err := svc.AcceptBooking(context.Background(), bookingID, runnerID)
+			outcomes[i] = acceptOutcome{RunnerID: runnerID, Result: result, Err: err}
+		}(i, runnerID)
+	}
+	close(start)
+	wg.Wait()
+	return outcomes
+}
+
+func TestAcceptBooking_ConcurrentRunnersFirstWins(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupRunnerLoadService(t, infra, 0)
+	defer cleanup()
+
+	ctx := context.Background()
+	const bookings, runnersPerBooking = 10, 12
+	for b := 0; b < bookings; b++ {
+		bk, err := svc.CreateBooking(ctx, uuid.New(), immediateRequest())
+		require.NoError(t, err)
+		runners := make([]uuid.UUID, runnersPerBooking)
+		for i := range runners {
+			runners[i] = uuid.New()
+		}
+
+		var winner *uuid.UUID
+		for _, o := range raceAccepts(svc, bk.ID, runners) {
+			if o.Err == nil {
+				require.Nil(t, winner, "only one runner may win booking %s", bk.BookingNumber)
+				id := o.RunnerID
+				winner = &id
+				continue
+			}
+			var taken *application.BookingTakenError
+			require.True(t, errors.As(o.Err, &taken), "losers are told the booking is taken, got %v", o.Err)
+			require.NotNil(t, taken.Booking.RunnerID)
+			assert.NotEqual(t, o.RunnerID, *taken.Booking.RunnerID)
+			assert.Equal(t, string(bookingDomain.StatusAccepted), taken.Booking.Status)
+		}
+		require.NotNil(t, winner, "one runner must win booking %s", bk.BookingNumber)
+
+		stored, err := svc.GetBooking(ctx, bk.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.RunnerID)
+		assert.Equal(t, *winner, *stored.RunnerID)
+		assert.Equal(t, bk.Version+1, stored.Version, "the booking is updated exactly once")
+
+		var transitions int64
+		require.NoError(t, infra.DB.Table("booking_status_history").
+			Where("booking_id = ? AND to_status = ?", bk.ID, string(bookingDomain.StatusAccepted)).
+			Count(&transitions).Error)
+		assert.Equal(t, int64(1), transitions)
+	}
+}
+
+func TestAcceptBookingHTTP_LoserGetsTakenResponse(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupRunnerLoadService(t, infra, 0)
+	defer cleanup()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	handler.NewBookingHandler(svc, nil).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	bk, err := svc.CreateBooking(context.Background(), uuid.New(), immediateRequest())
+	require.NoError(t, err)
+	winner, loser := uuid.New(), uuid.New()
+	path := "/api/v1/bookings/" + bk.ID.String() + "/accept"
+
+	w := doJSONRequest(t, router, http.MethodPost, path, runnerToken(t, jwtManager, winner), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = doJSONRequest(t, router, http.MethodPost, path, runnerToken(t, jwtManager, loser), nil)
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	var body struct {
+		Success bool                   `json:"success"`
+		Error   string                 `json:"error"`
+		Data    application.BookingDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.False(t, body.Success)
+	assert.Contains(t, body.Error, "already taken")
+	require.NotNil(t, body.Data.RunnerID)
+	assert.Equal(t, winner, *body.Data.RunnerID)
+	assert.Equal(t, string(bookingDomain.StatusAccepted), body.Data.Status)
+}
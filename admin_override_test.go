@@ -0,0 +1,98 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdminForceComplete_AdjustsPrice_RecordsHistory_EmitsEvent verifies that an admin
+// can complete a stuck delivered booking with an adjusted final price, that the
+// override is audited in booking_status_history and that the override event is emitted.
+func TestAdminForceComplete_AdjustsPrice_RecordsHistory_EmitsEvent(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	bookingID, adminID := uuid.New(), uuid.New()
+	seedBookingInDeliveredState(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	result, err := stack.Service.AdminForceComplete(context.Background(), adminID, bookingID, 4200, "payment webhook lost")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Status)
+	require.NotNil(t, result.FinalPriceCents)
+	assert.Equal(t, int64(4200), *result.FinalPriceCents)
+
+	var history repository.StatusHistoryModel
+	require.NoError(t, infra.DB.Where("booking_id = ?", bookingID).First(&history).Error)
+	assert.Equal(t, "delivered", history.FromStatus)
+	assert.Equal(t, "completed", history.ToStatus)
+	assert.Equal(t, "payment webhook lost", history.Reason)
+	require.NotNil(t, history.ChangedBy)
+	assert.Equal(t, adminID, *history.ChangedBy)
+	require.NotNil(t, history.AdminAction)
+	assert.Equal(t, "force_complete", *history.AdminAction)
+
+	ce := consumeOneEvent(t, infra.KafkaBrokers, events.TopicBookingEvents,
+		application.BookingAdminOverride, 15*time.Second)
+	var evt application.BookingAdminOverrideEvent
+	require.NoError(t, ce.ParseData(&evt))
+	assert.Equal(t, bookingID, evt.BookingID)
+	assert.Equal(t, "force_complete", evt.Action)
+	assert.Equal(t, "delivered", evt.FromStatus)
+}
+
+func TestAdminRevertToAccepted_ClearsPickup(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	bookingID, runnerID := uuid.New(), uuid.New()
+	seedInProgressBooking(t, infra.DB, bookingID, uuid.New(), runnerID)
+
+	result, err := stack.Service.AdminRevertToAccepted(context.Background(), uuid.New(), bookingID, "runner marked pickup by mistake")
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", result.Status)
+	require.NotNil(t, result.RunnerID)
+	assert.Equal(t, runnerID, *result.RunnerID)
+
+	model := waitForBookingStatus(t, infra.DB, bookingID, "accepted", 5*time.Second)
+	assert.Nil(t, model.PickedUpAt)
+}
+
+func TestAdminOverride_RejectsActionOutsideAdminTable(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	bookingID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	// accepted -> completed is not in the admin transition table.
+	_, err := stack.Service.AdminForceComplete(context.Background(), uuid.New(), bookingID, 1000, "testing")
+	require.Error(t, err)
+
+	// Reason is mandatory.
+	_, err = stack.Service.AdminForceCancel(context.Background(), uuid.New(), bookingID, "")
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, infra.DB.Model(&repository.StatusHistoryModel{}).
+		Where("booking_id = ?", bookingID).Count(&count).Error)
+	assert.Zero(t, count)
+}
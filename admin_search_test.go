@@ -0,0 +1,81 @@
+//go:build integration
+
+package main_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupAdminRouter(t *testing.T, db *gorm.DB) (*gin.Engine, string) {
+	t.Helper()
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+
+	svc := setupBookingStackWith(t, db, bookingStackConfig{}).Service
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewAdminBookingHandler(svc).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	token, err := jwtManager.GenerateAccessToken(uuid.New(), "admin@test.com", auth.RoleAdmin)
+	require.NoError(t, err)
+	return router, token
+}
+
+func searchBookings(t *testing.T, router *gin.Engine, token string, query url.Values) []application.BookingDTO {
+	t.Helper()
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/admin/bookings/search?"+query.Encode(), token, nil)
+	require.Equal(t, http.StatusOK, w.Code, "search failed: %s", w.Body.String())
+
+	var body struct {
+		Data application.BookingCursorPage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body.Data.Items
+}
+
+func TestAdminSearch_MatchesAddressPetNameAndRunnerID(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, token := setupAdminRouter(t, infra.DB)
+
+	bookingID, runnerID := uuid.New(), uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, uuid.New(), runnerID)
+	seedAcceptedBooking(t, infra.DB, uuid.New(), uuid.New(), uuid.New())
+	require.NoError(t, infra.DB.Exec(
+		`UPDATE bookings SET pickup_address = jsonb_set(pickup_address, '{line1}', '"88 Jalan Ampang"'),
+		 pet_spec = jsonb_set(pet_spec, '{name}', '"Mochi"') WHERE id = ?`, bookingID).Error)
+
+	for _, q := range []string{"jalan amp", "MOCHI", runnerID.String()[:8]} {
+		items := searchBookings(t, router, token, url.Values{"q": {q}})
+		require.Len(t, items, 1, "query %q", q)
+		assert.Equal(t, bookingID, items[0].ID)
+	}
+
+	// Structured filters combine with the text query.
+	items := searchBookings(t, router, token, url.Values{"q": {"mochi"}, "status": {"completed"}})
+	assert.Empty(t, items)
+}
+
+func TestAdminSearch_ShortQuery_Returns400(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, token := setupAdminRouter(t, infra.DB)
+
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/admin/bookings/search?q=ab", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "expected 400, got: %s", w.Body.String())
+}
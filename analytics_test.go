@@ -0,0 +1,82 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestAnalytics_BookingSeries_LiveMatchesRollup drives one booking to completion and
+// cancels another, then checks the series totals from the live queries and from the
+// refreshed daily rollups.
+func TestAnalytics_BookingSeries_LiveMatchesRollup(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.DailyRollupModel{}))
+
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+	ctx := context.Background()
+
+	completedID, runnerID := uuid.New(), uuid.New()
+	seedBookingInDeliveredState(t, infra.DB, completedID, uuid.New(), runnerID)
+	var created time.Time
+	require.NoError(t, infra.DB.Model(&repository.BookingModel{}).
+		Select("created_at").Where("id = ?", completedID).Scan(&created).Error)
+	require.NoError(t, infra.DB.Create(&repository.StatusHistoryModel{
+		ID:         uuid.New(),
+		BookingID:  completedID,
+		FromStatus: "requested",
+		ToStatus:   "accepted",
+		ChangedBy:  &runnerID,
+		CreatedAt:  created.Add(5 * time.Minute),
+	}).Error)
+	_, err := stack.Service.CompleteBooking(ctx, completedID)
+	require.NoError(t, err)
+
+	cancelledID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, cancelledID, uuid.New(), uuid.New())
+	_, err = stack.Service.CancelBooking(ctx, cancelledID, uuid.New(), "changed plans")
+	require.NoError(t, err)
+
+	logger, _ := zap.NewDevelopment()
+	analyticsRepo := repository.NewGormAnalyticsRepository(infra.DB)
+	query := application.BookingSeriesQuery{Granularity: "day", PetType: "cat"}
+
+	assertTotals := func(t *testing.T, series *application.BookingSeriesDTO) {
+		t.Helper()
+		totals := series.Totals
+		assert.Equal(t, int64(2), totals.Created)
+		assert.Equal(t, int64(1), totals.Completed)
+		assert.Equal(t, int64(1), totals.Cancelled)
+		assert.Equal(t, int64(150000), totals.GMVCents)
+		require.NotNil(t, totals.AvgPriceCents)
+		assert.Equal(t, int64(150000), *totals.AvgPriceCents)
+		require.NotNil(t, totals.AvgTimeToAcceptSec)
+		assert.InDelta(t, 300, *totals.AvgTimeToAcceptSec, 1)
+		assert.NotNil(t, totals.AvgPickupToDeliverySec)
+		assert.Len(t, series.Points, 30)
+	}
+
+	live, err := application.NewAnalyticsService(analyticsRepo, nil, false, logger).GetBookingSeries(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, "live", live.Source)
+	assertTotals(t, live)
+
+	rollupSvc := application.NewAnalyticsService(analyticsRepo, nil, true, logger)
+	now := time.Now().UTC()
+	require.NoError(t, rollupSvc.RefreshRollups(ctx, now.Add(-48*time.Hour), now.Add(24*time.Hour)))
+	rollup, err := rollupSvc.GetBookingSeries(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, "rollup", rollup.Source)
+	assertTotals(t, rollup)
+}
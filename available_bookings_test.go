@@ -0,0 +1,96 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailableBookings_NearbyOpenWorkForRunner(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, capabilities, cleanup := setupCapabilityStack(t, infra, false)
+	defer cleanup()
+
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewBookingHandler(bookings, nil).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	runnerID := uuid.New()
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: runnerID, CrateSizes: []string{"medium"}, MaxPetWeightKg: 20, UpdatedAt: time.Now().UTC(),
+	}))
+
+	createAt := func(lat float64, petType string) *application.BookingDTO {
+		req := vetVisitRequest(0)
+		req.PickupAddress.Latitude = lat
+		req.PetSpec.PetType = petType
+		bk, err := bookings.CreateBooking(ctx, ownerID, req)
+		require.NoError(t, err)
+		return bk
+	}
+	near := createAt(3.139, "cat")
+	farther := createAt(3.20, "cat")
+	createAt(3.50, "cat")     // about 40 km away, outside the radius
+	createAt(3.14, "reptile") // needs climate control the runner lacks
+	declined := createAt(3.15, "cat")
+	_, err := bookings.AcceptBooking(ctx, declined.ID, runnerID)
+	require.NoError(t, err)
+	_, err = bookings.DeclineBooking(ctx, declined.ID, runnerID, "too far")
+	require.NoError(t, err)
+	taken := createAt(3.141, "cat")
+	_, err = bookings.AcceptBooking(ctx, taken.ID, uuid.New())
+	require.NoError(t, err)
+
+	token, err := jwtManager.GenerateAccessToken(runnerID, "runner@test.com", auth.RoleRunner)
+	require.NoError(t, err)
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/bookings/available?lat=3.139&lng=101.6869&radius_km=10", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, "list failed: %s", w.Body.String())
+	var resp struct {
+		Data []application.AvailableBookingDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, near.ID, resp.Data[0].ID)
+	assert.Equal(t, farther.ID, resp.Data[1].ID)
+	assert.InDelta(t, 0, resp.Data[0].PickupDistanceKm, 0.01)
+	assert.InDelta(t, 6.8, resp.Data[1].PickupDistanceKm, 0.2)
+	assert.Equal(t, bookingDomain.RunnerPayoutCents(near.EstimatedPriceCents, bookingDomain.DefaultRunnerPayoutPercent), resp.Data[0].EstimatedPayoutCents)
+
+	// Another runner without a profile still sees the booking the first one declined.
+	other, err := bookings.ListAvailableBookings(ctx, uuid.New(), application.AvailableBookingsQuery{
+		Latitude: ptrFloat(3.139), Longitude: ptrFloat(101.6869),
+	})
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, len(other))
+	for i, a := range other {
+		ids[i] = a.ID
+	}
+	assert.Contains(t, ids, declined.ID)
+	assert.Len(t, ids, 4)
+
+	w = doJSONRequest(t, router, http.MethodGet, "/api/v1/bookings/available?lng=101.6869", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	ownerToken, err := jwtManager.GenerateAccessToken(ownerID, "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	w = doJSONRequest(t, router, http.MethodGet, "/api/v1/bookings/available?lat=3.139&lng=101.6869", ownerToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func ptrFloat(f float64) *float64 { return &f }
@@ -0,0 +1,98 @@
+//go:build integration
+
+package main_test
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupExportRouter(t *testing.T, db *gorm.DB) (*gin.Engine, string) {
+	t.Helper()
+	logger, _ := zap.NewDevelopment()
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+
+	svc := application.NewExportService(repository.NewGormBookingExportRepository(db), logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewAdminExportHandler(svc, logger).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	token, err := jwtManager.GenerateAccessToken(uuid.New(), "admin@test.com", auth.RoleAdmin)
+	require.NoError(t, err)
+	return router, token
+}
+
+func TestBookingExport_CSVFiltersByStatus(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, token := setupExportRouter(t, infra.DB)
+	ownerID := uuid.New()
+	ids := seedOwnerBookings(t, infra.DB, ownerID, 4) // 2 accepted, 2 completed
+
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/admin/bookings/export?status=completed", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3, "header plus two completed bookings")
+	assert.Equal(t, "booking_number", records[0][1])
+	for _, rec := range records[1:] {
+		assert.Equal(t, "completed", rec[2])
+		assert.Equal(t, ownerID.String(), rec[3])
+		assert.Equal(t, "MYR", rec[8])
+		assert.NotEmpty(t, rec[14], "completed_at")
+	}
+	assert.Contains(t, []string{ids[0].String(), ids[2].String()}, records[1][0])
+}
+
+func TestBookingExport_NDJSONStreamsAllRows(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, token := setupExportRouter(t, infra.DB)
+	seedOwnerBookings(t, infra.DB, uuid.New(), 5)
+
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/admin/bookings/export?format=ndjson", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var rows []application.BookingExportDTO
+	scanner := bufio.NewScanner(strings.NewReader(w.Body.String()))
+	for scanner.Scan() {
+		var row application.BookingExportDTO
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 5)
+	for i := 1; i < len(rows); i++ {
+		assert.False(t, rows[i].CreatedAt.Before(rows[i-1].CreatedAt), "rows are oldest first")
+	}
+}
+
+func TestBookingExport_InvalidFormat_Returns400(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, token := setupExportRouter(t, infra.DB)
+
+	w := doJSONRequest(t, router, http.MethodGet, "/api/v1/admin/bookings/export?format=xlsx", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
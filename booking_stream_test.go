@@ -0,0 +1,70 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestBookingStream_OwnerReceivesStatusChange verifies that a committed transition is
+// pushed through the real-time hub to the booking owner's subscription.
+func TestBookingStream_OwnerReceivesStatusChange(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	logger, _ := zap.NewDevelopment()
+	hub := realtime.NewHub(realtime.NewLocalBroadcaster(), logger)
+	// No brokers: decline doesn't publish Kafka events.
+	svc := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Options: application.BookingServiceOptions{Updates: hub},
+	}).Service
+
+	bookingID := uuid.New()
+	ownerID := uuid.New()
+	runnerID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, ownerID, runnerID)
+
+	updates, unsubscribe, err := svc.SubscribeUpdates(context.Background(), ownerID, nil)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	_, err = svc.DeclineBooking(context.Background(), bookingID, runnerID, "too_far")
+	require.NoError(t, err)
+
+	select {
+	case u := <-updates:
+		assert.Equal(t, realtime.UpdateStatusChanged, u.Type)
+		assert.Equal(t, bookingID, u.BookingID)
+		assert.Equal(t, "requested", u.Status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for booking update")
+	}
+}
+
+// TestBookingStream_StrangerForbidden verifies that only the owner and assigned runner
+// may subscribe to a single booking's stream.
+func TestBookingStream_StrangerForbidden(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	logger, _ := zap.NewDevelopment()
+	svc := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Options: application.BookingServiceOptions{Updates: realtime.NewHub(realtime.NewLocalBroadcaster(), logger)},
+	}).Service
+
+	bookingID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	_, _, err := svc.SubscribeUpdates(context.Background(), uuid.New(), &bookingID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not your booking")
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/config"
 	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
 	bookingEvents "github.com/Kilat-Pet-Delivery/service-booking/internal/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/geocoding"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -61,7 +63,7 @@ func main() {
 
 	// Run database migrations
 	if cfg.AppEnv == "development" {
-		if err := db.AutoMigrate(&repository.BookingModel{}, &repository.PetModel{}, &repository.PhotoModel{}); err != nil {
+		if err := db.AutoMigrate(&repository.BookingModel{}, &repository.PetModel{}, &repository.PhotoModel{}, &repository.LocationModel{}, &repository.StatusHistoryModel{}, &repository.DailyRollupModel{}, &repository.SeriesModel{}, &repository.ZoneModel{}, &repository.SavedAddressModel{}, &repository.RunnerCapabilityModel{}, &repository.OfferModel{}, &repository.DispatchAttemptModel{}, &repository.RunnerAvailabilityModel{}, &repository.IdempotencyKeyModel{}); err != nil {
 			log.Fatal("failed to run auto-migration", zap.Error(err))
 		}
 		log.Info("database migration completed (dev auto-migrate)")
@@ -91,6 +93,86 @@ func main() {
 	// Initialize pricing strategy
 	pricingStrategy := bookingDomain.NewStandardPricingStrategy()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize real-time update hub and its cross-replica broadcaster
+	var broadcaster realtime.Broadcaster
+	switch cfg.RealtimeBroadcaster {
+	case "kafka":
+		broadcaster = realtime.NewKafkaBroadcaster(
+			cfg.KafkaConfig.Brokers,
+			cfg.KafkaConfig.GroupPrefix,
+			cfg.InstanceID,
+			"booking.realtime",
+			kafkaProducer,
+			log,
+		)
+	case "local":
+		broadcaster = realtime.NewLocalBroadcaster()
+	default:
+		broadcaster = realtime.NewPostgresBroadcaster(db, dbConfig.DatabaseURL(), "booking_updates", log)
+	}
+	updateHub := realtime.NewHub(broadcaster, log)
+	defer func() { _ = updateHub.Close() }()
+
+	go func() {
+		log.Info("starting real-time update relay", zap.String("broadcaster", cfg.RealtimeBroadcaster))
+		if err := updateHub.Run(ctx); err != nil && err != context.Canceled {
+			log.Error("real-time update relay error", zap.Error(err))
+		}
+	}()
+
+	// Initialize service area zones, used for coverage checks and zone pricing
+	serviceAreaService := application.NewServiceAreaService(repository.NewGormZoneRepository(db), log)
+
+	// Initialize the geocoder used to verify booking addresses
+	var geocoder geocoding.Geocoder
+	switch cfg.Geocoder {
+	case "nominatim":
+		if cfg.NominatimURL == "" {
+			log.Fatal("NOMINATIM_URL is required with GEOCODER=nominatim")
+		}
+		geocoder = geocoding.NewNominatimGeocoder(cfg.NominatimURL, cfg.NominatimUserAgent, cfg.GeocoderTimeout)
+		if cfg.GeocoderCacheSize > 0 {
+			geocoder = geocoding.NewCachingGeocoder(geocoder, cfg.GeocoderCacheSize, cfg.GeocoderCacheTTL)
+		}
+	case "gazetteer":
+		gazetteer, err := geocoding.NewGazetteerGeocoder(cfg.GazetteerPath)
+		if err != nil {
+			log.Fatal("failed to load gazetteer", zap.Error(err))
+		}
+		geocoder = gazetteer
+	case "none":
+	default:
+		log.Fatal("unknown GEOCODER", zap.String("geocoder", cfg.Geocoder))
+	}
+	var addressVerifier *application.AddressVerifier
+	if geocoder != nil {
+		addressVerifier = application.NewAddressVerifier(geocoder, cfg.GeocodeMaxMismatchM, log)
+	}
+	log.Info("address verification configured", zap.String("geocoder", cfg.Geocoder))
+
+	// Initialize owners' saved addresses, which bookings can refer to by ID
+	savedAddressService := application.NewSavedAddressService(repository.NewGormSavedAddressRepository(db), addressVerifier, log)
+
+	// Initialize the pet eligibility policy checked on every new booking
+	eligibilityPolicy := bookingDomain.DefaultEligibilityPolicy()
+	if cfg.EligibilityPolicyPath != "" {
+		eligibilityPolicy, err = application.LoadEligibilityPolicy(cfg.EligibilityPolicyPath)
+		if err != nil {
+			log.Fatal("failed to load eligibility policy", zap.Error(err))
+		}
+	}
+	eligibilityChecker := application.NewEligibilityChecker(eligibilityPolicy, petRepo, log)
+
+	// Initialize runner capability profiles checked when runners accept bookings
+	capabilityService := application.NewRunnerCapabilityService(
+		repository.NewGormRunnerCapabilityRepository(db),
+		cfg.RunnerCapabilitiesRequired,
+		log,
+	)
+
 	// Initialize application service
 	bookingService := application.NewBookingService(
 		bookingRepo,
@@ -99,11 +181,19 @@ func main() {
 		log,
 		db,
 		declineRepo,
+		application.BookingServiceOptions{
+			Updates:             updateHub,
+			ServiceArea:         serviceAreaService,
+			Addresses:           addressVerifier,
+			SavedAddresses:      savedAddressService,
+			Eligibility:         eligibilityChecker,
+			Capabilities:        capabilityService,
+			MaxActivePerRunner:  cfg.MaxActiveBookingsPerRunner,
+			RunnerPayoutPercent: cfg.RunnerPayoutPercent,
+		},
 	)
 
 	// Initialize and start payment event consumer in a goroutine
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
 	groupID := cfg.KafkaConfig.GroupPrefix + "booking-service"
 	paymentConsumer := bookingEvents.NewPaymentEventConsumer(
@@ -121,17 +211,107 @@ func main() {
 		}
 	}()
 
+	// Initialize the optional automatic dispatcher
+	var dispatchService *application.DispatchService
+	if cfg.DispatchEnabled {
+		dispatchCfg := application.DefaultDispatchConfig()
+		dispatchCfg.OfferTimeout = cfg.DispatchOfferTimeout
+		dispatchCfg.RadiusKm = cfg.DispatchRadiusKm
+		dispatchCfg.MaxRounds = cfg.DispatchMaxRounds
+		runnerAvailabilityRepo := repository.NewGormRunnerAvailabilityRepository(db)
+		dispatchService = application.NewDispatchService(
+			repository.NewGormOfferRepository(db),
+			runnerAvailabilityRepo,
+			runnerAvailabilityRepo,
+			bookingService,
+			dispatchCfg,
+			nil,
+			log,
+		)
+		go dispatchService.RunDispatcher(ctx, cfg.DispatchInterval)
+	}
+
+	// Initialize and start runner event consumer in a goroutine. It has its own group,
+	// so a rebalance on one topic does not stall the other.
+	runnerConsumer := bookingEvents.NewRunnerEventConsumer(
+		cfg.KafkaConfig.Brokers,
+		cfg.KafkaConfig.GroupPrefix+"booking-service-runner",
+		bookingService,
+		capabilityService,
+		dispatchService,
+		log,
+	)
+	defer func() { _ = runnerConsumer.Close() }()
+
+	go func() {
+		log.Info("starting runner event consumer")
+		if err := runnerConsumer.Start(ctx); err != nil && err != context.Canceled {
+			log.Error("runner event consumer error", zap.Error(err))
+		}
+	}()
+
+	// Initialize recurring booking series and their booking generator
+	seriesService := application.NewSeriesService(
+		repository.NewGormSeriesRepository(db),
+		bookingService,
+		db,
+		cfg.SeriesGenerationHorizon,
+		log,
+	)
+	if cfg.SeriesGenerationInterval > 0 {
+		go seriesService.RunGenerator(ctx, cfg.SeriesGenerationInterval)
+	}
+
 	// Initialize pet service
 	petService := application.NewPetService(petRepo, log)
 
 	// Initialize photo service
 	photoRepo := repository.NewGormPhotoRepository(db)
-	photoService := application.NewPhotoService(photoRepo, log)
+	photoService := application.NewPhotoService(photoRepo, bookingRepo, updateHub, log)
+
+	// Initialize tracking service and location retention job
+	locationRepo := repository.NewGormLocationRepository(db)
+	trackingService := application.NewTrackingService(
+		bookingRepo,
+		locationRepo,
+		kafkaProducer,
+		updateHub,
+		cfg.LocationRetention,
+		cfg.ETADelayThreshold,
+		log,
+	)
+	go trackingService.RunRetention(ctx, time.Hour)
+
+	// Initialize admin analytics, the optional daily rollup refresh job and the
+	// runner reliability publisher
+	analyticsService := application.NewAnalyticsService(
+		repository.NewGormAnalyticsRepository(db),
+		kafkaProducer,
+		cfg.AnalyticsRollupEnabled,
+		log,
+	)
+	if cfg.AnalyticsRollupEnabled {
+		go analyticsService.RunRollupRefresh(ctx, cfg.AnalyticsRollupRefresh)
+	}
+	if cfg.ReliabilityPublishInterval > 0 {
+		go analyticsService.RunReliabilityPublisher(ctx, cfg.ReliabilityPublishInterval, cfg.ReliabilityWindow)
+	}
+
+	// Initialize Idempotency-Key storage and its expiry job
+	idempotencyService := application.NewIdempotencyService(
+		repository.NewGormIdempotencyRepository(db),
+		cfg.IdempotencyKeyTTL,
+		log,
+	)
+	go idempotencyService.RunRetention(ctx, time.Hour)
 
 	// Initialize HTTP handlers
-	bookingHandler := handler.NewBookingHandler(bookingService)
+	bookingHandler := handler.NewBookingHandler(bookingService, idempotencyService)
 	petHandler := handler.NewPetHandler(petService)
+	savedAddressHandler := handler.NewSavedAddressHandler(savedAddressService)
+	seriesHandler := handler.NewSeriesHandler(seriesService)
 	photoHandler := handler.NewPhotoHandler(photoService)
+	trackingHandler := handler.NewTrackingHandler(trackingService)
 
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
@@ -151,11 +331,27 @@ func main() {
 	// Register routes
 	bookingHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
 	petHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	savedAddressHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	seriesHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
 	photoHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	trackingHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
 
 	// Register admin handler routes
 	adminBookingHandler := handler.NewAdminBookingHandler(bookingService)
 	adminBookingHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	adminAnalyticsHandler := handler.NewAdminAnalyticsHandler(analyticsService)
+	adminAnalyticsHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	exportService := application.NewExportService(repository.NewGormBookingExportRepository(db), log)
+	adminExportHandler := handler.NewAdminExportHandler(exportService, log)
+	adminExportHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	zoneHandler := handler.NewZoneHandler(serviceAreaService)
+	zoneHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	runnerCapabilityHandler := handler.NewRunnerCapabilityHandler(capabilityService)
+	runnerCapabilityHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	if dispatchService != nil {
+		offerHandler := handler.NewOfferHandler(dispatchService)
+		offerHandler.RegisterRoutes(&router.RouterGroup, jwtManager)
+	}
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -0,0 +1,36 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCrateRules_RuleVersionAndAppliedRulesAreStored checks that a booking keeps the
+// crate rules it was created under. Rule evaluation itself is unit-tested in
+// internal/domain/booking.
+func TestCrateRules_RuleVersionAndAppliedRulesAreStored(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	req := vetVisitRequest(0)
+	req.PetSpec = dto.PetSpecDTO{PetType: "dog", Breed: "Pug", Name: "Mochi", WeightKg: 8}
+	bk, err := stack.Service.CreateBooking(ctx, uuid.New(), req)
+	require.NoError(t, err)
+
+	stored, err := stack.Service.GetBooking(ctx, bk.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookingDomain.DefaultCrateRules().Version, stored.CrateReq.RuleVersion)
+	assert.Equal(t, []string{"brachycephalic-dog"}, stored.CrateReq.AppliedRules)
+	assert.Equal(t, []string{"brachycephalic_care"}, stored.CrateReq.RunnerSkills)
+}
@@ -0,0 +1,101 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingEvents "github.com/Kilat-Pet-Delivery/service-booking/internal/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seedAcceptHistory records that runnerID accepted the booking acceptedAgo ago.
+func seedAcceptHistory(t *testing.T, db *gorm.DB, bookingID, runnerID uuid.UUID, acceptedAgo time.Duration) {
+	t.Helper()
+	require.NoError(t, db.Create(&repository.StatusHistoryModel{
+		ID:         uuid.New(),
+		BookingID:  bookingID,
+		FromStatus: "requested",
+		ToStatus:   "accepted",
+		ChangedBy:  &runnerID,
+		CreatedAt:  time.Now().UTC().Add(-acceptedAgo),
+	}).Error)
+}
+
+// TestDeclineAnalytics_ReportsReasonsRepeatDeclinesAndRunnerRates declines one booking
+// twice by two runners and checks the decline summary and per-runner reliability.
+// A system release of a third runner must not count as a decline.
+func TestDeclineAnalytics_ReportsReasonsRepeatDeclinesAndRunnerRates(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	stack := setupDeclineStack(t, infra.DB)
+	bookingID, firstRunner, secondRunner := uuid.New(), uuid.New(), uuid.New()
+
+	seedAcceptedBooking(t, infra.DB, bookingID, uuid.New(), firstRunner)
+	seedAcceptHistory(t, infra.DB, bookingID, firstRunner, 10*time.Minute)
+	w := doDeclineRequest(t, stack.Router, bookingID, runnerToken(t, stack.JWTManager, firstRunner), "too_far")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Second runner picks the booking up and declines it too.
+	require.NoError(t, infra.DB.Model(&repository.BookingModel{}).Where("id = ?", bookingID).
+		Updates(map[string]interface{}{
+			"status":    "accepted",
+			"runner_id": secondRunner,
+			"version":   gorm.Expr("version + 1"),
+		}).Error)
+	seedAcceptHistory(t, infra.DB, bookingID, secondRunner, 2*time.Minute)
+	w = doDeclineRequest(t, stack.Router, bookingID, runnerToken(t, stack.JWTManager, secondRunner), "too_far")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// A release the service made for a suspended runner is not the runner declining.
+	suspended := uuid.New()
+	seedAcceptHistory(t, infra.DB, bookingID, suspended, time.Minute)
+	require.NoError(t, infra.DB.Create(&repository.DeclineReasonModel{
+		ID:         uuid.New(),
+		BookingID:  bookingID,
+		RunnerID:   suspended,
+		Reason:     bookingEvents.ReleaseReasonRunnerSuspended,
+		DeclinedAt: time.Now().UTC(),
+	}).Error)
+
+	logger, _ := zap.NewDevelopment()
+	svc := application.NewAnalyticsService(repository.NewGormAnalyticsRepository(infra.DB), nil, false, logger)
+	ctx := context.Background()
+
+	summary, err := svc.GetDeclineAnalytics(ctx, application.DeclineAnalyticsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), summary.TotalDeclines)
+	require.NotNil(t, summary.AvgAcceptToDeclineSec)
+	assert.InDelta(t, 360, *summary.AvgAcceptToDeclineSec, 30)
+	require.Len(t, summary.TopReasons, 1)
+	assert.Equal(t, "too_far", summary.TopReasons[0].Reason)
+	assert.Equal(t, int64(2), summary.TopReasons[0].Runners)
+	require.Len(t, summary.MultiDeclineBookings, 1)
+	assert.Equal(t, bookingID, summary.MultiDeclineBookings[0].BookingID)
+
+	reliability, err := svc.GetRunnerReliability(ctx, firstRunner, application.DeclineAnalyticsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reliability.Accepted)
+	assert.Equal(t, int64(1), reliability.Declined)
+	assert.InDelta(t, 1.0, reliability.DeclineRate, 1e-9)
+	assert.Nil(t, reliability.Score, "score needs a minimum sample")
+
+	released, err := svc.GetRunnerReliability(ctx, suspended, application.DeclineAnalyticsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), released.Accepted)
+	assert.Equal(t, int64(0), released.Declined)
+
+	runners, err := svc.ListRunnerReliability(ctx, application.DeclineAnalyticsQuery{})
+	require.NoError(t, err)
+	assert.Len(t, runners, 3)
+}
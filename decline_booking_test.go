@@ -13,15 +13,12 @@ import (
 	"time"
 
 	"github.com/Kilat-Pet-Delivery/lib-common/auth"
-	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
-	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -40,17 +37,12 @@ func setupDeclineStack(t *testing.T, db *gorm.DB) *declineTestStack {
 	// Auto-migrate the decline reasons table for tests.
 	require.NoError(t, db.AutoMigrate(&repository.DeclineReasonModel{}))
 
-	logger, _ := zap.NewDevelopment()
 	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
 
-	bookingRepo := repository.NewGormBookingRepository(db)
-	declineRepo := repository.NewGormDeclineReasonRepository(db)
-	pricing := bookingDomain.NewStandardPricingStrategy()
+	// No Kafka producer — decline doesn't publish events.
+	svc := setupBookingStackWith(t, db, bookingStackConfig{}).Service
 
-	// Use a no-op Kafka producer (nil) — decline doesn't publish events.
-	svc := application.NewBookingService(bookingRepo, pricing, nil, logger, db, declineRepo)
-
-	bookingHandler := handler.NewBookingHandler(svc)
+	bookingHandler := handler.NewBookingHandler(svc, nil)
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -0,0 +1,307 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	dispatchDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/dispatch"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeClock is a clock the simulation advances by hand.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// fakeLocator places simulated runners at fixed positions.
+type fakeLocator struct {
+	runners map[uuid.UUID]dispatchDomain.Candidate
+}
+
+func (l *fakeLocator) FindAvailableNear(_ context.Context, lat, lng, radiusKm float64, _ time.Time) ([]dispatchDomain.Candidate, error) {
+	var found []dispatchDomain.Candidate
+	for _, c := range l.runners {
+		if bookingDomain.HaversineDistanceKm(lat, lng, c.Latitude, c.Longitude) <= radiusKm {
+			found = append(found, c)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].RunnerID.String() < found[j].RunnerID.String() })
+	return found, nil
+}
+
+// place puts a runner the given distance north of the vetVisitRequest pickup.
+func (l *fakeLocator) place(runnerID uuid.UUID, northKm, rating float64) {
+	pickup := vetVisitRequest(0).PickupAddress
+	l.runners[runnerID] = dispatchDomain.Candidate{
+		RunnerID:  runnerID,
+		Latitude:  pickup.Latitude + northKm/111.195,
+		Longitude: pickup.Longitude,
+		Rating:    rating,
+	}
+}
+
+// dispatchSimulation drives a DispatchService with a fake clock and fake runners.
+type dispatchSimulation struct {
+	Bookings *application.BookingService
+	Dispatch *application.DispatchService
+	Clock    *fakeClock
+	Runners  *fakeLocator
+	cleanup  func()
+}
+
+func setupDispatchSimulation(t *testing.T, infra *testInfra) *dispatchSimulation {
+	t.Helper()
+	return setupDispatchSimulationWith(t, infra, nil)
+}
+
+// setupDispatchSimulationWith is setupDispatchSimulation with configure applied to the
+// dispatch config, if set.
+func setupDispatchSimulationWith(t *testing.T, infra *testInfra, configure func(*application.DispatchConfig)) *dispatchSimulation {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.OfferModel{}, &repository.DispatchAttemptModel{}))
+
+	bookings, _, cleanup := setupCapabilityStack(t, infra, false)
+	clock := &fakeClock{now: time.Now().UTC().Truncate(time.Second)}
+	runners := &fakeLocator{runners: map[uuid.UUID]dispatchDomain.Candidate{}}
+	logger, _ := zap.NewDevelopment()
+	cfg := application.DefaultDispatchConfig()
+	cfg.OfferTimeout = 30 * time.Second
+	cfg.MaxRounds = 3
+	if configure != nil {
+		configure(&cfg)
+	}
+	dispatch := application.NewDispatchService(
+		repository.NewGormOfferRepository(infra.DB),
+		runners,
+		repository.NewGormRunnerAvailabilityRepository(infra.DB),
+		bookings,
+		cfg,
+		clock.Now,
+		logger,
+	)
+	return &dispatchSimulation{Bookings: bookings, Dispatch: dispatch, Clock: clock, Runners: runners, cleanup: cleanup}
+}
+
+func (s *dispatchSimulation) tick(t *testing.T) *application.DispatchTickResult {
+	t.Helper()
+	result, err := s.Dispatch.Tick(context.Background())
+	require.NoError(t, err)
+	return result
+}
+
+// openOffer returns the runner's single open offer.
+func (s *dispatchSimulation) openOffer(t *testing.T, runnerID uuid.UUID) application.OfferDTO {
+	t.Helper()
+	offers, err := s.Dispatch.ListRunnerOffers(context.Background(), runnerID)
+	require.NoError(t, err)
+	require.Len(t, offers, 1, "runner %s should hold one offer", runnerID)
+	return offers[0]
+}
+
+var (
+	simRunnerNear    = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	simRunnerRated   = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	simRunnerFar     = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	simRunnerTooFar  = uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+	simRunnerRefuser = uuid.MustParse("00000000-0000-0000-0000-00000000000e")
+)
+
+func TestDispatchSimulation_OfferRoundsUntilAccepted(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	sim := setupDispatchSimulation(t, infra)
+	defer sim.cleanup()
+
+	ctx := context.Background()
+	// Scores (lower is better): near 1 km, 3 stars: 1 - 9 = -8; rated 2 km, 5 stars:
+	// 2 - 15 = -13; far 8 km, 5 stars: 8 - 15 = -7; too far is outside the radius.
+	sim.Runners.place(simRunnerNear, 1, 3)
+	sim.Runners.place(simRunnerRated, 2, 5)
+	sim.Runners.place(simRunnerFar, 8, 5)
+	sim.Runners.place(simRunnerTooFar, 15, 5)
+
+	bk, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	first := sim.openOffer(t, simRunnerRated)
+	assert.Equal(t, 1, first.Round)
+	assert.InDelta(t, 2, first.DistanceKm, 0.01)
+	assert.Equal(t, 0, sim.tick(t).Offered, "one open offer per booking")
+
+	// The best runner lets the offer lapse; it moves on to the next one.
+	sim.Clock.Advance(29 * time.Second)
+	assert.Equal(t, 0, sim.tick(t).Expired)
+	sim.Clock.Advance(time.Second)
+	result := sim.tick(t)
+	assert.Equal(t, 1, result.Expired)
+	assert.Equal(t, 1, result.Offered)
+	_, err = sim.Dispatch.AcceptOffer(ctx, simRunnerRated, first.ID)
+	require.Error(t, err, "an expired offer cannot be accepted")
+
+	// The next runner turns it down; it is offered to the last one at once.
+	second := sim.openOffer(t, simRunnerNear)
+	_, err = sim.Dispatch.RejectOffer(ctx, simRunnerFar, second.ID)
+	require.Error(t, err, "only the runner it was offered to can answer")
+	_, err = sim.Dispatch.RejectOffer(ctx, simRunnerNear, second.ID)
+	require.NoError(t, err)
+
+	third := sim.openOffer(t, simRunnerFar)
+	assert.Equal(t, 3, third.Round)
+	accepted, err := sim.Dispatch.AcceptOffer(ctx, simRunnerFar, third.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusAccepted), accepted.Status)
+	require.NotNil(t, accepted.RunnerID)
+	assert.Equal(t, simRunnerFar, *accepted.RunnerID)
+
+	history, err := sim.Dispatch.ListBookingOffers(ctx, bk.ID)
+	require.NoError(t, err)
+	var states []string
+	for _, o := range history {
+		states = append(states, o.RunnerID.String()[35:]+":"+o.State)
+	}
+	assert.Equal(t, []string{"b:expired", "a:rejected", "c:accepted"}, states)
+}
+
+func TestDispatchSimulation_BusyRunnersRefusalsAndJobBoard(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	sim := setupDispatchSimulation(t, infra)
+	defer sim.cleanup()
+
+	ctx := context.Background()
+	// The refuser is nearest and best rated but has declined work recently.
+	sim.Runners.place(simRunnerRefuser, 0.5, 5)
+	sim.Runners.place(simRunnerNear, 1, 5)
+
+	earlier, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+	_, err = sim.Bookings.AcceptBooking(ctx, earlier.ID, simRunnerRefuser)
+	require.NoError(t, err)
+	_, err = sim.Bookings.DeclineBooking(ctx, earlier.ID, simRunnerRefuser, "changed my mind")
+	require.NoError(t, err)
+
+	// 0.5 - 15 + 2 = -12.5 loses to 1 - 15 = -14. The declined booking is never offered
+	// back to the refuser, and a runner holds one offer at a time.
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	offer := sim.openOffer(t, simRunnerNear)
+	assert.Equal(t, earlier.ID, offer.BookingID)
+
+	second, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	assert.Equal(t, second.ID, sim.openOffer(t, simRunnerRefuser).BookingID)
+
+	// A runner taking the booking from the job board withdraws the open offer.
+	_, err = sim.Bookings.AcceptBooking(ctx, earlier.ID, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Expired)
+	_, err = sim.Dispatch.AcceptOffer(ctx, simRunnerNear, offer.ID)
+	require.Error(t, err)
+
+	// After MaxRounds unanswered offers the booking stays on the job board only.
+	for i := 0; i < 3; i++ {
+		sim.Clock.Advance(30 * time.Second)
+		sim.tick(t)
+	}
+	history, err := sim.Dispatch.ListBookingOffers(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2, "only two runners are in range")
+	for _, o := range history {
+		assert.Equal(t, string(dispatchDomain.OfferExpired), o.State)
+	}
+	stored, err := sim.Bookings.GetBooking(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusRequested), stored.Status)
+}
+
+func TestDispatchSimulation_UndispatchableBookingDoesNotStarveOthers(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	sim := setupDispatchSimulationWith(t, infra, func(cfg *application.DispatchConfig) {
+		cfg.BatchSize = 1
+	})
+	defer sim.cleanup()
+
+	ctx := context.Background()
+	sim.Runners.place(simRunnerNear, 1, 5)
+
+	// The older booking is picked up 50 km away, out of every runner's reach.
+	remote := vetVisitRequest(0)
+	remote.PickupAddress.Latitude += 50 / 111.195
+	_, err := sim.Bookings.CreateBooking(ctx, uuid.New(), remote)
+	require.NoError(t, err)
+	near, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, sim.tick(t).Offered, "the oldest booking is tried first")
+	assert.Equal(t, 1, sim.tick(t).Offered, "the booking tried longest ago goes next")
+	assert.Equal(t, near.ID, sim.openOffer(t, simRunnerNear).BookingID)
+}
+
+func TestDispatchSimulation_WithdrawnOffersAreNotRefusals(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	sim := setupDispatchSimulation(t, infra)
+	defer sim.cleanup()
+
+	ctx := context.Background()
+	sim.Runners.place(simRunnerNear, 1, 5)
+	sim.Runners.place(simRunnerFar, 8, 5)
+
+	// The offered runner takes the booking from the job board instead of the offer.
+	taken, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	require.Equal(t, taken.ID, sim.openOffer(t, simRunnerNear).BookingID)
+	_, err = sim.Bookings.AcceptBooking(ctx, taken.ID, simRunnerNear)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Expired)
+
+	// The owner cancels while the booking is on offer.
+	ownerID := uuid.New()
+	cancelled, err := sim.Bookings.CreateBooking(ctx, ownerID, vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	require.Equal(t, cancelled.ID, sim.openOffer(t, simRunnerFar).BookingID)
+	_, err = sim.Bookings.CancelBooking(ctx, cancelled.ID, ownerID, "plans changed")
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Expired)
+
+	// Only an offer left to time out counts against the runner.
+	timedOut, err := sim.Bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.tick(t).Offered)
+	require.Equal(t, timedOut.ID, sim.openOffer(t, simRunnerFar).BookingID)
+	sim.Clock.Advance(30 * time.Second)
+	assert.Equal(t, 1, sim.tick(t).Expired)
+
+	refusals, err := repository.NewGormOfferRepository(infra.DB).
+		CountRecentRefusals(ctx, []uuid.UUID{simRunnerNear, simRunnerFar}, sim.Clock.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{simRunnerFar: 1}, refusals)
+}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupEligibilityStack wires a BookingService that checks the default eligibility policy.
+func setupEligibilityStack(t *testing.T, infra *testInfra) (*application.BookingService, *application.PetService, func()) {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.PetModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	petRepo := repository.NewGormPetRepository(infra.DB)
+	stack := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{
+			Eligibility: application.NewEligibilityChecker(bookingDomain.DefaultEligibilityPolicy(), petRepo, logger),
+		},
+	})
+	return stack.Service, application.NewPetService(petRepo, logger), stack.CleanupProducer
+}
+
+func TestEligibility_BlocksAndRecordsFindings(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, _, cleanup := setupEligibilityStack(t, infra)
+	defer cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	dogTrip := func(vaccinations ...dto.VaccinationDTO) application.CreateBookingRequest {
+		req := vetVisitRequest(0)
+		req.PetSpec = dto.PetSpecDTO{PetType: "dog", Breed: "French Bulldog", Name: "Bobo", WeightKg: 12, Vaccinations: vaccinations}
+		return req
+	}
+
+	_, err := bookings.CreateBooking(ctx, ownerID, dogTrip())
+	var ineligible *application.IneligiblePetError
+	require.ErrorAs(t, err, &ineligible)
+	require.Len(t, ineligible.Findings, 1)
+	assert.Equal(t, bookingDomain.EligibilityVaccinationMissing, ineligible.Findings[0].Code)
+	assert.Contains(t, err.Error(), "rabies vaccination is required")
+
+	expired := time.Now().UTC().AddDate(0, 0, -1)
+	_, err = bookings.CreateBooking(ctx, ownerID, dogTrip(dto.VaccinationDTO{
+		VaccineName: "Rabies", DateGiven: time.Now().UTC().AddDate(-1, 0, 0), ExpiresAt: &expired, Verified: true,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rabies vaccination is not valid")
+
+	bk, err := bookings.CreateBooking(ctx, ownerID, dogTrip(dto.VaccinationDTO{
+		VaccineName: "rabies", DateGiven: time.Now().UTC().AddDate(0, -2, 0),
+	}))
+	require.NoError(t, err)
+
+	// Warnings are stored with the booking for the runner.
+	stored, err := bookings.GetBooking(ctx, bk.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.Eligibility)
+	codes := make([]string, len(stored.Eligibility.Findings))
+	for i, f := range stored.Eligibility.Findings {
+		assert.Equal(t, bookingDomain.EligibilityWarning, f.Severity)
+		codes[i] = f.Code
+	}
+	assert.ElementsMatch(t, []string{
+		bookingDomain.EligibilityVaccinationUnverified,
+		bookingDomain.EligibilityBreedExtraHandling,
+	}, codes)
+}
+
+func TestEligibility_PetProfileAgeNeedsVetNote(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, pets, cleanup := setupEligibilityStack(t, infra)
+	defer cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	senior, err := pets.CreatePet(ctx, ownerID, application.CreatePetRequest{
+		Name: "Oyen", PetType: "cat", WeightKg: 5, AgeMonths: 200, Allergies: "chicken",
+	})
+	require.NoError(t, err)
+
+	req := vetVisitRequest(0)
+	req.PetSpec = dto.PetSpecDTO{}
+	req.PetID = &senior.ID
+	_, err = bookings.CreateBooking(ctx, ownerID, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs a vet note")
+
+	req.VetNote = "Fit to travel, Dr. Lim, 2 Oct"
+	bk, err := bookings.CreateBooking(ctx, ownerID, req)
+	require.NoError(t, err)
+	assert.Equal(t, "Oyen", bk.PetSpec.Name, "blank pet fields come from the profile")
+	require.NotNil(t, bk.Eligibility)
+	assert.Equal(t, req.VetNote, bk.Eligibility.VetNote)
+	var messages []string
+	for _, f := range bk.Eligibility.Findings {
+		messages = append(messages, f.Message)
+	}
+	assert.Contains(t, messages, "allergies: chicken")
+
+	// Another owner's pet profile cannot be used.
+	_, err = bookings.CreateBooking(ctx, uuid.New(), req)
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/geocoding"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupGeocodedBookingService wires a BookingService that verifies addresses against
+// the gazetteer in testdata.
+func setupGeocodedBookingService(t *testing.T, infra *testInfra) (*application.BookingService, func()) {
+	t.Helper()
+	gazetteer, err := geocoding.NewGazetteerGeocoder("testdata/gazetteer.json")
+	require.NoError(t, err)
+
+	logger, _ := zap.NewDevelopment()
+	stack := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{Addresses: application.NewAddressVerifier(gazetteer, 500, logger)},
+	})
+	return stack.Service, stack.CleanupProducer
+}
+
+func TestGeocoding_FillsAndVerifiesCoordinates(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupGeocodedBookingService(t, infra)
+	defer cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	// Abbreviated, oddly spaced addresses without coordinates are normalised and located.
+	req := vetVisitRequest(0)
+	req.PickupAddress.Line1 = "10  Jln Rumah"
+	req.PickupAddress.Latitude, req.PickupAddress.Longitude = 0, 0
+	req.DropoffAddress.Latitude, req.DropoffAddress.Longitude = 0, 0
+	bk, err := svc.CreateBooking(ctx, ownerID, req)
+	require.NoError(t, err)
+	assert.Equal(t, "10 Jalan Rumah", bk.PickupAddress.Line1)
+	assert.InDelta(t, 3.139, bk.PickupAddress.Latitude, 1e-9)
+	assert.InDelta(t, 101.72, bk.DropoffAddress.Longitude, 1e-9)
+
+	// Client coordinates close to the geocoded position are kept as given.
+	near := vetVisitRequest(0)
+	near.PickupAddress.Latitude += 0.001
+	bk, err = svc.CreateBooking(ctx, ownerID, near)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.140, bk.PickupAddress.Latitude, 1e-9)
+
+	// Coordinates a few kilometres off are rejected.
+	far := vetVisitRequest(0)
+	far.DropoffAddress.Latitude += 0.05
+	_, err = svc.CreateBooking(ctx, ownerID, far)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dropoff address coordinates")
+
+	// Stops are verified too, and unknown addresses are refused.
+	unknown := vetVisitRequest(0)
+	unknown.Stops = []application.StopRequest{
+		{Address: req.PickupAddress, Purpose: "grooming"},
+	}
+	unknown.Stops[0].Address.Line1 = "99 Jalan Tiada"
+	_, err = svc.CreateBooking(ctx, ownerID, unknown)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop 1 address could not be found")
+
+	unknown.Stops[0].Address.Line1 = "5 Lrg Grooming"
+	bk, err = svc.CreateBooking(ctx, ownerID, unknown)
+	require.NoError(t, err)
+	require.Len(t, bk.Stops, 1)
+	assert.Equal(t, "5 Lorong Grooming", bk.Stops[0].Address.Line1)
+	assert.InDelta(t, 3.148, bk.Stops[0].Address.Latitude, 1e-9)
+}
@@ -0,0 +1,247 @@
+//go:build integration
+
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupIdempotentRouter serves the booking routes with Idempotency-Key support.
+func setupIdempotentRouter(t *testing.T, infra *testInfra, ttl time.Duration) (*gin.Engine, *auth.JWTManager, func()) {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.IdempotencyKeyModel{}))
+
+	svc, cleanup := setupRunnerLoadService(t, infra, 0)
+	logger, _ := zap.NewDevelopment()
+	idempotency := application.NewIdempotencyService(repository.NewGormIdempotencyRepository(infra.DB), ttl, logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	handler.NewBookingHandler(svc, idempotency).RegisterRoutes(&router.RouterGroup, jwtManager)
+	return router, jwtManager, cleanup
+}
+
+// doIdempotentRequest is doJSONRequest with an Idempotency-Key header.
+func doIdempotentRequest(t *testing.T, router *gin.Engine, method, path, token, key string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(handler.IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func bookingIDFrom(t *testing.T, w *httptest.ResponseRecorder) uuid.UUID {
+	t.Helper()
+	var resp struct {
+		Data application.BookingDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data.ID
+}
+
+func TestIdempotency_CreateBookingRetryReplaysResponse(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	router, jwtManager, cleanup := setupIdempotentRouter(t, infra, time.Hour)
+	defer cleanup()
+
+	ownerID := uuid.New()
+	token, err := jwtManager.GenerateAccessToken(ownerID, "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	req := immediateRequest()
+
+	first := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "create-1", req)
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get(handler.IdempotentReplayedHeader))
+
+	retry := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "create-1", req)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(handler.IdempotentReplayedHeader))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+	var count int64
+	require.NoError(t, infra.DB.Model(&repository.BookingModel{}).Where("owner_id = ?", ownerID).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "the retry must not create a second booking")
+
+	// The same key with a different body is refused.
+	changed := immediateRequest()
+	changed.Notes = "different trip"
+	w := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "create-1", changed)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// Keys are per user, and a new key is a new request.
+	otherToken, err := jwtManager.GenerateAccessToken(uuid.New(), "other@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	w = doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", otherToken, "create-1", req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEqual(t, bookingIDFrom(t, first), bookingIDFrom(t, w))
+
+	w = doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "create-2", req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEqual(t, bookingIDFrom(t, first), bookingIDFrom(t, w))
+}
+
+func TestIdempotency_AcceptRetryDoesNotConflict(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	router, jwtManager, cleanup := setupIdempotentRouter(t, infra, time.Hour)
+	defer cleanup()
+
+	ownerToken, err := jwtManager.GenerateAccessToken(uuid.New(), "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	w := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", ownerToken, "create", immediateRequest())
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	path := "/api/v1/bookings/" + bookingIDFrom(t, w).String() + "/accept"
+	token := runnerToken(t, jwtManager, uuid.New())
+
+	first := doIdempotentRequest(t, router, http.MethodPost, path, token, "accept-1", nil)
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	retry := doIdempotentRequest(t, router, http.MethodPost, path, token, "accept-1", nil)
+	assert.Equal(t, http.StatusOK, retry.Code, "a retried accept gets the original success, not a 409")
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+	// Without the key the same retry is a genuine conflict.
+	w = doJSONRequest(t, router, http.MethodPost, path, token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotency_InFlightAndExpiredKeys(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	router, jwtManager, cleanup := setupIdempotentRouter(t, infra, time.Hour)
+	defer cleanup()
+
+	ownerID := uuid.New()
+	token, err := jwtManager.GenerateAccessToken(ownerID, "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	repo := repository.NewGormIdempotencyRepository(infra.DB)
+
+	// A reservation without a response means the first request is still running.
+	_, reserved, err := repo.Reserve(t.Context(), ownerID, "busy", "hash", now, now.Add(time.Hour), now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+	w := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "busy", immediateRequest())
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// An expired key is free to use again, whatever it was used for.
+	_, reserved, err = repo.Reserve(t.Context(), ownerID, "old", "hash", now.Add(-2*time.Hour), now.Add(-time.Hour), now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	require.True(t, reserved)
+	w = doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "old", immediateRequest())
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	deleted, err := repo.DeleteExpired(t.Context(), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+func TestIdempotency_PanicReleasesKeyAndLargeBodiesAreRejected(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.IdempotencyKeyModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	idempotency := application.NewIdempotencyService(repository.NewGormIdempotencyRepository(infra.DB), time.Hour, logger)
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	calls := 0
+	router.POST("/flaky", middleware.AuthMiddleware(jwtManager), handler.Idempotency(idempotency), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+
+	token, err := jwtManager.GenerateAccessToken(uuid.New(), "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+
+	// The panic leaves nothing reserved, so the retry runs instead of getting 409.
+	w := doIdempotentRequest(t, router, http.MethodPost, "/flaky", token, "k", gin.H{"a": 1})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	w = doIdempotentRequest(t, router, http.MethodPost, "/flaky", token, "k", gin.H{"a": 1})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 2, calls)
+
+	w = doIdempotentRequest(t, router, http.MethodPost, "/flaky", token, "big", gin.H{"a": strings.Repeat("x", 2<<20)})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_RoleRejectionsAndTakenOverReservations(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	router, jwtManager, cleanup := setupIdempotentRouter(t, infra, time.Hour)
+	defer cleanup()
+
+	// A runner may not create bookings; the 403 is not stored under the key.
+	runnerID := uuid.New()
+	token, err := jwtManager.GenerateAccessToken(runnerID, "runner@test.com", auth.RoleRunner)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		w := doIdempotentRequest(t, router, http.MethodPost, "/api/v1/bookings", token, "role", immediateRequest())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get(handler.IdempotentReplayedHeader))
+	}
+	var stored int64
+	require.NoError(t, infra.DB.Model(&repository.IdempotencyKeyModel{}).Where("user_id = ?", runnerID).Count(&stored).Error)
+	assert.Zero(t, stored)
+
+	// A request that outlives the pending timeout loses its key to the retry, and can
+	// then neither store its response nor release the retry's reservation.
+	logger, _ := zap.NewDevelopment()
+	repo := repository.NewGormIdempotencyRepository(infra.DB)
+	svc := application.NewIdempotencyService(repo, time.Hour, logger)
+	ownerID := uuid.New()
+	slowAt := time.Now().UTC().Add(-2 * time.Minute).Truncate(time.Microsecond)
+	_, reserved, err := repo.Reserve(t.Context(), ownerID, "slow", "hash", slowAt, slowAt.Add(time.Hour), slowAt.Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+	slow := &application.IdempotencyReservation{UserID: ownerID, Key: "slow", RequestHash: "hash", ReservedAt: slowAt}
+
+	retry, replay, err := svc.Begin(t.Context(), ownerID, "slow", "hash")
+	require.NoError(t, err)
+	require.Nil(t, replay)
+	require.NotNil(t, retry)
+
+	completed, err := repo.Complete(t.Context(), ownerID, "slow", slow.RequestHash, slow.ReservedAt, http.StatusCreated, "application/json", []byte(`{}`))
+	require.NoError(t, err)
+	assert.False(t, completed)
+	svc.Abandon(t.Context(), slow)
+
+	svc.Finish(t.Context(), retry, application.IdempotentResponse{StatusCode: http.StatusOK, ContentType: "application/json", Body: []byte(`{"retry":true}`)})
+	_, replay, err = svc.Begin(t.Context(), ownerID, "slow", "hash")
+	require.NoError(t, err)
+	require.NotNil(t, replay)
+	assert.Equal(t, http.StatusOK, replay.StatusCode)
+	assert.JSONEq(t, `{"retry":true}`, string(replay.Body))
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/geocoding"
+	"go.uber.org/zap"
+)
+
+// AddressVerifier normalises booking addresses with a geocoder and fills in or
+// verifies their coordinates.
+type AddressVerifier struct {
+	geocoder     geocoding.Geocoder
+	maxMismatchM float64
+	logger       *zap.Logger
+}
+
+// NewAddressVerifier creates an AddressVerifier. Client coordinates further than
+// maxMismatchM metres from the geocoded position are rejected.
+func NewAddressVerifier(geocoder geocoding.Geocoder, maxMismatchM float64, logger *zap.Logger) *AddressVerifier {
+	return &AddressVerifier{geocoder: geocoder, maxMismatchM: maxMismatchM, logger: logger}
+}
+
+// verifyRequest verifies the pickup, every stop and the dropoff of a booking request
+// and returns the request with the normalised addresses.
+func (v *AddressVerifier) verifyRequest(ctx context.Context, req CreateBookingRequest) (CreateBookingRequest, error) {
+	var err error
+	if req.PickupAddress, err = v.verify(ctx, "pickup address", req.PickupAddress); err != nil {
+		return req, err
+	}
+	if req.DropoffAddress, err = v.verify(ctx, "dropoff address", req.DropoffAddress); err != nil {
+		return req, err
+	}
+	stops := make([]StopRequest, len(req.Stops))
+	for i, st := range req.Stops {
+		if st.Address, err = v.verify(ctx, fmt.Sprintf("stop %d address", i+1), st.Address); err != nil {
+			return req, err
+		}
+		stops[i] = st
+	}
+	req.Stops = stops
+	return req, nil
+}
+
+// verify geocodes one address. Missing coordinates (0,0) are filled in from the
+// geocoded position; client coordinates within the allowed distance are kept, as
+// they usually pinpoint the entrance better than the geocoder.
+func (v *AddressVerifier) verify(ctx context.Context, field string, addr dto.AddressDTO) (dto.AddressDTO, error) {
+	if addr.Line1 == "" {
+		// Left to the aggregate, which reports missing addresses.
+		return addr, nil
+	}
+	hasCoordinates := addr.Latitude != 0 || addr.Longitude != 0
+
+	geocoded, err := v.geocoder.Geocode(ctx, addr)
+	if errors.Is(err, geocoding.ErrNotFound) {
+		return addr, domain.NewValidationError(fmt.Sprintf("%s could not be found", field))
+	}
+	if err != nil {
+		if !hasCoordinates {
+			return addr, fmt.Errorf("failed to geocode %s: %w", field, err)
+		}
+		// The geocoder is down; trust the client rather than refuse every booking.
+		v.logger.Warn("geocoding failed, using client coordinates",
+			zap.String("field", field),
+			zap.Error(err),
+		)
+		return geocoding.NormalizeAddress(addr), nil
+	}
+
+	if !hasCoordinates {
+		return geocoded, nil
+	}
+	mismatchM := bookingDomain.HaversineDistanceKm(
+		addr.Latitude, addr.Longitude, geocoded.Latitude, geocoded.Longitude,
+	) * 1000
+	if mismatchM > v.maxMismatchM {
+		return addr, domain.NewValidationError(fmt.Sprintf(
+			"%s coordinates are %.0f m from the address (at most %.0f m allowed)",
+			field, mismatchM, v.maxMismatchM,
+		))
+	}
+	geocoded.Latitude, geocoded.Longitude = addr.Latitude, addr.Longitude
+	return geocoded, nil
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminOverrideRequest carries the mandatory reason for an admin override.
+type AdminOverrideRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// AdminReassignRequest is the request body for reassigning a booking to another runner.
+type AdminReassignRequest struct {
+	RunnerID uuid.UUID `json:"runner_id" binding:"required"`
+	Reason   string    `json:"reason" binding:"required,max=500"`
+}
+
+// AdminForceCompleteRequest is the request body for force-completing a booking.
+type AdminForceCompleteRequest struct {
+	FinalPriceCents *int64 `json:"final_price_cents" binding:"required"`
+	Reason          string `json:"reason" binding:"required,max=500"`
+}
+
+// AdminForceCancel cancels a booking from any non-terminal state (admin).
+func (s *BookingService) AdminForceCancel(ctx context.Context, adminID, bookingID uuid.UUID, reason string) (*BookingDTO, error) {
+	return s.applyAdminOverride(ctx, adminID, bookingID, bookingDomain.AdminActionForceCancel, reason,
+		func(bk *bookingDomain.Booking) error { return bk.AdminForceCancel(reason) })
+}
+
+// AdminReassign hands a requested or accepted booking to a specific runner (admin).
+func (s *BookingService) AdminReassign(ctx context.Context, adminID, bookingID, runnerID uuid.UUID, reason string) (*BookingDTO, error) {
+	return s.applyAdminOverride(ctx, adminID, bookingID, bookingDomain.AdminActionReassign, reason,
+		func(bk *bookingDomain.Booking) error { return bk.AdminReassign(runnerID) })
+}
+
+// AdminRevertToAccepted moves an in-progress booking back to accepted (admin).
+func (s *BookingService) AdminRevertToAccepted(ctx context.Context, adminID, bookingID uuid.UUID, reason string) (*BookingDTO, error) {
+	return s.applyAdminOverride(ctx, adminID, bookingID, bookingDomain.AdminActionRevertToAccepted, reason,
+		func(bk *bookingDomain.Booking) error { return bk.AdminRevertToAccepted() })
+}
+
+// AdminForceComplete completes an in-progress or delivered booking with an adjusted
+// final price (admin).
+func (s *BookingService) AdminForceComplete(ctx context.Context, adminID, bookingID uuid.UUID, finalPriceCents int64, reason string) (*BookingDTO, error) {
+	return s.applyAdminOverride(ctx, adminID, bookingID, bookingDomain.AdminActionForceComplete, reason,
+		func(bk *bookingDomain.Booking) error { return bk.AdminForceComplete(finalPriceCents) })
+}
+
+// applyAdminOverride runs an admin action against a booking, persisting the booking
+// together with an audited status history entry, then emits the override event.
+func (s *BookingService) applyAdminOverride(
+	ctx context.Context,
+	adminID, bookingID uuid.UUID,
+	action bookingDomain.AdminAction,
+	reason string,
+	apply func(bk *bookingDomain.Booking) error,
+) (*BookingDTO, error) {
+	if reason == "" {
+		return nil, domain.NewValidationError("reason is required for admin overrides")
+	}
+
+	bk, err := s.repo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromStatus := bk.Status()
+	previousRunnerID := bk.RunnerID()
+
+	if err := apply(bk); err != nil {
+		return nil, err
+	}
+	bk.IncrementVersion()
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txBookingRepo := repository.NewGormBookingRepository(tx)
+		if err := txBookingRepo.Update(ctx, bk); err != nil {
+			return err
+		}
+		return s.historyRepo.RecordTransition(ctx, tx, repository.StatusHistoryEntry{
+			BookingID:   bk.ID(),
+			FromStatus:  string(fromStatus),
+			ToStatus:    string(bk.Status()),
+			ChangedBy:   &adminID,
+			Reason:      reason,
+			AdminAction: string(action),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	evt := BookingAdminOverrideEvent{
+		BookingID:        bk.ID(),
+		BookingNumber:    bk.BookingNumber(),
+		OwnerID:          bk.OwnerID(),
+		AdminID:          adminID,
+		Action:           string(action),
+		FromStatus:       string(fromStatus),
+		ToStatus:         string(bk.Status()),
+		PreviousRunnerID: previousRunnerID,
+		RunnerID:         bk.RunnerID(),
+		FinalPriceCents:  bk.FinalPriceCents(),
+		Reason:           reason,
+		OccurredAt:       now,
+	}
+	s.publishEvent(ctx, events.TopicBookingEvents, BookingAdminOverride, bk.ID().String(), evt)
+
+	// Terminal overrides also emit the regular lifecycle event so payment and
+	// notification services settle the booking exactly as for a normal flow.
+	switch action {
+	case bookingDomain.AdminActionForceCancel:
+		s.publishEvent(ctx, events.TopicBookingEvents, events.BookingCancelled, bk.ID().String(), events.BookingCancelledEvent{
+			BookingID:     bk.ID(),
+			BookingNumber: bk.BookingNumber(),
+			CancelledBy:   adminID,
+			Reason:        reason,
+			OccurredAt:    now,
+		})
+		s.cascadeCancelReturnLeg(ctx, bk, fromStatus, adminID)
+	case bookingDomain.AdminActionForceComplete:
+		var runnerID uuid.UUID
+		if bk.RunnerID() != nil {
+			runnerID = *bk.RunnerID()
+		}
+		s.publishEvent(ctx, events.TopicBookingEvents, events.BookingCompleted, bk.ID().String(), events.BookingCompletedEvent{
+			BookingID:     bk.ID(),
+			BookingNumber: bk.BookingNumber(),
+			RunnerID:      runnerID,
+			OwnerID:       bk.OwnerID(),
+			FinalPrice:    *bk.FinalPriceCents(),
+			Currency:      bk.Currency(),
+			OccurredAt:    now,
+		})
+	}
+
+	result := toBookingDTO(bk)
+	updateType := realtime.UpdateStatusChanged
+	if action == bookingDomain.AdminActionReassign {
+		updateType = realtime.UpdateRunnerAssigned
+	}
+	s.notifyUpdate(ctx, updateType, bk, result)
+	return &result, nil
+}
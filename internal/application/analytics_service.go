@@ -0,0 +1,273 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-common/kafka"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultAnalyticsRange is the series window when no date range is given.
+	defaultAnalyticsRange = 30 * 24 * time.Hour
+	// maxAnalyticsRange bounds the series window to keep the live queries cheap.
+	maxAnalyticsRange = 366 * 24 * time.Hour
+	// rollupRefreshWindow is how many recent days each periodic rollup refresh recomputes.
+	rollupRefreshWindow = 3 * 24 * time.Hour
+)
+
+// AnalyticsService serves the admin booking analytics.
+type AnalyticsService struct {
+	repo      *repository.GormAnalyticsRepository
+	producer  *kafka.Producer
+	useRollup bool
+	logger    *zap.Logger
+}
+
+// NewAnalyticsService creates a new AnalyticsService. When useRollup is set, series
+// are read from the daily rollup table, which RunRollupRefresh keeps up to date.
+func NewAnalyticsService(repo *repository.GormAnalyticsRepository, producer *kafka.Producer, useRollup bool, logger *zap.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		repo:      repo,
+		producer:  producer,
+		useRollup: useRollup,
+		logger:    logger,
+	}
+}
+
+// BookingSeriesQuery holds the query parameters for the booking time series.
+type BookingSeriesQuery struct {
+	Granularity string `form:"granularity"` // day (default) or week
+	From        string `form:"from"`        // RFC3339 or YYYY-MM-DD, inclusive
+	To          string `form:"to"`          // RFC3339 or YYYY-MM-DD, exclusive
+	PetType     string `form:"pet_type"`
+}
+
+// BookingMetricsDTO holds booking activity for one bucket or the whole range.
+// Durations are in seconds; averages are omitted when there is nothing to average.
+type BookingMetricsDTO struct {
+	Created                int64    `json:"created"`
+	Completed              int64    `json:"completed"`
+	Cancelled              int64    `json:"cancelled"`
+	GMVCents               int64    `json:"gmv_cents"`
+	AvgPriceCents          *int64   `json:"avg_price_cents,omitempty"`
+	AvgTimeToAcceptSec     *float64 `json:"avg_time_to_accept_sec,omitempty"`
+	AvgPickupToDeliverySec *float64 `json:"avg_pickup_to_delivery_sec,omitempty"`
+}
+
+// BookingSeriesPointDTO is one bucket of the booking time series.
+type BookingSeriesPointDTO struct {
+	Bucket time.Time `json:"bucket"`
+	BookingMetricsDTO
+}
+
+// BookingSeriesDTO is the booking time series for the admin dashboard.
+type BookingSeriesDTO struct {
+	Granularity string                  `json:"granularity"`
+	From        time.Time               `json:"from"`
+	To          time.Time               `json:"to"`
+	PetType     string                  `json:"pet_type,omitempty"`
+	Source      string                  `json:"source"` // live or rollup
+	Points      []BookingSeriesPointDTO `json:"points"`
+	Totals      BookingMetricsDTO       `json:"totals"`
+}
+
+// GetBookingSeries returns daily or weekly created/completed/cancelled counts, GMV,
+// average price, time to accept and pickup-to-delivery duration.
+func (s *AnalyticsService) GetBookingSeries(ctx context.Context, q BookingSeriesQuery) (*BookingSeriesDTO, error) {
+	filter, err := q.toFilter(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	source := "live"
+	var rows []repository.SeriesRow
+	if s.useRollup {
+		source = "rollup"
+		rows, err = s.repo.RollupSeries(ctx, filter)
+	} else {
+		rows, err = s.repo.LiveSeries(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Fold pet types together and lay the rows out on a gap-free bucket axis.
+	byBucket := make(map[time.Time]*repository.SeriesRow)
+	var total repository.SeriesRow
+	for _, row := range rows {
+		bucket := row.Bucket.UTC()
+		acc, ok := byBucket[bucket]
+		if !ok {
+			acc = &repository.SeriesRow{Bucket: bucket}
+			byBucket[bucket] = acc
+		}
+		acc.Add(row)
+		total.Add(row)
+	}
+
+	result := &BookingSeriesDTO{
+		Granularity: filter.Granularity,
+		From:        filter.From,
+		To:          filter.To,
+		PetType:     filter.PetType,
+		Source:      source,
+		Totals:      toBookingMetricsDTO(total),
+	}
+	for _, bucket := range seriesBuckets(filter) {
+		point := BookingSeriesPointDTO{Bucket: bucket}
+		if row, ok := byBucket[bucket]; ok {
+			point.BookingMetricsDTO = toBookingMetricsDTO(*row)
+			delete(byBucket, bucket)
+		}
+		result.Points = append(result.Points, point)
+	}
+	// Rows outside the generated axis (should not happen) are kept rather than dropped.
+	for bucket, row := range byBucket {
+		result.Points = append(result.Points, BookingSeriesPointDTO{Bucket: bucket, BookingMetricsDTO: toBookingMetricsDTO(*row)})
+	}
+	sort.Slice(result.Points, func(i, j int) bool { return result.Points[i].Bucket.Before(result.Points[j].Bucket) })
+
+	return result, nil
+}
+
+// RefreshRollups recomputes the daily rollups for the given range. It does nothing
+// while another replica is refreshing.
+func (s *AnalyticsService) RefreshRollups(ctx context.Context, from, to time.Time) error {
+	_, err := s.repo.RefreshRollups(ctx, from, to)
+	return err
+}
+
+// RunRollupRefresh backfills the days missing from the daily rollups, then recomputes
+// the most recent days every interval until ctx is cancelled. Refreshes are serialised
+// across replicas by an advisory lock; a replica that finds it held skips that run.
+func (s *AnalyticsService) RunRollupRefresh(ctx context.Context, interval time.Duration) {
+	tomorrow := func() time.Time { return time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour) }
+
+	if from, err := s.backfillStart(ctx); err != nil {
+		s.logger.Error("failed to find rollup backfill start", zap.Error(err))
+	} else if from != nil {
+		if refreshed, err := s.repo.RefreshRollups(ctx, *from, tomorrow()); err != nil {
+			s.logger.Error("failed to backfill booking rollups", zap.Error(err))
+		} else if !refreshed {
+			s.logger.Info("booking rollup backfill skipped, another replica is refreshing")
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			to := tomorrow()
+			if _, err := s.repo.RefreshRollups(ctx, to.Add(-rollupRefreshWindow), to); err != nil {
+				s.logger.Error("failed to refresh booking rollups", zap.Error(err))
+			}
+		}
+	}
+}
+
+// backfillStart returns where the startup backfill begins: the latest day already
+// rolled up, which may have been partial, or the oldest booking when the table is
+// empty. It returns nil when there are no bookings.
+func (s *AnalyticsService) backfillStart(ctx context.Context) (*time.Time, error) {
+	latest, err := s.repo.LatestRollupDay(ctx)
+	if err != nil || latest != nil {
+		return latest, err
+	}
+	return s.repo.EarliestBookingAt(ctx)
+}
+
+// toFilter validates the query and resolves defaults relative to now.
+func (q BookingSeriesQuery) toFilter(now time.Time) (repository.SeriesFilter, error) {
+	f := repository.SeriesFilter{Granularity: q.Granularity}
+	switch f.Granularity {
+	case "":
+		f.Granularity = "day"
+	case "day", "week":
+	default:
+		return f, domain.NewValidationError("granularity must be 'day' or 'week'")
+	}
+
+	from, err := parseListTime("from", q.From)
+	if err != nil {
+		return f, err
+	}
+	to, err := parseListTime("to", q.To)
+	if err != nil {
+		return f, err
+	}
+	if to == nil {
+		end := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		to = &end
+	}
+	if from == nil {
+		start := to.Add(-defaultAnalyticsRange)
+		from = &start
+	}
+	if !from.Before(*to) {
+		return f, domain.NewValidationError("from must be before to")
+	}
+	if to.Sub(*from) > maxAnalyticsRange {
+		return f, domain.NewValidationError(fmt.Sprintf("date range must not exceed %d days", int(maxAnalyticsRange/(24*time.Hour))))
+	}
+	f.From, f.To = from.UTC(), to.UTC()
+
+	if q.PetType != "" {
+		if !bookingDomain.PetType(q.PetType).IsValid() {
+			return f, domain.NewValidationError(fmt.Sprintf("invalid pet_type: %s", q.PetType))
+		}
+		f.PetType = q.PetType
+	}
+
+	return f, nil
+}
+
+// seriesBuckets lists the bucket starts covering [From, To), matching Postgres
+// date_trunc (weeks start on Monday).
+func seriesBuckets(f repository.SeriesFilter) []time.Time {
+	start := f.From.Truncate(24 * time.Hour)
+	step := 24 * time.Hour
+	if f.Granularity == "week" {
+		offset := (int(start.Weekday()) + 6) % 7 // days since Monday
+		start = start.AddDate(0, 0, -offset)
+		step = 7 * 24 * time.Hour
+	}
+
+	var buckets []time.Time
+	for b := start; b.Before(f.To); b = b.Add(step) {
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+func toBookingMetricsDTO(row repository.SeriesRow) BookingMetricsDTO {
+	m := BookingMetricsDTO{
+		Created:   row.Created,
+		Completed: row.Completed,
+		Cancelled: row.Cancelled,
+		GMVCents:  row.GMVCents,
+	}
+	if row.Completed > 0 {
+		avg := row.GMVCents / row.Completed
+		m.AvgPriceCents = &avg
+	}
+	if row.AcceptCount > 0 {
+		avg := row.AcceptSeconds / float64(row.AcceptCount)
+		m.AvgTimeToAcceptSec = &avg
+	}
+	if row.DeliveryCount > 0 {
+		avg := row.DeliverySeconds / float64(row.DeliveryCount)
+		m.AvgPickupToDeliverySec = &avg
+	}
+	return m
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAvailableRadiusKm = 10
+	maxAvailableRadiusKm     = 50
+
+	// maxAvailableCandidates caps how many nearby bookings are loaded before those
+	// the runner cannot carry are filtered out.
+	maxAvailableCandidates = 500
+)
+
+// AvailableBookingsQuery holds the query parameters of the runner job board.
+type AvailableBookingsQuery struct {
+	Latitude  *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Longitude *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm  float64  `form:"radius_km"`
+	Limit     int      `form:"limit"`
+}
+
+// AvailableBookingDTO is an open booking as shown on the runner job board.
+type AvailableBookingDTO struct {
+	BookingDTO
+	PickupDistanceKm     float64 `json:"pickup_distance_km"`
+	EstimatedPayoutCents int64   `json:"estimated_payout_cents"`
+}
+
+// ListAvailableBookings returns requested bookings whose pickup is within the radius
+// of the runner's location, nearest first. Bookings the runner has declined or cannot
+// carry are left out.
+func (s *BookingService) ListAvailableBookings(ctx context.Context, runnerID uuid.UUID, q AvailableBookingsQuery) ([]AvailableBookingDTO, error) {
+	if q.Latitude == nil || q.Longitude == nil {
+		return nil, domain.NewValidationError("lat and lng are required")
+	}
+	radius := q.RadiusKm
+	if radius <= 0 {
+		radius = defaultAvailableRadiusKm
+	}
+	if radius > maxAvailableRadiusKm {
+		return nil, domain.NewValidationError(fmt.Sprintf("radius_km must be at most %d", maxAvailableRadiusKm))
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	canCarry := func(bookingDomain.CrateRequirement) bool { return true }
+	candidates := limit
+	if s.capabilities != nil {
+		var err error
+		if canCarry, err = s.capabilities.carryFilter(ctx, runnerID); err != nil {
+			return nil, err
+		}
+		candidates = maxAvailableCandidates
+	}
+
+	nearby, err := s.repo.FindRequestedNear(ctx, bookingDomain.NearbyQuery{
+		Latitude:          *q.Latitude,
+		Longitude:         *q.Longitude,
+		RadiusKm:          radius,
+		ExcludeDeclinedBy: &runnerID,
+		Limit:             candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]AvailableBookingDTO, 0, limit)
+	for _, n := range nearby {
+		if !canCarry(n.Booking.CrateReq()) {
+			continue
+		}
+		dtos = append(dtos, AvailableBookingDTO{
+			BookingDTO:           toBookingDTO(n.Booking),
+			PickupDistanceKm:     n.PickupDistanceKm,
+			EstimatedPayoutCents: s.runnerPayoutCents(n.Booking),
+		})
+		if len(dtos) == limit {
+			break
+		}
+	}
+	return dtos, nil
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// minSearchLength is the shortest query the trigram index can serve.
+	minSearchLength = 3
+)
+
+// BookingListQuery holds the query parameters for a cursor-paginated booking listing.
+type BookingListQuery struct {
+	Cursor        string `form:"cursor"`
+	Limit         int    `form:"limit"`
+	Status        string `form:"status"` // comma-separated
+	From          string `form:"from"`   // RFC3339 or YYYY-MM-DD, inclusive
+	To            string `form:"to"`     // RFC3339 or YYYY-MM-DD, exclusive
+	PetType       string `form:"pet_type"`
+	Scheduled     *bool  `form:"scheduled"`
+	SeriesID      string `form:"series_id"`
+	ParentID      string `form:"parent_id"`
+	MinPriceCents *int64 `form:"min_price_cents"`
+	MaxPriceCents *int64 `form:"max_price_cents"`
+}
+
+// BookingSearchQuery holds the query parameters for the admin booking search.
+// The structured filters of BookingListQuery can be combined with the text query.
+type BookingSearchQuery struct {
+	Q string `form:"q" binding:"required"`
+	BookingListQuery
+}
+
+// BookingCursorPage is one page of a cursor-paginated booking listing.
+type BookingCursorPage struct {
+	Items      []BookingDTO `json:"items"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	HasMore    bool         `json:"has_more"`
+}
+
+// ListOwnerBookings returns a cursor-paginated, filtered page of an owner's bookings.
+func (s *BookingService) ListOwnerBookings(ctx context.Context, ownerID uuid.UUID, q BookingListQuery) (*BookingCursorPage, error) {
+	query, err := q.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	query.OwnerID = &ownerID
+	return s.listBookings(ctx, query)
+}
+
+// ListRunnerBookings returns a cursor-paginated, filtered page of a runner's bookings.
+func (s *BookingService) ListRunnerBookings(ctx context.Context, runnerID uuid.UUID, q BookingListQuery) (*BookingCursorPage, error) {
+	query, err := q.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	query.RunnerID = &runnerID
+	return s.listBookings(ctx, query)
+}
+
+// ListAllBookingsByCursor returns a cursor-paginated, filtered page of all bookings (admin).
+func (s *BookingService) ListAllBookingsByCursor(ctx context.Context, q BookingListQuery) (*BookingCursorPage, error) {
+	query, err := q.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	return s.listBookings(ctx, query)
+}
+
+// SearchBookings finds bookings whose number, owner or runner ID, pet name or
+// pickup/dropoff address lines contain the query text (admin).
+func (s *BookingService) SearchBookings(ctx context.Context, q BookingSearchQuery) (*BookingCursorPage, error) {
+	text := strings.TrimSpace(q.Q)
+	if len([]rune(text)) < minSearchLength {
+		return nil, domain.NewValidationError(fmt.Sprintf("search query must be at least %d characters", minSearchLength))
+	}
+
+	query, err := q.BookingListQuery.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	query.Search = text
+	return s.listBookings(ctx, query)
+}
+
+// listBookings fetches one row beyond the limit to learn whether another page exists.
+func (s *BookingService) listBookings(ctx context.Context, query bookingDomain.ListQuery) (*BookingCursorPage, error) {
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	bookings, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &BookingCursorPage{Items: make([]BookingDTO, 0, limit)}
+	if len(bookings) > limit {
+		page.HasMore = true
+		bookings = bookings[:limit]
+		page.NextCursor = bookingDomain.CursorAfter(bookings[limit-1]).Encode()
+	}
+	for _, bk := range bookings {
+		page.Items = append(page.Items, toBookingDTO(bk))
+	}
+	return page, nil
+}
+
+// toDomain validates the query parameters and converts them into a domain list query.
+func (q BookingListQuery) toDomain() (bookingDomain.ListQuery, error) {
+	query := bookingDomain.ListQuery{Limit: q.Limit}
+	if query.Limit < 1 {
+		query.Limit = defaultListLimit
+	}
+	if query.Limit > maxListLimit {
+		query.Limit = maxListLimit
+	}
+
+	if q.Cursor != "" {
+		cursor, err := bookingDomain.DecodeCursor(q.Cursor)
+		if err != nil {
+			return query, domain.NewValidationError("invalid cursor")
+		}
+		query.After = &cursor
+	}
+
+	f := &query.Filter
+	if q.Status != "" {
+		for _, raw := range strings.Split(q.Status, ",") {
+			st := bookingDomain.BookingStatus(strings.TrimSpace(raw))
+			if !st.IsValid() {
+				return query, domain.NewValidationError(fmt.Sprintf("invalid status: %s", raw))
+			}
+			f.Statuses = append(f.Statuses, st)
+		}
+	}
+
+	var err error
+	if f.CreatedFrom, err = parseListTime("from", q.From); err != nil {
+		return query, err
+	}
+	if f.CreatedTo, err = parseListTime("to", q.To); err != nil {
+		return query, err
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return query, domain.NewValidationError("from must be before to")
+	}
+
+	if q.PetType != "" {
+		pt := bookingDomain.PetType(q.PetType)
+		if !pt.IsValid() {
+			return query, domain.NewValidationError(fmt.Sprintf("invalid pet_type: %s", q.PetType))
+		}
+		f.PetType = pt
+	}
+
+	f.Scheduled = q.Scheduled
+	if q.SeriesID != "" {
+		seriesID, err := uuid.Parse(q.SeriesID)
+		if err != nil {
+			return query, domain.NewValidationError("invalid series_id")
+		}
+		f.SeriesID = &seriesID
+	}
+	if q.ParentID != "" {
+		parentID, err := uuid.Parse(q.ParentID)
+		if err != nil {
+			return query, domain.NewValidationError("invalid parent_id")
+		}
+		f.ParentID = &parentID
+	}
+	f.MinPriceCents = q.MinPriceCents
+	f.MaxPriceCents = q.MaxPriceCents
+	if f.MinPriceCents != nil && f.MaxPriceCents != nil && *f.MinPriceCents > *f.MaxPriceCents {
+		return query, domain.NewValidationError("min_price_cents must not exceed max_price_cents")
+	}
+
+	return query, nil
+}
+
+// parseListTime accepts either an RFC3339 timestamp or a plain date (UTC midnight).
+func parseListTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	return nil, domain.NewValidationError(fmt.Sprintf("invalid %s: expected RFC3339 timestamp or YYYY-MM-DD", name))
+}
@@ -3,7 +3,6 @@ package application
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/Kilat-Pet-Delivery/lib-common/domain"
@@ -11,6 +10,7 @@ import (
 	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
 	"github.com/Kilat-Pet-Delivery/lib-proto/events"
 	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -19,47 +19,107 @@ import (
 
 // CreateBookingRequest holds the data needed to create a new booking.
 type CreateBookingRequest struct {
-	PetSpec        dto.PetSpecDTO  `json:"pet_spec" binding:"required"`
-	PickupAddress  dto.AddressDTO  `json:"pickup_address" binding:"required"`
-	DropoffAddress dto.AddressDTO  `json:"dropoff_address" binding:"required"`
-	ScheduledAt    *time.Time      `json:"scheduled_at"`
-	Notes          string          `json:"notes"`
+	PetSpec        dto.PetSpecDTO `json:"pet_spec" binding:"required"`
+	PickupAddress  dto.AddressDTO `json:"pickup_address"`
+	DropoffAddress dto.AddressDTO `json:"dropoff_address"`
+	ScheduledAt    *time.Time     `json:"scheduled_at"`
+	Notes          string         `json:"notes"`
+	// ReturnLeg optionally books the trip back from dropoff to pickup as a linked booking.
+	ReturnLeg *ReturnLegRequest `json:"return_leg"`
+	// Stops are visited in order between pickup and dropoff.
+	Stops []StopRequest `json:"stops" binding:"omitempty,dive"`
+	// PickupAddressID and DropoffAddressID book from or to a saved address instead
+	// of an inline one; the saved address is copied into the booking.
+	PickupAddressID  *uuid.UUID `json:"pickup_address_id"`
+	DropoffAddressID *uuid.UUID `json:"dropoff_address_id"`
+	// PetID names the owner's pet profile; it fills blanks in PetSpec and adds the
+	// pet's allergies to the eligibility check.
+	PetID *uuid.UUID `json:"pet_id"`
+	// VetNote lets pets outside the usual age range travel.
+	VetNote string `json:"vet_note" binding:"max=1000"`
 }
 
 // BookingDTO is the response representation of a booking.
 type BookingDTO struct {
-	ID                  uuid.UUID              `json:"id"`
-	BookingNumber       string                 `json:"booking_number"`
-	OwnerID             uuid.UUID              `json:"owner_id"`
-	RunnerID            *uuid.UUID             `json:"runner_id,omitempty"`
-	Status              string                 `json:"status"`
-	PetSpec             bookingDomain.PetSpecification  `json:"pet_spec"`
-	CrateReq            bookingDomain.CrateRequirement  `json:"crate_requirement"`
-	PickupAddress       dto.AddressDTO         `json:"pickup_address"`
-	DropoffAddress      dto.AddressDTO         `json:"dropoff_address"`
+	ID                  uuid.UUID                         `json:"id"`
+	BookingNumber       string                            `json:"booking_number"`
+	OwnerID             uuid.UUID                         `json:"owner_id"`
+	RunnerID            *uuid.UUID                        `json:"runner_id,omitempty"`
+	Status              string                            `json:"status"`
+	PetSpec             bookingDomain.PetSpecification    `json:"pet_spec"`
+	CrateReq            bookingDomain.CrateRequirement    `json:"crate_requirement"`
+	PickupAddress       dto.AddressDTO                    `json:"pickup_address"`
+	DropoffAddress      dto.AddressDTO                    `json:"dropoff_address"`
 	RouteSpec           *bookingDomain.RouteSpecification `json:"route_spec,omitempty"`
-	EstimatedPriceCents int64                  `json:"estimated_price_cents"`
-	FinalPriceCents     *int64                 `json:"final_price_cents,omitempty"`
-	Currency            string                 `json:"currency"`
-	ScheduledAt         *time.Time             `json:"scheduled_at,omitempty"`
-	PickedUpAt          *time.Time             `json:"picked_up_at,omitempty"`
-	DeliveredAt         *time.Time             `json:"delivered_at,omitempty"`
-	CancelledAt         *time.Time             `json:"cancelled_at,omitempty"`
-	CancelNote          string                 `json:"cancel_note,omitempty"`
-	Notes               string                 `json:"notes,omitempty"`
-	Version             int64                  `json:"version"`
-	CreatedAt           time.Time              `json:"created_at"`
-	UpdatedAt           time.Time              `json:"updated_at"`
+	DeliveryProgress    *bookingDomain.DeliveryProgress   `json:"delivery_progress,omitempty"`
+	EstimatedPriceCents int64                             `json:"estimated_price_cents"`
+	FinalPriceCents     *int64                            `json:"final_price_cents,omitempty"`
+	Currency            string                            `json:"currency"`
+	ScheduledAt         *time.Time                        `json:"scheduled_at,omitempty"`
+	PickedUpAt          *time.Time                        `json:"picked_up_at,omitempty"`
+	DeliveredAt         *time.Time                        `json:"delivered_at,omitempty"`
+	CancelledAt         *time.Time                        `json:"cancelled_at,omitempty"`
+	CancelNote          string                            `json:"cancel_note,omitempty"`
+	Notes               string                            `json:"notes,omitempty"`
+	SeriesID            *uuid.UUID                        `json:"series_id,omitempty"`
+	ParentID            *uuid.UUID                        `json:"parent_id,omitempty"`
+	Leg                 string                            `json:"leg,omitempty"`
+	Stops               []bookingDomain.Stop              `json:"stops,omitempty"`
+	PickupDetails       *bookingDomain.AddressDetails     `json:"pickup_details,omitempty"`
+	DropoffDetails      *bookingDomain.AddressDetails     `json:"dropoff_details,omitempty"`
+	Eligibility         *bookingDomain.EligibilityReport  `json:"eligibility,omitempty"`
+	ReturnLeg           *BookingDTO                       `json:"return_leg,omitempty"`
+	Version             int64                             `json:"version"`
+	CreatedAt           time.Time                         `json:"created_at"`
+	UpdatedAt           time.Time                         `json:"updated_at"`
 }
 
 // BookingService is the application service orchestrating booking use cases.
 type BookingService struct {
 	repo        bookingDomain.BookingRepository
 	declineRepo *repository.GormDeclineReasonRepository
+	historyRepo *repository.GormStatusHistoryRepository
 	db          *gorm.DB
 	pricing     bookingDomain.PricingStrategy
 	producer    *kafka.Producer
-	logger      *zap.Logger
+	updates     *realtime.Hub
+	serviceArea *ServiceAreaService
+	addresses   *AddressVerifier
+	// savedAddresses resolves pickup_address_id and dropoff_address_id.
+	savedAddresses *SavedAddressService
+	// eligibility checks pets against the eligibility policy.
+	eligibility *EligibilityChecker
+	// capabilities checks runners can carry the pet before they accept.
+	capabilities *RunnerCapabilityService
+	// maxActivePerRunner caps a runner's accepted and in-progress bookings; 0 is no cap.
+	maxActivePerRunner int
+	// runnerPayoutPercent is the share of the price quoted to runners as their payout.
+	runnerPayoutPercent int
+	logger              *zap.Logger
+}
+
+// BookingServiceOptions holds the optional collaborators of a BookingService. The
+// zero value of each field turns its feature off.
+type BookingServiceOptions struct {
+	// Updates receives real-time booking updates; nil publishes none.
+	Updates *realtime.Hub
+	// ServiceArea checks coverage and zone fares; nil accepts bookings anywhere at
+	// standard prices.
+	ServiceArea *ServiceAreaService
+	// Addresses verifies addresses and fills in coordinates; nil trusts client
+	// addresses as given.
+	Addresses *AddressVerifier
+	// SavedAddresses resolves saved address references; nil rejects them.
+	SavedAddresses *SavedAddressService
+	// Eligibility checks pets against the eligibility policy; nil skips the check.
+	Eligibility *EligibilityChecker
+	// Capabilities checks runners can carry the pet; nil lets any runner accept.
+	Capabilities *RunnerCapabilityService
+	// MaxActivePerRunner caps a runner's accepted and in-progress bookings; 0 is no cap.
+	MaxActivePerRunner int
+	// RunnerPayoutPercent is the share of the price quoted to runners as their payout;
+	// 0 uses bookingDomain.DefaultRunnerPayoutPercent.
+	RunnerPayoutPercent int
 }
 
 // NewBookingService creates a new BookingService.
@@ -70,37 +130,123 @@ func NewBookingService(
 	logger *zap.Logger,
 	db *gorm.DB,
 	declineRepo *repository.GormDeclineReasonRepository,
+	opts BookingServiceOptions,
 ) *BookingService {
-	return &BookingService{
-		repo:        repo,
-		pricing:     pricing,
-		producer:    producer,
-		logger:      logger,
-		db:          db,
-		declineRepo: declineRepo,
-	}
+	s := &BookingService{
+		repo:                repo,
+		pricing:             pricing,
+		producer:            producer,
+		logger:              logger,
+		db:                  db,
+		declineRepo:         declineRepo,
+		historyRepo:         repository.NewGormStatusHistoryRepository(db),
+		updates:             opts.Updates,
+		serviceArea:         opts.ServiceArea,
+		addresses:           opts.Addresses,
+		savedAddresses:      opts.SavedAddresses,
+		eligibility:         opts.Eligibility,
+		capabilities:        opts.Capabilities,
+		maxActivePerRunner:  opts.MaxActivePerRunner,
+		runnerPayoutPercent: opts.RunnerPayoutPercent,
+	}
+	if s.runnerPayoutPercent == 0 {
+		s.runnerPayoutPercent = bookingDomain.DefaultRunnerPayoutPercent
+	}
+	return s
+}
+
+// runnerPayoutCents returns the payout quoted to runners for the booking.
+func (s *BookingService) runnerPayoutCents(bk *bookingDomain.Booking) int64 {
+	return bookingDomain.RunnerPayoutCents(bk.EstimatedPriceCents(), s.runnerPayoutPercent)
 }
 
 // CreateBooking creates a new booking for the given owner.
 func (s *BookingService) CreateBooking(ctx context.Context, ownerID uuid.UUID, req CreateBookingRequest) (*BookingDTO, error) {
+	bk, err := s.buildBooking(ctx, ownerID, req, req.ReturnLeg != nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.ReturnLeg != nil {
+		return s.createRoundTrip(ctx, ownerID, req, bk)
+	}
+
+	// Persist the booking
+	if err := s.repo.Save(ctx, bk); err != nil {
+		return nil, fmt.Errorf("failed to save booking: %w", err)
+	}
+
+	// Publish BookingRequestedEvent
+	s.publishBookingRequested(ctx, bk)
+
+	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
+	return &result, nil
+}
+
+// buildBooking verifies the addresses of a booking request, checks them against the
+// service area, prices the trip and builds the new aggregate with its route.
+// roundTrip prices it as one leg of a round trip.
+func (s *BookingService) buildBooking(ctx context.Context, ownerID uuid.UUID, req CreateBookingRequest, roundTrip bool) (*bookingDomain.Booking, error) {
+	// Copy in the saved addresses the request refers to
+	pickupDetails, dropoffDetails, err := s.applySavedAddresses(ctx, ownerID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the pet may travel; blocking findings reject the booking
+	var eligibility *bookingDomain.EligibilityReport
+	if s.eligibility != nil {
+		if eligibility, err = s.eligibility.check(ctx, ownerID, &req); err != nil {
+			return nil, err
+		}
+	} else if req.PetID != nil {
+		return nil, domain.NewValidationError("pet profiles are not available")
+	}
+
+	// Normalise addresses and fill in or verify their coordinates
+	if s.addresses != nil {
+		if req, err = s.addresses.verifyRequest(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build pet specification from DTO
 	petSpec := buildPetSpecification(req.PetSpec)
 
 	// Determine crate requirement
 	crateReq := bookingDomain.DetermineCrateRequirement(petSpec)
 
-	// Calculate distance (Haversine approximation)
-	distanceKm := haversineDistance(
-		req.PickupAddress.Latitude, req.PickupAddress.Longitude,
-		req.DropoffAddress.Latitude, req.DropoffAddress.Longitude,
-	)
+	stops, err := bookingDomain.NewStops(toDomainStops(req.Stops))
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan the route through every stop (Haversine approximation per leg)
+	route := bookingDomain.PlanRoute(req.PickupAddress, stops, req.DropoffAddress)
+	stopWaitMin := make([]int, len(stops))
+	for i, st := range stops {
+		stopWaitMin[i] = st.WaitMin
+	}
+
+	// Reject trips outside coverage and look up the zone fares
+	var pickupZone, dropoffZone *bookingDomain.ZoneFare
+	if s.serviceArea != nil {
+		pickupZone, dropoffZone, err = s.serviceArea.tripZones(ctx, req.PickupAddress, stops, req.DropoffAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Calculate estimated price
 	priceCents, err := s.pricing.Calculate(bookingDomain.PricingParams{
-		DistanceKm:  distanceKm,
+		DistanceKm:  route.DistanceKm,
 		PetType:     bookingDomain.PetType(req.PetSpec.PetType),
 		CrateSize:   crateReq.MinimumSize,
 		IsScheduled: req.ScheduledAt != nil,
+		IsRoundTrip: roundTrip,
+		StopWaitMin: stopWaitMin,
+		PickupZone:  pickupZone,
+		DropoffZone: dropoffZone,
 	})
 	if err != nil {
 		return nil, domain.NewValidationError(fmt.Sprintf("pricing error: %v", err))
@@ -121,32 +267,65 @@ func (s *BookingService) CreateBooking(ctx context.Context, ownerID uuid.UUID, r
 	if err != nil {
 		return nil, err
 	}
+	bk.SetStops(stops)
+	bk.SetRouteSpec(route)
+	bk.SetAddressDetails(pickupDetails, dropoffDetails)
+	bk.SetEligibility(eligibility)
+	return bk, nil
+}
 
-	// Persist the booking
-	if err := s.repo.Save(ctx, bk); err != nil {
-		return nil, fmt.Errorf("failed to save booking: %w", err)
-	}
-
-	// Publish BookingRequestedEvent
-	s.publishBookingRequested(ctx, bk)
+// BookingTakenError is returned when another runner accepted the booking first. It
+// carries the booking as the winning accept left it.
+type BookingTakenError struct {
+	Booking BookingDTO
+}
 
-	result := toBookingDTO(bk)
-	return &result, nil
+func (e *BookingTakenError) Error() string {
+	return "booking already taken by another runner"
 }
 
-// AcceptBooking assigns a runner to an open booking.
+// AcceptBooking assigns a runner to an open booking. The booking row is locked while
+// the accept is checked and saved, so of several runners accepting at once the first
+// wins and the others get a BookingTakenError.
 func (s *BookingService) AcceptBooking(ctx context.Context, bookingID, runnerID uuid.UUID) (*BookingDTO, error) {
-	bk, err := s.repo.FindByID(ctx, bookingID)
-	if err != nil {
-		return nil, err
-	}
+	var bk *bookingDomain.Booking
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txBookingRepo := repository.NewGormBookingRepository(tx)
+		// Every accept locks the runner before the booking row, so accepts cannot deadlock.
+		if err := txBookingRepo.LockRunner(ctx, runnerID); err != nil {
+			return err
+		}
+		current, err := txBookingRepo.FindByIDForUpdate(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		if current.Status() != bookingDomain.StatusCancelled && current.RunnerID() != nil && *current.RunnerID() != runnerID {
+			return &BookingTakenError{Booking: toBookingDTO(current)}
+		}
 
-	if err := bk.Accept(runnerID); err != nil {
-		return nil, err
-	}
+		from := current.Status()
+		if err := current.Accept(runnerID); err != nil {
+			return err
+		}
+		if s.capabilities != nil {
+			if err := s.capabilities.ensureCanCarry(ctx, runnerID, current.CrateReq()); err != nil {
+				return err
+			}
+		}
+		// The runner lock makes this check safe against the runner's other accepts.
+		active, err := txBookingRepo.FindActiveByRunnerID(ctx, runnerID)
+		if err != nil {
+			return err
+		}
+		if err := bookingDomain.CheckRunnerLoad(current, active, s.maxActivePerRunner, time.Now().UTC()); err != nil {
+			return err
+		}
 
-	bk.IncrementVersion()
-	if err := s.repo.Update(ctx, bk); err != nil {
+		current.IncrementVersion()
+		bk = current
+		return s.updateWithHistoryTx(ctx, tx, current, from, &runnerID, "")
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -161,6 +340,7 @@ func (s *BookingService) AcceptBooking(ctx context.Context, bookingID, runnerID
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingAccepted, bk.ID().String(), evt)
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateRunnerAssigned, bk, result)
 	return &result, nil
 }
 
@@ -171,12 +351,13 @@ func (s *BookingService) StartDelivery(ctx context.Context, bookingID uuid.UUID)
 		return nil, err
 	}
 
+	from := bk.Status()
 	if err := bk.StartDelivery(); err != nil {
 		return nil, err
 	}
 
 	bk.IncrementVersion()
-	if err := s.repo.Update(ctx, bk); err != nil {
+	if err := s.updateWithHistory(ctx, bk, from, bk.RunnerID(), ""); err != nil {
 		return nil, err
 	}
 
@@ -192,6 +373,7 @@ func (s *BookingService) StartDelivery(ctx context.Context, bookingID uuid.UUID)
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingPetPickedUp, bk.ID().String(), evt)
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
 	return &result, nil
 }
 
@@ -202,12 +384,13 @@ func (s *BookingService) ConfirmDelivery(ctx context.Context, bookingID uuid.UUI
 		return nil, err
 	}
 
+	from := bk.Status()
 	if err := bk.ConfirmDelivery(); err != nil {
 		return nil, err
 	}
 
 	bk.IncrementVersion()
-	if err := s.repo.Update(ctx, bk); err != nil {
+	if err := s.updateWithHistory(ctx, bk, from, bk.RunnerID(), ""); err != nil {
 		return nil, err
 	}
 
@@ -223,25 +406,36 @@ func (s *BookingService) ConfirmDelivery(ctx context.Context, bookingID uuid.UUI
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingDeliveryConfirmed, bk.ID().String(), evt)
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
 	return &result, nil
 }
 
 // CompleteBooking finalizes the booking after payment escrow is released.
 func (s *BookingService) CompleteBooking(ctx context.Context, bookingID uuid.UUID) (*BookingDTO, error) {
-	bk, err := s.repo.FindByID(ctx, bookingID)
-	if err != nil {
-		return nil, err
-	}
+	return s.completeBooking(ctx, bookingID, NoRetry)
+}
 
-	// Use estimated price as final price if not set differently
-	finalPrice := bk.EstimatedPriceCents()
+// CompleteBookingAfterEscrowRelease is CompleteBooking for the payment event consumer:
+// it retries when the completion races another update to the booking.
+func (s *BookingService) CompleteBookingAfterEscrowRelease(ctx context.Context, bookingID uuid.UUID) (*BookingDTO, error) {
+	return s.completeBooking(ctx, bookingID, BackgroundRetry)
+}
 
-	if err := bk.Complete(finalPrice); err != nil {
-		return nil, err
-	}
+func (s *BookingService) completeBooking(ctx context.Context, bookingID uuid.UUID, policy RetryPolicy) (*BookingDTO, error) {
+	var finalPrice int64
+	bk, err := s.updateBooking(ctx, bookingID, policy, func(bk *bookingDomain.Booking) error {
+		// Use estimated price as final price if not set differently
+		finalPrice = bk.EstimatedPriceCents()
 
-	bk.IncrementVersion()
-	if err := s.repo.Update(ctx, bk); err != nil {
+		from := bk.Status()
+		if err := bk.Complete(finalPrice); err != nil {
+			return err
+		}
+
+		bk.IncrementVersion()
+		return s.updateWithHistory(ctx, bk, from, nil, "")
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -262,6 +456,7 @@ func (s *BookingService) CompleteBooking(ctx context.Context, bookingID uuid.UUI
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingCompleted, bk.ID().String(), evt)
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
 	return &result, nil
 }
 
@@ -272,12 +467,13 @@ func (s *BookingService) CancelBooking(ctx context.Context, bookingID, cancelled
 		return nil, err
 	}
 
+	from := bk.Status()
 	if err := bk.Cancel(reason); err != nil {
 		return nil, err
 	}
 
 	bk.IncrementVersion()
-	if err := s.repo.Update(ctx, bk); err != nil {
+	if err := s.updateWithHistory(ctx, bk, from, &cancelledBy, reason); err != nil {
 		return nil, err
 	}
 
@@ -292,6 +488,8 @@ func (s *BookingService) CancelBooking(ctx context.Context, bookingID, cancelled
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingCancelled, bk.ID().String(), evt)
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
+	result.ReturnLeg = s.cascadeCancelReturnLeg(ctx, bk, from, cancelledBy)
 	return &result, nil
 }
 
@@ -376,6 +574,10 @@ func (s *BookingService) RebookBooking(ctx context.Context, ownerID, originalBoo
 		PickupAddress:  original.PickupAddress(),
 		DropoffAddress: original.DropoffAddress(),
 		Notes:          original.Notes(),
+		Stops:          toStopRequests(original.Stops()),
+	}
+	if original.Eligibility() != nil {
+		req.VetNote = original.Eligibility().VetNote
 	}
 
 	return s.CreateBooking(ctx, ownerID, req)
@@ -401,26 +603,7 @@ func (s *BookingService) DeclineBooking(ctx context.Context, bookingID, runnerID
 		)
 	}
 
-	// Apply domain transition (clears runnerID, sets status back to requested).
-	if err := bk.Decline(reason); err != nil {
-		return nil, err
-	}
-	bk.IncrementVersion()
-
-	// Persist booking update + decline reason in a single transaction.
-	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Update booking via the existing repo, which uses s.db internally.
-		// We pass a tx-scoped repo so both writes share the same transaction.
-		txBookingRepo := repository.NewGormBookingRepository(tx)
-		if err := txBookingRepo.Update(ctx, bk); err != nil {
-			return err
-		}
-
-		if err := s.declineRepo.RecordDecline(ctx, tx, bookingID, runnerID, reason); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
+	if err := s.applyDecline(ctx, bk, runnerID, reason); err != nil {
 		return nil, err
 	}
 
@@ -429,9 +612,87 @@ func (s *BookingService) DeclineBooking(ctx context.Context, bookingID, runnerID
 	// Tracked as a follow-up to Phase 3 of the app-runner design system plan.
 
 	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, result)
 	return &result, nil
 }
 
+// ReleaseRunnerBookings moves every booking the runner has accepted back to "requested"
+// with a system reason, e.g. when the runner is suspended or has been offline too long.
+// Each booking goes through the same decline path as DeclineBooking and the owner is
+// notified via a BookingRunnerReleasedEvent. It returns the number of bookings released.
+func (s *BookingService) ReleaseRunnerBookings(ctx context.Context, runnerID uuid.UUID, reason string) (int, error) {
+	bookings, err := s.repo.FindByRunnerIDAndStatus(ctx, runnerID, bookingDomain.StatusAccepted)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	var firstErr error
+	for _, listed := range bookings {
+		held := false
+		bk, err := s.updateBooking(ctx, listed.ID(), BackgroundRetry, func(bk *bookingDomain.Booking) error {
+			// A retry may find the booking already handed back or with another runner.
+			held = bk.Status() == bookingDomain.StatusAccepted && bk.RunnerID() != nil && *bk.RunnerID() == runnerID
+			if !held {
+				return nil
+			}
+			return s.applyDecline(ctx, bk, runnerID, reason)
+		})
+		if err != nil {
+			s.logger.Error("failed to release booking from runner",
+				zap.String("booking_id", listed.ID().String()),
+				zap.String("runner_id", runnerID.String()),
+				zap.Error(err),
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !held {
+			continue
+		}
+		released++
+
+		// Publish BookingRunnerReleasedEvent so the owner can be notified.
+		evt := BookingRunnerReleasedEvent{
+			BookingID:     bk.ID(),
+			BookingNumber: bk.BookingNumber(),
+			OwnerID:       bk.OwnerID(),
+			RunnerID:      runnerID,
+			Reason:        reason,
+			OccurredAt:    time.Now().UTC(),
+		}
+		s.publishEvent(ctx, events.TopicBookingEvents, BookingRunnerReleased, bk.ID().String(), evt)
+		s.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, toBookingDTO(bk))
+	}
+
+	return released, firstErr
+}
+
+// SubscribeUpdates registers a real-time subscriber for booking changes. With a nil
+// bookingID the user receives updates for every booking they own or run; otherwise the
+// user must be that booking's owner or assigned runner.
+func (s *BookingService) SubscribeUpdates(ctx context.Context, userID uuid.UUID, bookingID *uuid.UUID) (<-chan realtime.Update, func(), error) {
+	if s.updates == nil {
+		return nil, nil, fmt.Errorf("real-time updates are not enabled")
+	}
+
+	if bookingID != nil {
+		bk, err := s.repo.FindByID(ctx, *bookingID)
+		if err != nil {
+			return nil, nil, err
+		}
+		isRunner := bk.RunnerID() != nil && *bk.RunnerID() == userID
+		if bk.OwnerID() != userID && !isRunner {
+			return nil, nil, domain.NewForbiddenError("not your booking")
+		}
+	}
+
+	ch, cancel := s.updates.Subscribe(realtime.Subscription{UserID: userID, BookingID: bookingID})
+	return ch, cancel, nil
+}
+
 // --- Admin methods ---
 
 // BookingStatsDTO holds booking statistics for the admin dashboard.
@@ -486,6 +747,7 @@ func toBookingDTO(bk *bookingDomain.Booking) BookingDTO {
 		PickupAddress:       bk.PickupAddress(),
 		DropoffAddress:      bk.DropoffAddress(),
 		RouteSpec:           bk.RouteSpec(),
+		DeliveryProgress:    bk.Progress(),
 		EstimatedPriceCents: bk.EstimatedPriceCents(),
 		FinalPriceCents:     bk.FinalPriceCents(),
 		Currency:            bk.Currency(),
@@ -495,6 +757,13 @@ func toBookingDTO(bk *bookingDomain.Booking) BookingDTO {
 		CancelledAt:         bk.CancelledAt(),
 		CancelNote:          bk.CancelNote(),
 		Notes:               bk.Notes(),
+		SeriesID:            bk.SeriesID(),
+		ParentID:            bk.ParentID(),
+		Leg:                 string(bk.Leg()),
+		Stops:               bk.Stops(),
+		PickupDetails:       bk.PickupDetails(),
+		DropoffDetails:      bk.DropoffDetails(),
+		Eligibility:         bk.Eligibility(),
 		Version:             bk.Version(),
 		CreatedAt:           bk.CreatedAt(),
 		UpdatedAt:           bk.UpdatedAt(),
@@ -525,6 +794,60 @@ func buildPetSpecification(petDTO dto.PetSpecDTO) bookingDomain.PetSpecification
 	}
 }
 
+// applyDecline transitions an accepted booking back to "requested" and persists the
+// booking update together with the decline reason in a single transaction.
+func (s *BookingService) applyDecline(ctx context.Context, bk *bookingDomain.Booking, runnerID uuid.UUID, reason string) error {
+	// Apply domain transition (clears runnerID, sets status back to requested).
+	from := bk.Status()
+	if err := bk.Decline(reason); err != nil {
+		return err
+	}
+	bk.IncrementVersion()
+
+	// Persist booking update + decline reason in a single transaction.
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update booking via the existing repo, which uses s.db internally.
+		// We pass a tx-scoped repo so both writes share the same transaction.
+		txBookingRepo := repository.NewGormBookingRepository(tx)
+		if err := txBookingRepo.Update(ctx, bk); err != nil {
+			return err
+		}
+
+		if err := s.declineRepo.RecordDecline(ctx, tx, bk.ID(), runnerID, reason); err != nil {
+			return err
+		}
+		return s.historyRepo.RecordTransition(ctx, tx, repository.StatusHistoryEntry{
+			BookingID:  bk.ID(),
+			FromStatus: string(from),
+			ToStatus:   string(bk.Status()),
+			ChangedBy:  &runnerID,
+			Reason:     reason,
+		})
+	})
+}
+
+// updateWithHistory persists a booking after a status transition together with its
+// booking_status_history row, so analytics can reconstruct when each state was entered.
+func (s *BookingService) updateWithHistory(ctx context.Context, bk *bookingDomain.Booking, from bookingDomain.BookingStatus, changedBy *uuid.UUID, reason string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.updateWithHistoryTx(ctx, tx, bk, from, changedBy, reason)
+	})
+}
+
+func (s *BookingService) updateWithHistoryTx(ctx context.Context, tx *gorm.DB, bk *bookingDomain.Booking, from bookingDomain.BookingStatus, changedBy *uuid.UUID, reason string) error {
+	txBookingRepo := repository.NewGormBookingRepository(tx)
+	if err := txBookingRepo.Update(ctx, bk); err != nil {
+		return err
+	}
+	return s.historyRepo.RecordTransition(ctx, tx, repository.StatusHistoryEntry{
+		BookingID:  bk.ID(),
+		FromStatus: string(from),
+		ToStatus:   string(bk.Status()),
+		ChangedBy:  changedBy,
+		Reason:     reason,
+	})
+}
+
 func (s *BookingService) publishBookingRequested(ctx context.Context, bk *bookingDomain.Booking) {
 	evt := events.BookingRequestedEvent{
 		BookingID:      bk.ID(),
@@ -543,42 +866,57 @@ func (s *BookingService) publishBookingRequested(ctx context.Context, bk *bookin
 	s.publishEvent(ctx, events.TopicBookingEvents, events.BookingRequested, bk.ID().String(), evt)
 }
 
+// notifyUpdate pushes a committed booking change to real-time subscribers.
+func (s *BookingService) notifyUpdate(ctx context.Context, updateType realtime.UpdateType, bk *bookingDomain.Booking, payload interface{}) {
+	publishUpdate(ctx, s.updates, s.logger, updateType, bk.ID(), bk.OwnerID(), bk.RunnerID(), string(bk.Status()), payload)
+}
+
+// publishUpdate builds and publishes a real-time update; a nil hub disables streaming.
+func publishUpdate(
+	ctx context.Context,
+	hub *realtime.Hub,
+	logger *zap.Logger,
+	updateType realtime.UpdateType,
+	bookingID, ownerID uuid.UUID,
+	runnerID *uuid.UUID,
+	status string,
+	payload interface{},
+) {
+	if hub == nil {
+		return
+	}
+	u, err := realtime.NewUpdate(updateType, bookingID, ownerID, runnerID, status, payload)
+	if err != nil {
+		logger.Error("failed to build booking update",
+			zap.String("booking_id", bookingID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	hub.Publish(ctx, u)
+}
+
 func (s *BookingService) publishEvent(ctx context.Context, topic, eventType, key string, data interface{}) {
+	publishCloudEvent(ctx, s.producer, s.logger, topic, eventType, data)
+}
+
+// publishCloudEvent wraps data in a CloudEvent and publishes it, logging (not returning)
+// failures: events are best-effort notifications after the state change has committed.
+func publishCloudEvent(ctx context.Context, producer *kafka.Producer, logger *zap.Logger, topic, eventType string, data interface{}) {
 	cloudEvent, err := kafka.NewCloudEvent("service-booking", eventType, data)
 	if err != nil {
-		s.logger.Error("failed to create cloud event",
+		logger.Error("failed to create cloud event",
 			zap.String("event_type", eventType),
 			zap.Error(err),
 		)
 		return
 	}
 
-	if err := s.producer.PublishEvent(ctx, topic, cloudEvent); err != nil {
-		s.logger.Error("failed to publish event",
+	if err := producer.PublishEvent(ctx, topic, cloudEvent); err != nil {
+		logger.Error("failed to publish event",
 			zap.String("topic", topic),
 			zap.String("event_type", eventType),
 			zap.Error(err),
 		)
 	}
 }
-
-// haversineDistance calculates the distance between two coordinates in kilometers.
-func haversineDistance(lat1, lng1, lat2, lng2 float64) float64 {
-	const earthRadiusKm = 6371.0
-
-	dLat := degreesToRadians(lat2 - lat1)
-	dLng := degreesToRadians(lng2 - lng1)
-
-	lat1Rad := degreesToRadians(lat1)
-	lat2Rad := degreesToRadians(lat2)
-
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Sin(dLng/2)*math.Sin(dLng/2)*math.Cos(lat1Rad)*math.Cos(lat2Rad)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-
-	return earthRadiusKm * c
-}
-
-func degreesToRadians(deg float64) float64 {
-	return deg * math.Pi / 180.0
-}
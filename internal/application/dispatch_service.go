@@ -0,0 +1,449 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	dispatchDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/dispatch"
+	runnerDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/runner"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DispatchConfig tunes the automatic dispatcher.
+type DispatchConfig struct {
+	// OfferTimeout is how long a runner has to answer an offer.
+	OfferTimeout time.Duration
+	// RadiusKm is how far from the pickup runners are looked for.
+	RadiusKm float64
+	// MaxRounds is how many runners a booking is offered to before it is left on the
+	// job board.
+	MaxRounds int
+	// RunnerSeenWithin skips runners who have not reported their position for longer.
+	RunnerSeenWithin time.Duration
+	// RefusalWindow is how far back declines and unanswered offers count against a
+	// runner.
+	RefusalWindow time.Duration
+	// BatchSize caps how many bookings one tick offers.
+	BatchSize int
+	Weights   dispatchDomain.RankWeights
+}
+
+// DefaultDispatchConfig returns the dispatcher settings used unless configured.
+func DefaultDispatchConfig() DispatchConfig {
+	return DispatchConfig{
+		OfferTimeout:     45 * time.Second,
+		RadiusKm:         10,
+		MaxRounds:        5,
+		RunnerSeenWithin: 5 * time.Minute,
+		RefusalWindow:    30 * 24 * time.Hour,
+		BatchSize:        100,
+		Weights:          dispatchDomain.DefaultRankWeights(),
+	}
+}
+
+// UpdateRunnerAvailabilityRequest is a runner's position and status as published by
+// the runner service.
+type UpdateRunnerAvailabilityRequest struct {
+	RunnerID  uuid.UUID `json:"runner_id"`
+	Online    bool      `json:"online"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Rating    float64   `json:"rating"`
+	// UpdatedAt is when the runner service recorded the report.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OfferDTO is the response representation of a booking offer.
+type OfferDTO struct {
+	ID          uuid.UUID   `json:"id"`
+	BookingID   uuid.UUID   `json:"booking_id"`
+	RunnerID    uuid.UUID   `json:"runner_id"`
+	Round       int         `json:"round"`
+	Score       float64     `json:"score"`
+	DistanceKm  float64     `json:"distance_km"`
+	State       string      `json:"state"`
+	OfferedAt   time.Time   `json:"offered_at"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+	RespondedAt *time.Time  `json:"responded_at,omitempty"`
+	Booking     *BookingDTO `json:"booking,omitempty"`
+}
+
+// DispatchTickResult counts what one dispatcher tick did.
+type DispatchTickResult struct {
+	Expired int `json:"expired"`
+	Offered int `json:"offered"`
+}
+
+// DispatchService offers requested bookings to the best-ranked nearby runner, one
+// runner at a time, moving on when an offer is rejected or expires. Runners can still
+// take bookings from the job board while an offer is open.
+type DispatchService struct {
+	offers       dispatchDomain.OfferRepository
+	runners      dispatchDomain.RunnerLocator
+	availability runnerDomain.AvailabilityRepository
+	bookings     *BookingService
+	cfg          DispatchConfig
+	now          func() time.Time
+	logger       *zap.Logger
+}
+
+// NewDispatchService creates a new DispatchService. A nil now uses the wall clock;
+// tests pass a fake clock to drive offers through their timeouts.
+func NewDispatchService(
+	offers dispatchDomain.OfferRepository,
+	runners dispatchDomain.RunnerLocator,
+	availability runnerDomain.AvailabilityRepository,
+	bookings *BookingService,
+	cfg DispatchConfig,
+	now func() time.Time,
+	logger *zap.Logger,
+) *DispatchService {
+	if now == nil {
+		now = func() time.Time { return time.Now().UTC() }
+	}
+	return &DispatchService{
+		offers:       offers,
+		runners:      runners,
+		availability: availability,
+		bookings:     bookings,
+		cfg:          cfg,
+		now:          now,
+		logger:       logger,
+	}
+}
+
+// UpdateRunnerAvailability stores a runner's position and status. Reports older than
+// the stored one are ignored.
+func (s *DispatchService) UpdateRunnerAvailability(ctx context.Context, req UpdateRunnerAvailabilityRequest) error {
+	if req.UpdatedAt.IsZero() {
+		req.UpdatedAt = s.now()
+	}
+	a, err := runnerDomain.NewAvailability(req.RunnerID, req.Online, req.Latitude, req.Longitude, req.Rating, req.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if _, err := s.availability.Upsert(ctx, a); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Tick expires offers that timed out or whose booking was taken or cancelled, then
+// offers every requested booking without an open offer to its best-ranked runner.
+func (s *DispatchService) Tick(ctx context.Context) (*DispatchTickResult, error) {
+	now := s.now()
+	result := &DispatchTickResult{}
+
+	stale, err := s.offers.FindStale(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range stale {
+		if err := o.Expire(now); err != nil {
+			continue
+		}
+		if err := s.offers.Update(ctx, o); err != nil {
+			s.logger.Warn("failed to expire offer", zap.String("offer_id", o.ID().String()), zap.Error(err))
+			continue
+		}
+		result.Expired++
+	}
+
+	busy, err := s.busyRunners(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bookingIDs, err := s.offers.FindBookingsToDispatch(ctx, s.cfg.MaxRounds, s.cfg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	var firstErr error
+	for _, id := range bookingIDs {
+		offered, err := s.dispatchBooking(ctx, id, now, busy)
+		if markErr := s.offers.MarkDispatchAttempted(ctx, id, now); markErr != nil {
+			s.logger.Warn("failed to record dispatch attempt", zap.String("booking_id", id.String()), zap.Error(markErr))
+		}
+		if err != nil {
+			s.logger.Error("failed to dispatch booking", zap.String("booking_id", id.String()), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if offered {
+			result.Offered++
+		}
+	}
+	return result, firstErr
+}
+
+// RunDispatcher calls Tick every interval until ctx is cancelled.
+func (s *DispatchService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.Tick(ctx)
+			if err != nil {
+				s.logger.Error("dispatch tick failed", zap.Error(err))
+				continue
+			}
+			if result.Expired > 0 || result.Offered > 0 {
+				s.logger.Info("dispatch tick",
+					zap.Int("expired", result.Expired),
+					zap.Int("offered", result.Offered),
+				)
+			}
+		}
+	}
+}
+
+// ListRunnerOffers returns the runner's open offers with their bookings.
+func (s *DispatchService) ListRunnerOffers(ctx context.Context, runnerID uuid.UUID) ([]OfferDTO, error) {
+	offers, err := s.offers.FindOpenByRunnerID(ctx, runnerID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	dtos := make([]OfferDTO, 0, len(offers))
+	for _, o := range offers {
+		if !o.IsOpen(now) {
+			continue
+		}
+		bk, err := s.bookings.repo.FindByID(ctx, o.BookingID())
+		if err != nil {
+			return nil, err
+		}
+		dto := toOfferDTO(o)
+		booking := toBookingDTO(bk)
+		dto.Booking = &booking
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
+
+// ListBookingOffers returns every offer made for a booking (admin).
+func (s *DispatchService) ListBookingOffers(ctx context.Context, bookingID uuid.UUID) ([]OfferDTO, error) {
+	offers, err := s.offers.FindByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]OfferDTO, len(offers))
+	for i, o := range offers {
+		dtos[i] = toOfferDTO(o)
+	}
+	return dtos, nil
+}
+
+// AcceptOffer accepts the booking on offer for the runner, with the same checks as
+// accepting it from the job board.
+func (s *DispatchService) AcceptOffer(ctx context.Context, runnerID, offerID uuid.UUID) (*BookingDTO, error) {
+	o, err := s.findRunnerOffer(ctx, runnerID, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.Accept(s.now()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.bookings.AcceptBooking(ctx, o.BookingID(), runnerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.offers.Update(ctx, o); err != nil {
+		// The booking is the runner's either way; only the offer record is behind.
+		s.logger.Warn("booking accepted but offer not updated",
+			zap.String("offer_id", o.ID().String()),
+			zap.Error(err),
+		)
+	}
+	return result, nil
+}
+
+// RejectOffer turns the offered booking down and offers it to the next runner.
+func (s *DispatchService) RejectOffer(ctx context.Context, runnerID, offerID uuid.UUID) (*OfferDTO, error) {
+	o, err := s.findRunnerOffer(ctx, runnerID, offerID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if err := o.Reject(now); err != nil {
+		return nil, err
+	}
+	if err := s.offers.Update(ctx, o); err != nil {
+		return nil, err
+	}
+
+	busy, err := s.busyRunners(ctx)
+	if err == nil {
+		_, err = s.dispatchBooking(ctx, o.BookingID(), now, busy)
+	}
+	if err != nil {
+		// The next tick retries.
+		s.logger.Warn("failed to offer rejected booking to the next runner",
+			zap.String("booking_id", o.BookingID().String()),
+			zap.Error(err),
+		)
+	}
+
+	result := toOfferDTO(o)
+	return &result, nil
+}
+
+// dispatchBooking offers a requested booking to the best-ranked runner who has not
+// had it offered, has not declined it, holds no other open offer, has room for the
+// booking and can carry the pet. It reports whether an offer was made; busy is updated with the runner.
+func (s *DispatchService) dispatchBooking(ctx context.Context, bookingID uuid.UUID, now time.Time, busy map[uuid.UUID]bool) (bool, error) {
+	bk, err := s.bookings.repo.FindByID(ctx, bookingID)
+	if err != nil {
+		return false, err
+	}
+	if bk.Status() != bookingDomain.StatusRequested {
+		return false, nil
+	}
+	prior, err := s.offers.FindByBookingID(ctx, bookingID)
+	if err != nil {
+		return false, err
+	}
+	if len(prior) >= s.cfg.MaxRounds {
+		return false, nil
+	}
+	for _, o := range prior {
+		if o.State() == dispatchDomain.OfferOffered {
+			return false, nil
+		}
+	}
+
+	excluded := make(map[uuid.UUID]bool, len(prior))
+	for _, o := range prior {
+		excluded[o.RunnerID()] = true
+	}
+	declined, err := s.offers.FindDeclinedRunnerIDs(ctx, bookingID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range declined {
+		excluded[id] = true
+	}
+
+	pickup := bk.PickupAddress()
+	found, err := s.runners.FindAvailableNear(ctx, pickup.Latitude, pickup.Longitude, s.cfg.RadiusKm, now.Add(-s.cfg.RunnerSeenWithin))
+	if err != nil {
+		return false, err
+	}
+	candidates := make([]dispatchDomain.Candidate, 0, len(found))
+	ids := make([]uuid.UUID, 0, len(found))
+	for _, c := range found {
+		if excluded[c.RunnerID] || busy[c.RunnerID] {
+			continue
+		}
+		// Accepting re-checks the load; skipping full runners here keeps the offer
+		// from going to someone who could not accept it.
+		active, err := s.bookings.repo.FindActiveByRunnerID(ctx, c.RunnerID)
+		if err != nil {
+			return false, err
+		}
+		if bookingDomain.CheckRunnerLoad(bk, active, s.bookings.maxActivePerRunner, now) != nil {
+			continue
+		}
+		if capabilities := s.bookings.capabilities; capabilities != nil {
+			canCarry, err := capabilities.carryFilter(ctx, c.RunnerID)
+			if err != nil {
+				return false, err
+			}
+			if !canCarry(bk.CrateReq()) {
+				continue
+			}
+		}
+		candidates = append(candidates, c)
+		ids = append(ids, c.RunnerID)
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	refusals, err := s.offers.CountRecentRefusals(ctx, ids, now.Add(-s.cfg.RefusalWindow))
+	if err != nil {
+		return false, err
+	}
+	for i := range candidates {
+		candidates[i].RecentRefusals = refusals[candidates[i].RunnerID]
+	}
+	best := dispatchDomain.Rank(candidates, pickup.Latitude, pickup.Longitude, s.cfg.Weights)[0]
+
+	o, err := dispatchDomain.NewOffer(bookingID, best.RunnerID, len(prior)+1, best.Score, best.DistanceKm, now, s.cfg.OfferTimeout)
+	if err != nil {
+		return false, err
+	}
+	saved, err := s.offers.Save(ctx, o)
+	if err != nil || !saved {
+		// Not saved: another dispatcher got there first.
+		return false, err
+	}
+	busy[best.RunnerID] = true
+
+	s.logger.Info("booking offered",
+		zap.String("booking_id", bookingID.String()),
+		zap.String("runner_id", best.RunnerID.String()),
+		zap.Int("round", o.Round()),
+	)
+	evt := BookingOfferedEvent{
+		OfferID:              o.ID(),
+		BookingID:            bk.ID(),
+		BookingNumber:        bk.BookingNumber(),
+		RunnerID:             best.RunnerID,
+		Round:                o.Round(),
+		PickupDistanceKm:     best.DistanceKm,
+		EstimatedPayoutCents: s.bookings.runnerPayoutCents(bk),
+		ExpiresAt:            o.ExpiresAt(),
+		OccurredAt:           now,
+	}
+	s.bookings.publishEvent(ctx, events.TopicBookingEvents, BookingOffered, bk.ID().String(), evt)
+	return true, nil
+}
+
+func (s *DispatchService) busyRunners(ctx context.Context) (map[uuid.UUID]bool, error) {
+	ids, err := s.offers.FindBusyRunnerIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	busy := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		busy[id] = true
+	}
+	return busy, nil
+}
+
+func (s *DispatchService) findRunnerOffer(ctx context.Context, runnerID, offerID uuid.UUID) (*dispatchDomain.Offer, error) {
+	o, err := s.offers.FindByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsFor(runnerID) {
+		return nil, domain.NewForbiddenError("this offer was made to another runner")
+	}
+	return o, nil
+}
+
+func toOfferDTO(o *dispatchDomain.Offer) OfferDTO {
+	return OfferDTO{
+		ID:          o.ID(),
+		BookingID:   o.BookingID(),
+		RunnerID:    o.RunnerID(),
+		Round:       o.Round(),
+		Score:       o.Score(),
+		DistanceKm:  o.DistanceKm(),
+		State:       string(o.State()),
+		OfferedAt:   o.OfferedAt(),
+		ExpiresAt:   o.ExpiresAt(),
+		RespondedAt: o.RespondedAt(),
+	}
+}
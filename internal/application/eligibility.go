@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	petDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/pet"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EligibilityChecker evaluates the eligibility policy for new bookings, using the
+// owner's pet profile when the request names one.
+type EligibilityChecker struct {
+	policy bookingDomain.EligibilityPolicy
+	pets   petDomain.PetRepository
+	logger *zap.Logger
+}
+
+// NewEligibilityChecker creates an EligibilityChecker.
+func NewEligibilityChecker(policy bookingDomain.EligibilityPolicy, pets petDomain.PetRepository, logger *zap.Logger) *EligibilityChecker {
+	return &EligibilityChecker{policy: policy, pets: pets, logger: logger}
+}
+
+// LoadEligibilityPolicy reads an eligibility policy from a JSON file. The file
+// replaces the default policy as a whole.
+func LoadEligibilityPolicy(path string) (bookingDomain.EligibilityPolicy, error) {
+	var policy bookingDomain.EligibilityPolicy
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policy, fmt.Errorf("failed to read eligibility policy: %w", err)
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("failed to parse eligibility policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// IneligiblePetError is returned when the eligibility policy does not let the pet
+// travel. It carries every blocking finding, so clients can act on the codes.
+type IneligiblePetError struct {
+	Findings []bookingDomain.EligibilityFinding
+}
+
+func (e *IneligiblePetError) Error() string {
+	messages := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		messages[i] = f.Message
+	}
+	return "pet is not eligible to travel: " + strings.Join(messages, "; ")
+}
+
+// check fills blanks in the request's pet specification from the named pet profile,
+// evaluates the policy and returns the report. Blocking findings are returned as an
+// IneligiblePetError.
+func (c *EligibilityChecker) check(ctx context.Context, ownerID uuid.UUID, req *CreateBookingRequest) (*bookingDomain.EligibilityReport, error) {
+	var allergies string
+	if req.PetID != nil {
+		pet, err := c.pets.FindByID(ctx, *req.PetID)
+		if err != nil {
+			return nil, err
+		}
+		if !pet.IsOwnedBy(ownerID) || !pet.IsActive() {
+			return nil, domain.NewForbiddenError("you do not own this pet profile")
+		}
+		fillPetSpecFromProfile(req, pet)
+		allergies = pet.Allergies()
+	}
+
+	now := time.Now().UTC()
+	tripAt := now
+	if req.ScheduledAt != nil {
+		tripAt = req.ScheduledAt.UTC()
+	}
+	report := c.policy.Evaluate(bookingDomain.EligibilityInput{
+		PetSpec:   buildPetSpecification(req.PetSpec),
+		Allergies: allergies,
+		VetNote:   req.VetNote,
+		TripAt:    tripAt,
+	}, now)
+
+	if blocking := report.Blocking(); len(blocking) > 0 {
+		codes := make([]string, len(blocking))
+		for i, f := range blocking {
+			codes[i] = f.Code
+		}
+		c.logger.Info("booking rejected by eligibility policy",
+			zap.String("owner_id", ownerID.String()),
+			zap.Strings("codes", codes),
+		)
+		return nil, &IneligiblePetError{Findings: blocking}
+	}
+	return report, nil
+}
+
+// fillPetSpecFromProfile copies the pet profile into the fields the request left empty.
+func fillPetSpecFromProfile(req *CreateBookingRequest, pet *petDomain.Pet) {
+	spec := &req.PetSpec
+	if spec.Name == "" {
+		spec.Name = pet.Name()
+	}
+	if spec.PetType == "" {
+		spec.PetType = pet.PetType()
+	}
+	if spec.Breed == "" {
+		spec.Breed = pet.Breed()
+	}
+	if spec.WeightKg == 0 {
+		spec.WeightKg = pet.WeightKg()
+	}
+	if spec.Age == 0 {
+		spec.Age = pet.AgeMonths()
+	}
+	if spec.SpecialNeeds == "" {
+		spec.SpecialNeeds = pet.SpecialNeeds()
+	}
+	if spec.PhotoURL == "" {
+		spec.PhotoURL = pet.PhotoURL()
+	}
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking event types emitted by this service that are not (yet) part of lib-proto.
+// They are published on events.TopicBookingEvents like the shared booking events.
+const (
+	// BookingRunnerReleased is emitted when the system takes an accepted booking away
+	// from its runner (e.g. runner suspended or offline) and puts it back to "requested".
+	BookingRunnerReleased = "booking.runner_released"
+
+	// BookingDelayed is emitted when the live ETA of an in-progress booking slips past
+	// the original estimate by more than the configured threshold.
+	BookingDelayed = "booking.delayed"
+
+	// BookingAdminOverride is emitted when an admin forces a booking out of its normal
+	// lifecycle (force-cancel, reassign, revert to accepted, force-complete).
+	BookingAdminOverride = "booking.admin_override"
+
+	// RunnerReliabilityScored carries a runner's periodic reliability score for the
+	// dispatch service.
+	RunnerReliabilityScored = "booking.runner_reliability_scored"
+
+	// BookingStopConfirmed is emitted when the runner reaches an intermediate stop
+	// of a multi-stop booking.
+	BookingStopConfirmed = "booking.stop_confirmed"
+
+	// BookingOffered is emitted when the dispatcher offers a booking to a runner, who
+	// must answer before the offer expires.
+	BookingOffered = "booking.offered"
+
+	// SeriesOccurrenceSkipped is emitted when the series generator cannot create the
+	// booking for an occurrence and leaves that date out.
+	SeriesOccurrenceSkipped = "booking.series_occurrence_skipped"
+)
+
+// BookingRunnerReleasedEvent notifies the owner that their booking lost its runner
+// and is waiting for a new one.
+type BookingRunnerReleasedEvent struct {
+	BookingID     uuid.UUID `json:"booking_id"`
+	BookingNumber string    `json:"booking_number"`
+	OwnerID       uuid.UUID `json:"owner_id"`
+	RunnerID      uuid.UUID `json:"runner_id"`
+	Reason        string    `json:"reason"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// BookingDelayedEvent notifies the owner that their pet will arrive later than estimated.
+type BookingDelayedEvent struct {
+	BookingID           uuid.UUID `json:"booking_id"`
+	BookingNumber       string    `json:"booking_number"`
+	OwnerID             uuid.UUID `json:"owner_id"`
+	RunnerID            uuid.UUID `json:"runner_id"`
+	OriginalArrivalAt   time.Time `json:"original_arrival_at"`
+	EstimatedArrivalAt  time.Time `json:"estimated_arrival_at"`
+	DelayMin            int       `json:"delay_min"`
+	RemainingDistanceKm float64   `json:"remaining_distance_km"`
+	OccurredAt          time.Time `json:"occurred_at"`
+}
+
+// BookingAdminOverrideEvent records an admin override for audit and downstream services.
+type BookingAdminOverrideEvent struct {
+	BookingID        uuid.UUID  `json:"booking_id"`
+	BookingNumber    string     `json:"booking_number"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	AdminID          uuid.UUID  `json:"admin_id"`
+	Action           string     `json:"action"`
+	FromStatus       string     `json:"from_status"`
+	ToStatus         string     `json:"to_status"`
+	PreviousRunnerID *uuid.UUID `json:"previous_runner_id,omitempty"`
+	RunnerID         *uuid.UUID `json:"runner_id,omitempty"`
+	FinalPriceCents  *int64     `json:"final_price_cents,omitempty"`
+	Reason           string     `json:"reason"`
+	OccurredAt       time.Time  `json:"occurred_at"`
+}
+
+// RunnerReliabilityScoredEvent reports a runner's accept, decline, cancel and completion
+// rates over a trailing window. Score is omitted while the sample is too small.
+type RunnerReliabilityScoredEvent struct {
+	RunnerID       uuid.UUID `json:"runner_id"`
+	WindowStart    time.Time `json:"window_start"`
+	WindowEnd      time.Time `json:"window_end"`
+	Accepted       int64     `json:"accepted"`
+	Declined       int64     `json:"declined"`
+	Cancelled      int64     `json:"cancelled"`
+	Completed      int64     `json:"completed"`
+	AcceptRate     float64   `json:"accept_rate"`
+	DeclineRate    float64   `json:"decline_rate"`
+	CancelRate     float64   `json:"cancel_rate"`
+	CompletionRate float64   `json:"completion_rate"`
+	Score          *float64  `json:"score,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// BookingStopConfirmedEvent tells the owner the runner has reached a stop on the way
+// to the dropoff.
+type BookingStopConfirmedEvent struct {
+	BookingID      uuid.UUID `json:"booking_id"`
+	BookingNumber  string    `json:"booking_number"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	RunnerID       uuid.UUID `json:"runner_id"`
+	Sequence       int       `json:"sequence"`
+	Purpose        string    `json:"purpose"`
+	RemainingStops int       `json:"remaining_stops"`
+	ConfirmedAt    time.Time `json:"confirmed_at"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// BookingOfferedEvent tells a runner a booking is being held for them.
+type BookingOfferedEvent struct {
+	OfferID              uuid.UUID `json:"offer_id"`
+	BookingID            uuid.UUID `json:"booking_id"`
+	BookingNumber        string    `json:"booking_number"`
+	RunnerID             uuid.UUID `json:"runner_id"`
+	Round                int       `json:"round"`
+	PickupDistanceKm     float64   `json:"pickup_distance_km"`
+	EstimatedPayoutCents int64     `json:"estimated_payout_cents"`
+	ExpiresAt            time.Time `json:"expires_at"`
+	OccurredAt           time.Time `json:"occurred_at"`
+}
+
+// SeriesOccurrenceSkippedEvent tells the owner a trip of their series was not booked.
+type SeriesOccurrenceSkippedEvent struct {
+	SeriesID    uuid.UUID `json:"series_id"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Date        string    `json:"date"`
+	Reason      string    `json:"reason"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// exportBatchSize is how many rows each cursor fetch pulls from Postgres.
+const exportBatchSize = 1000
+
+// Export formats supported by the booking export.
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportService streams bookings for finance reconciliation.
+type ExportService struct {
+	repo   *repository.GormBookingExportRepository
+	logger *zap.Logger
+}
+
+// NewExportService creates a new ExportService.
+func NewExportService(repo *repository.GormBookingExportRepository, logger *zap.Logger) *ExportService {
+	return &ExportService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// BookingExportQuery holds the query parameters for the booking export.
+type BookingExportQuery struct {
+	Format string `form:"format"` // csv (default) or ndjson
+	From   string `form:"from"`   // RFC3339 or YYYY-MM-DD, inclusive
+	To     string `form:"to"`     // RFC3339 or YYYY-MM-DD, exclusive
+	Status string `form:"status"` // comma-separated
+}
+
+// BookingExportDTO is one exported booking. Prices are in cents.
+type BookingExportDTO struct {
+	ID                  uuid.UUID  `json:"id"`
+	BookingNumber       string     `json:"booking_number"`
+	Status              string     `json:"status"`
+	OwnerID             uuid.UUID  `json:"owner_id"`
+	RunnerID            *uuid.UUID `json:"runner_id"`
+	PetType             string     `json:"pet_type"`
+	EstimatedPriceCents int64      `json:"estimated_price_cents"`
+	FinalPriceCents     *int64     `json:"final_price_cents"`
+	Currency            string     `json:"currency"`
+	CreatedAt           time.Time  `json:"created_at"`
+	ScheduledAt         *time.Time `json:"scheduled_at"`
+	PickedUpAt          *time.Time `json:"picked_up_at"`
+	DeliveredAt         *time.Time `json:"delivered_at"`
+	CompletedAt         *time.Time `json:"completed_at"`
+	CancelledAt         *time.Time `json:"cancelled_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// ValidateExport checks the export query before any response is written, so that
+// bad parameters can still be reported with a proper status code.
+func (s *ExportService) ValidateExport(q BookingExportQuery) error {
+	switch q.Format {
+	case "", ExportFormatCSV, ExportFormatNDJSON:
+	default:
+		return domain.NewValidationError("format must be 'csv' or 'ndjson'")
+	}
+	_, err := BookingListQuery{From: q.From, To: q.To, Status: q.Status}.toDomain()
+	return err
+}
+
+// StreamBookings calls fn for every booking matching the query, oldest first. Rows are
+// read through a server-side cursor, so the export size is not bounded by memory.
+func (s *ExportService) StreamBookings(ctx context.Context, q BookingExportQuery, fn func(BookingExportDTO) error) error {
+	query, err := BookingListQuery{From: q.From, To: q.To, Status: q.Status}.toDomain()
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Stream(ctx, query.Filter, exportBatchSize, func(row repository.BookingExportRow) error {
+		return fn(toBookingExportDTO(row))
+	})
+}
+
+func toBookingExportDTO(row repository.BookingExportRow) BookingExportDTO {
+	return BookingExportDTO{
+		ID:                  row.ID,
+		BookingNumber:       row.BookingNumber,
+		Status:              row.Status,
+		OwnerID:             row.OwnerID,
+		RunnerID:            row.RunnerID,
+		PetType:             row.PetType,
+		EstimatedPriceCents: row.EstimatedPriceCents,
+		FinalPriceCents:     row.FinalPriceCents,
+		Currency:            row.Currency,
+		CreatedAt:           row.CreatedAt,
+		ScheduledAt:         row.ScheduledAt,
+		PickedUpAt:          row.PickedUpAt,
+		DeliveredAt:         row.DeliveredAt,
+		CompletedAt:         row.CompletedAt,
+		CancelledAt:         row.CancelledAt,
+		UpdatedAt:           row.UpdatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// idempotencyPendingTimeout is how long a reservation without a response blocks its
+// key. After that the request is assumed lost (e.g. the instance crashed mid-request)
+// and a retry may take the key over.
+const idempotencyPendingTimeout = time.Minute
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key comes back with a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInFlight is returned while the first request with a key is still
+	// being handled.
+	ErrIdempotencyKeyInFlight = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IdempotentResponse is a stored response, replayed for retries of the same request.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyReservation is a key claimed by Begin. Finish and Abandon only touch the
+// key while it still holds this reservation, so a request that outlived the pending
+// timeout cannot overwrite or release the reservation of a retry that took over.
+type IdempotencyReservation struct {
+	UserID      uuid.UUID
+	Key         string
+	RequestHash string
+	ReservedAt  time.Time
+}
+
+// IdempotencyService remembers the responses to requests sent with an Idempotency-Key,
+// per user, for the TTL.
+type IdempotencyService struct {
+	repo   *repository.GormIdempotencyRepository
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// NewIdempotencyService creates a new IdempotencyService.
+func NewIdempotencyService(repo *repository.GormIdempotencyRepository, ttl time.Duration, logger *zap.Logger) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl, logger: logger}
+}
+
+// Begin reserves the user's key for a request with the given hash. It returns the
+// reservation when the caller should handle the request and then call Finish or
+// Abandon, or the stored response when the same request was already handled.
+func (s *IdempotencyService) Begin(ctx context.Context, userID uuid.UUID, key, requestHash string) (*IdempotencyReservation, *IdempotentResponse, error) {
+	var existing *repository.IdempotencyKeyModel
+	// A second try covers a key purged between the failed reservation and the read.
+	for try := 0; try < 2 && existing == nil; try++ {
+		// Postgres keeps microseconds; the reservation is matched on this time later.
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		found, reserved, err := s.repo.Reserve(ctx, userID, key, requestHash, now, now.Add(s.ttl), now.Add(-idempotencyPendingTimeout))
+		if err != nil {
+			return nil, nil, err
+		}
+		if reserved {
+			return &IdempotencyReservation{UserID: userID, Key: key, RequestHash: requestHash, ReservedAt: now}, nil, nil
+		}
+		existing = found
+	}
+	if existing == nil {
+		return nil, nil, ErrIdempotencyKeyInFlight
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, nil, ErrIdempotencyKeyReused
+	}
+	if existing.StatusCode == nil {
+		return nil, nil, ErrIdempotencyKeyInFlight
+	}
+	return nil, &IdempotentResponse{
+		StatusCode:  *existing.StatusCode,
+		ContentType: existing.ContentType,
+		Body:        existing.ResponseBody,
+	}, nil
+}
+
+// Finish stores the response to a request reserved with Begin. A failure is logged:
+// the request itself has already succeeded, and the reservation lapses after a minute.
+func (s *IdempotencyService) Finish(ctx context.Context, res *IdempotencyReservation, resp IdempotentResponse) {
+	stored, err := s.repo.Complete(ctx, res.UserID, res.Key, res.RequestHash, res.ReservedAt, resp.StatusCode, resp.ContentType, resp.Body)
+	if err != nil {
+		s.logger.Error("failed to store idempotent response", zap.String("key", res.Key), zap.Error(err))
+		return
+	}
+	if !stored {
+		s.logger.Warn("idempotency key was taken over before the response was stored", zap.String("key", res.Key))
+	}
+}
+
+// Abandon releases a reservation without storing a response, so the client can retry
+// with the same key, e.g. after a server error.
+func (s *IdempotencyService) Abandon(ctx context.Context, res *IdempotencyReservation) {
+	if err := s.repo.Release(ctx, res.UserID, res.Key, res.RequestHash, res.ReservedAt); err != nil {
+		s.logger.Error("failed to release idempotency key", zap.String("key", res.Key), zap.Error(err))
+	}
+}
+
+// PurgeExpired deletes keys past their TTL.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, time.Now().UTC())
+}
+
+// RunRetention periodically purges expired keys until the context is cancelled.
+func (s *IdempotencyService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.PurgeExpired(ctx)
+			if err != nil {
+				s.logger.Error("failed to purge idempotency keys", zap.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Info("purged expired idempotency keys", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
 	photoDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/photo"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -30,13 +32,20 @@ type PhotoDTO struct {
 
 // PhotoService handles booking photo use cases.
 type PhotoService struct {
-	repo   photoDomain.PhotoRepository
-	logger *zap.Logger
+	repo        photoDomain.PhotoRepository
+	bookingRepo bookingDomain.BookingRepository
+	updates     *realtime.Hub
+	logger      *zap.Logger
 }
 
 // NewPhotoService creates a new PhotoService.
-func NewPhotoService(repo photoDomain.PhotoRepository, logger *zap.Logger) *PhotoService {
-	return &PhotoService{repo: repo, logger: logger}
+func NewPhotoService(
+	repo photoDomain.PhotoRepository,
+	bookingRepo bookingDomain.BookingRepository,
+	updates *realtime.Hub,
+	logger *zap.Logger,
+) *PhotoService {
+	return &PhotoService{repo: repo, bookingRepo: bookingRepo, updates: updates, logger: logger}
 }
 
 // UploadPhoto creates a new proof photo for a booking.
@@ -61,7 +70,9 @@ func (s *PhotoService) UploadPhoto(ctx context.Context, bookingID, runnerID uuid
 		zap.String("photo_type", req.PhotoType),
 	)
 
-	return toPhotoDTO(photo), nil
+	result := toPhotoDTO(photo)
+	s.notifyPhotoUploaded(ctx, result)
+	return result, nil
 }
 
 // GetBookingPhotos returns all photos for a booking.
@@ -78,6 +89,23 @@ func (s *PhotoService) GetBookingPhotos(ctx context.Context, bookingID uuid.UUID
 	return dtos, nil
 }
 
+// notifyPhotoUploaded pushes the new photo to real-time subscribers of its booking.
+func (s *PhotoService) notifyPhotoUploaded(ctx context.Context, photo *PhotoDTO) {
+	if s.updates == nil {
+		return
+	}
+	bk, err := s.bookingRepo.FindByID(ctx, photo.BookingID)
+	if err != nil {
+		s.logger.Warn("failed to load booking for photo update",
+			zap.String("booking_id", photo.BookingID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	publishUpdate(ctx, s.updates, s.logger, realtime.UpdatePhotoUploaded,
+		bk.ID(), bk.OwnerID(), bk.RunnerID(), string(bk.Status()), photo)
+}
+
 func toPhotoDTO(p *photoDomain.BookingPhoto) *PhotoDTO {
 	return &PhotoDTO{
 		ID:        p.ID(),
@@ -0,0 +1,285 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultReliabilityWindow is the look-back window for decline and reliability metrics.
+	defaultReliabilityWindow = 30 * 24 * time.Hour
+	// minReliabilitySample is the number of accepted jobs below which no score is given.
+	minReliabilitySample = 5
+	// maxReliabilityPublishBatch caps how many runners one publish run scores.
+	maxReliabilityPublishBatch = 10000
+)
+
+// DeclineAnalyticsQuery holds the query parameters for the decline analytics endpoints.
+type DeclineAnalyticsQuery struct {
+	From        string `form:"from"` // RFC3339 or YYYY-MM-DD, inclusive
+	To          string `form:"to"`   // RFC3339 or YYYY-MM-DD, exclusive
+	Limit       int    `form:"limit"`
+	MinDeclines int    `form:"min_declines"`
+}
+
+// DeclineReasonStatDTO is one decline reason with its frequency.
+type DeclineReasonStatDTO struct {
+	Reason  string `json:"reason"`
+	Count   int64  `json:"count"`
+	Runners int64  `json:"runners"`
+}
+
+// MultiDeclineBookingDTO is a booking that kept being declined.
+type MultiDeclineBookingDTO struct {
+	BookingID      uuid.UUID `json:"booking_id"`
+	BookingNumber  string    `json:"booking_number"`
+	Status         string    `json:"status"`
+	Declines       int64     `json:"declines"`
+	Runners        int64     `json:"runners"`
+	LastDeclinedAt time.Time `json:"last_declined_at"`
+}
+
+// DeclineAnalyticsDTO summarises runner declines over a window.
+type DeclineAnalyticsDTO struct {
+	From                  time.Time                `json:"from"`
+	To                    time.Time                `json:"to"`
+	TotalDeclines         int64                    `json:"total_declines"`
+	AvgAcceptToDeclineSec *float64                 `json:"avg_accept_to_decline_sec,omitempty"`
+	TopReasons            []DeclineReasonStatDTO   `json:"top_reasons"`
+	MultiDeclineBookings  []MultiDeclineBookingDTO `json:"multi_decline_bookings"`
+}
+
+// RunnerReliabilityDTO holds a runner's activity and reliability over a window.
+// Rates are fractions of accepted jobs, except AcceptRate, which is the share of the
+// runner's accept/decline decisions that were accepts (offers are not tracked here).
+// Score is 0-100 and omitted until the runner has minReliabilitySample accepted jobs.
+type RunnerReliabilityDTO struct {
+	RunnerID              uuid.UUID `json:"runner_id"`
+	From                  time.Time `json:"from"`
+	To                    time.Time `json:"to"`
+	Accepted              int64     `json:"accepted"`
+	Declined              int64     `json:"declined"`
+	Cancelled             int64     `json:"cancelled"`
+	Completed             int64     `json:"completed"`
+	AcceptRate            float64   `json:"accept_rate"`
+	DeclineRate           float64   `json:"decline_rate"`
+	CancelRate            float64   `json:"cancel_rate"`
+	CompletionRate        float64   `json:"completion_rate"`
+	AvgAcceptToDeclineSec *float64  `json:"avg_accept_to_decline_sec,omitempty"`
+	Score                 *float64  `json:"score,omitempty"`
+}
+
+// GetDeclineAnalytics returns decline totals, top reasons and repeatedly declined bookings.
+func (s *AnalyticsService) GetDeclineAnalytics(ctx context.Context, q DeclineAnalyticsQuery) (*DeclineAnalyticsDTO, error) {
+	from, to, err := resolveWindow(q.From, q.To, defaultReliabilityWindow)
+	if err != nil {
+		return nil, err
+	}
+	limit := clampLimit(q.Limit)
+	minDeclines := q.MinDeclines
+	if minDeclines < 2 {
+		minDeclines = 2
+	}
+
+	summary, err := s.repo.DeclineSummary(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	reasons, err := s.repo.TopDeclineReasons(ctx, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.repo.MultiDeclineBookings(ctx, from, to, minDeclines, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DeclineAnalyticsDTO{
+		From:                  from,
+		To:                    to,
+		TotalDeclines:         summary.TotalDeclines,
+		AvgAcceptToDeclineSec: summary.AvgAcceptToDeclineSec,
+		TopReasons:            make([]DeclineReasonStatDTO, len(reasons)),
+		MultiDeclineBookings:  make([]MultiDeclineBookingDTO, len(bookings)),
+	}
+	for i, r := range reasons {
+		result.TopReasons[i] = DeclineReasonStatDTO{Reason: r.Reason, Count: r.Count, Runners: r.Runners}
+	}
+	for i, b := range bookings {
+		result.MultiDeclineBookings[i] = MultiDeclineBookingDTO{
+			BookingID:      b.BookingID,
+			BookingNumber:  b.BookingNumber,
+			Status:         b.Status,
+			Declines:       b.Declines,
+			Runners:        b.Runners,
+			LastDeclinedAt: b.LastDeclinedAt,
+		}
+	}
+	return result, nil
+}
+
+// ListRunnerReliability returns per-runner decline rates and reliability, most declines first.
+func (s *AnalyticsService) ListRunnerReliability(ctx context.Context, q DeclineAnalyticsQuery) ([]RunnerReliabilityDTO, error) {
+	from, to, err := resolveWindow(q.From, q.To, defaultReliabilityWindow)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.RunnerActivity(ctx, from, to, nil, clampLimit(q.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RunnerReliabilityDTO, len(rows))
+	for i, row := range rows {
+		result[i] = toRunnerReliabilityDTO(row, from, to)
+	}
+	return result, nil
+}
+
+// GetRunnerReliability returns one runner's reliability over the window.
+func (s *AnalyticsService) GetRunnerReliability(ctx context.Context, runnerID uuid.UUID, q DeclineAnalyticsQuery) (*RunnerReliabilityDTO, error) {
+	from, to, err := resolveWindow(q.From, q.To, defaultReliabilityWindow)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.RunnerActivity(ctx, from, to, &runnerID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	row := repository.RunnerActivityRow{RunnerID: runnerID}
+	if len(rows) > 0 {
+		row = rows[0]
+	}
+	result := toRunnerReliabilityDTO(row, from, to)
+	return &result, nil
+}
+
+// PublishRunnerReliability scores every runner active in the trailing window and
+// publishes a RunnerReliabilityScored event per runner for the dispatch service.
+func (s *AnalyticsService) PublishRunnerReliability(ctx context.Context, window time.Duration) (int, error) {
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	rows, err := s.repo.RunnerActivity(ctx, from, to, nil, maxReliabilityPublishBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, row := range rows {
+		r := toRunnerReliabilityDTO(row, from, to)
+		evt := RunnerReliabilityScoredEvent{
+			RunnerID:       r.RunnerID,
+			WindowStart:    r.From,
+			WindowEnd:      r.To,
+			Accepted:       r.Accepted,
+			Declined:       r.Declined,
+			Cancelled:      r.Cancelled,
+			Completed:      r.Completed,
+			AcceptRate:     r.AcceptRate,
+			DeclineRate:    r.DeclineRate,
+			CancelRate:     r.CancelRate,
+			CompletionRate: r.CompletionRate,
+			Score:          r.Score,
+			OccurredAt:     to,
+		}
+		publishCloudEvent(ctx, s.producer, s.logger, events.TopicBookingEvents, RunnerReliabilityScored, evt)
+	}
+	return len(rows), nil
+}
+
+// RunReliabilityPublisher publishes runner reliability scores every interval until
+// ctx is cancelled.
+func (s *AnalyticsService) RunReliabilityPublisher(ctx context.Context, interval, window time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.PublishRunnerReliability(ctx, window)
+			if err != nil {
+				s.logger.Error("failed to publish runner reliability", zap.Error(err))
+				continue
+			}
+			s.logger.Info("published runner reliability scores", zap.Int("runners", n))
+		}
+	}
+}
+
+// toRunnerReliabilityDTO derives rates and the reliability score from raw activity.
+// The score weights completion most, then penalises declines and runner cancellations.
+func toRunnerReliabilityDTO(row repository.RunnerActivityRow, from, to time.Time) RunnerReliabilityDTO {
+	r := RunnerReliabilityDTO{
+		RunnerID:              row.RunnerID,
+		From:                  from,
+		To:                    to,
+		Accepted:              row.Accepted,
+		Declined:              row.Declined,
+		Cancelled:             row.Cancelled,
+		Completed:             row.Completed,
+		AvgAcceptToDeclineSec: row.AvgAcceptToDeclineSec,
+	}
+	if decisions := row.Accepted + row.Declined; decisions > 0 {
+		r.AcceptRate = float64(row.Accepted) / float64(decisions)
+	}
+	if row.Accepted > 0 {
+		accepted := float64(row.Accepted)
+		r.DeclineRate = clampUnit(float64(row.Declined) / accepted)
+		r.CancelRate = clampUnit(float64(row.Cancelled) / accepted)
+		r.CompletionRate = clampUnit(float64(row.Completed) / accepted)
+	}
+	if row.Accepted >= minReliabilitySample {
+		score := 100 * (0.6*r.CompletionRate + 0.25*(1-r.DeclineRate) + 0.15*(1-r.CancelRate))
+		r.Score = &score
+	}
+	return r
+}
+
+// resolveWindow parses an optional [from, to) range, defaulting to the trailing window.
+func resolveWindow(fromStr, toStr string, window time.Duration) (time.Time, time.Time, error) {
+	from, err := parseListTime("from", fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parseListTime("to", toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to == nil {
+		now := time.Now().UTC()
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-window)
+		from = &start
+	}
+	if !from.Before(*to) {
+		return time.Time{}, time.Time{}, domain.NewValidationError("from must be before to")
+	}
+	return from.UTC(), to.UTC(), nil
+}
+
+func clampLimit(limit int) int {
+	if limit < 1 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
+
+func clampUnit(v float64) float64 {
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ReturnLegRequest schedules the trip back from the dropoff to the pickup address.
+type ReturnLegRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+	// Notes for the return leg; defaults to the outbound notes.
+	Notes string `json:"notes"`
+}
+
+// createRoundTrip saves an already built outbound booking together with its return
+// leg in one transaction. Both legs share a parent ID and are priced with the
+// round-trip discount.
+func (s *BookingService) createRoundTrip(ctx context.Context, ownerID uuid.UUID, req CreateBookingRequest, outbound *bookingDomain.Booking) (*BookingDTO, error) {
+	returnAt := req.ReturnLeg.ScheduledAt.UTC()
+	if earliest := outbound.EarliestReturnAt(time.Now().UTC()); returnAt.Before(earliest) {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"return_leg.scheduled_at must be at or after %s (outbound pickup plus estimated trip time)",
+			earliest.Format(time.RFC3339),
+		))
+	}
+
+	notes := req.ReturnLeg.Notes
+	if notes == "" {
+		notes = req.Notes
+	}
+	ret, err := s.buildBooking(ctx, ownerID, CreateBookingRequest{
+		PetSpec:        req.PetSpec,
+		PickupAddress:  req.DropoffAddress,
+		DropoffAddress: req.PickupAddress,
+		ScheduledAt:    &returnAt,
+		Notes:          notes,
+
+		PickupAddressID:  req.DropoffAddressID,
+		DropoffAddressID: req.PickupAddressID,
+		PetID:            req.PetID,
+		VetNote:          req.VetNote,
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID := uuid.New()
+	outbound.LinkRoundTrip(parentID, bookingDomain.LegOutbound)
+	ret.LinkRoundTrip(parentID, bookingDomain.LegReturn)
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txBookingRepo := repository.NewGormBookingRepository(tx)
+		if err := txBookingRepo.Save(ctx, outbound); err != nil {
+			return err
+		}
+		return txBookingRepo.Save(ctx, ret)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save round-trip bookings: %w", err)
+	}
+
+	s.publishBookingRequested(ctx, outbound)
+	s.publishBookingRequested(ctx, ret)
+
+	result := toBookingDTO(outbound)
+	returnDTO := toBookingDTO(ret)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, outbound, result)
+	s.notifyUpdate(ctx, realtime.UpdateStatusChanged, ret, returnDTO)
+
+	result.ReturnLeg = &returnDTO
+	return &result, nil
+}
+
+// cascadeCancelReturnLeg cancels the return leg of a round trip whose outbound leg
+// was cancelled before pickup, and returns it. A return leg that has already started
+// is left alone. Failures are logged: the outbound cancellation has committed.
+func (s *BookingService) cascadeCancelReturnLeg(ctx context.Context, outbound *bookingDomain.Booking, from bookingDomain.BookingStatus, cancelledBy uuid.UUID) *BookingDTO {
+	if !outbound.IsOutboundLeg() {
+		return nil
+	}
+	if from != bookingDomain.StatusRequested && from != bookingDomain.StatusAccepted {
+		return nil
+	}
+
+	legs, err := s.repo.FindByParentID(ctx, *outbound.ParentID())
+	if err != nil {
+		s.logger.Error("failed to find return leg",
+			zap.String("booking_id", outbound.ID().String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	for _, leg := range legs {
+		if leg.Leg() != bookingDomain.LegReturn {
+			continue
+		}
+		if leg.Status() != bookingDomain.StatusRequested && leg.Status() != bookingDomain.StatusAccepted {
+			return nil
+		}
+		result, err := s.CancelBooking(ctx, leg.ID(), cancelledBy, "outbound leg cancelled")
+		if err != nil {
+			s.logger.Error("failed to cancel return leg",
+				zap.String("booking_id", outbound.ID().String()),
+				zap.String("return_booking_id", leg.ID().String()),
+				zap.Error(err),
+			)
+			return nil
+		}
+		return result
+	}
+	return nil
+}
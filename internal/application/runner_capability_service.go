@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	runnerDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/runner"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UpdateRunnerCapabilitiesRequest is a runner capability profile as published by the
+// runner service.
+type UpdateRunnerCapabilitiesRequest struct {
+	RunnerID       uuid.UUID `json:"runner_id"`
+	CrateSizes     []string  `json:"crate_sizes"`
+	CoveredCarrier bool      `json:"covered_carrier"`
+	ClimateControl bool      `json:"climate_control"`
+	Certifications []string  `json:"certifications"`
+	MaxPetWeightKg float64   `json:"max_pet_weight_kg"`
+	// UpdatedAt is when the runner service recorded the profile.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RunnerCapabilitiesDTO is the response representation of a runner capability profile.
+type RunnerCapabilitiesDTO struct {
+	RunnerID       uuid.UUID `json:"runner_id"`
+	CrateSizes     []string  `json:"crate_sizes"`
+	CoveredCarrier bool      `json:"covered_carrier"`
+	ClimateControl bool      `json:"climate_control"`
+	Certifications []string  `json:"certifications"`
+	MaxPetWeightKg float64   `json:"max_pet_weight_kg"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// InvalidCapabilitiesError is returned when a capability profile fails validation.
+// Storing the same profile again cannot succeed.
+type InvalidCapabilitiesError struct {
+	Err error
+}
+
+func (e *InvalidCapabilitiesError) Error() string { return e.Err.Error() }
+
+func (e *InvalidCapabilitiesError) Unwrap() error { return e.Err }
+
+// RunnerCapabilityService keeps runner capability profiles and checks them against
+// the crate requirements of bookings runners try to accept.
+type RunnerCapabilityService struct {
+	repo runnerDomain.CapabilitiesRepository
+	// required rejects runners without a profile; otherwise they are let through.
+	required bool
+	logger   *zap.Logger
+}
+
+// NewRunnerCapabilityService creates a new RunnerCapabilityService. With required
+// set, runners the service has no profile for cannot accept bookings.
+func NewRunnerCapabilityService(repo runnerDomain.CapabilitiesRepository, required bool, logger *zap.Logger) *RunnerCapabilityService {
+	return &RunnerCapabilityService{repo: repo, required: required, logger: logger}
+}
+
+// UpdateCapabilities stores a runner's capability profile. Profiles older than the
+// stored one are ignored; invalid ones are rejected with an InvalidCapabilitiesError.
+func (s *RunnerCapabilityService) UpdateCapabilities(ctx context.Context, req UpdateRunnerCapabilitiesRequest) error {
+	if req.UpdatedAt.IsZero() {
+		req.UpdatedAt = time.Now().UTC()
+	}
+	c, err := runnerDomain.NewCapabilities(
+		req.RunnerID, req.CrateSizes, req.CoveredCarrier, req.ClimateControl, req.Certifications, req.MaxPetWeightKg, req.UpdatedAt,
+	)
+	if err != nil {
+		return &InvalidCapabilitiesError{Err: err}
+	}
+	stored, err := s.repo.Upsert(ctx, c)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		s.logger.Info("ignoring stale runner capabilities",
+			zap.String("runner_id", req.RunnerID.String()),
+			zap.Time("updated_at", c.UpdatedAt()),
+		)
+		return nil
+	}
+	s.logger.Info("runner capabilities updated", zap.String("runner_id", req.RunnerID.String()))
+	return nil
+}
+
+// GetCapabilities returns a runner's capability profile.
+func (s *RunnerCapabilityService) GetCapabilities(ctx context.Context, runnerID uuid.UUID) (*RunnerCapabilitiesDTO, error) {
+	c, err := s.repo.FindByRunnerID(ctx, runnerID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, domain.NewNotFoundError("RunnerCapabilities", runnerID.String())
+	}
+	result := toRunnerCapabilitiesDTO(c)
+	return &result, nil
+}
+
+// ensureCanCarry returns a forbidden error naming everything the runner lacks to
+// carry a pet with the given crate requirement.
+func (s *RunnerCapabilityService) ensureCanCarry(ctx context.Context, runnerID uuid.UUID, req bookingDomain.CrateRequirement) error {
+	c, err := s.repo.FindByRunnerID(ctx, runnerID)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		if s.required {
+			return domain.NewForbiddenError("runner cannot take this booking: no capability profile on record")
+		}
+		s.logger.Warn("no capability profile for runner; skipping capability check",
+			zap.String("runner_id", runnerID.String()),
+		)
+		return nil
+	}
+	if missing := c.Missing(req); len(missing) > 0 {
+		return domain.NewForbiddenError(fmt.Sprintf(
+			"runner cannot take this booking: missing %s", strings.Join(missing, ", "),
+		))
+	}
+	return nil
+}
+
+// carryFilter returns a function reporting whether the runner can carry a pet with a
+// given crate requirement, loading the runner's profile once.
+func (s *RunnerCapabilityService) carryFilter(ctx context.Context, runnerID uuid.UUID) (func(bookingDomain.CrateRequirement) bool, error) {
+	c, err := s.repo.FindByRunnerID(ctx, runnerID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		required := s.required
+		return func(bookingDomain.CrateRequirement) bool { return !required }, nil
+	}
+	return func(req bookingDomain.CrateRequirement) bool { return len(c.Missing(req)) == 0 }, nil
+}
+
+func toRunnerCapabilitiesDTO(c *runnerDomain.Capabilities) RunnerCapabilitiesDTO {
+	sizes := make([]string, len(c.CrateSizes()))
+	for i, size := range c.CrateSizes() {
+		sizes[i] = string(size)
+	}
+	return RunnerCapabilitiesDTO{
+		RunnerID:       c.RunnerID(),
+		CrateSizes:     sizes,
+		CoveredCarrier: c.CoveredCarrier(),
+		ClimateControl: c.ClimateControl(),
+		Certifications: c.Certifications(),
+		MaxPetWeightKg: c.MaxPetWeightKg(),
+		UpdatedAt:      c.UpdatedAt(),
+	}
+}
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	savedAddressDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/savedaddress"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SavedAddressRequest creates or replaces a saved address.
+type SavedAddressRequest struct {
+	// Label names the address, e.g. "Home" or "Vet"; unique per owner.
+	Label        string         `json:"label" binding:"required"`
+	Address      dto.AddressDTO `json:"address" binding:"required"`
+	AccessNotes  string         `json:"access_notes"`
+	ContactPhone string         `json:"contact_phone"`
+}
+
+// SavedAddressDTO is the response representation of a saved address.
+type SavedAddressDTO struct {
+	ID           uuid.UUID      `json:"id"`
+	OwnerID      uuid.UUID      `json:"owner_id"`
+	Label        string         `json:"label"`
+	Address      dto.AddressDTO `json:"address"`
+	AccessNotes  string         `json:"access_notes,omitempty"`
+	ContactPhone string         `json:"contact_phone,omitempty"`
+	Version      int64          `json:"version"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// SavedAddressService manages owners' address books and resolves the saved addresses
+// a booking refers to.
+type SavedAddressService struct {
+	repo      savedAddressDomain.SavedAddressRepository
+	addresses *AddressVerifier
+	logger    *zap.Logger
+}
+
+// NewSavedAddressService creates a new SavedAddressService. With a non-nil addresses
+// verifier, addresses are normalised and their coordinates checked when saved.
+func NewSavedAddressService(repo savedAddressDomain.SavedAddressRepository, addresses *AddressVerifier, logger *zap.Logger) *SavedAddressService {
+	return &SavedAddressService{repo: repo, addresses: addresses, logger: logger}
+}
+
+// CreateAddress adds an address to the owner's address book.
+func (s *SavedAddressService) CreateAddress(ctx context.Context, ownerID uuid.UUID, req SavedAddressRequest) (*SavedAddressDTO, error) {
+	address, err := s.verify(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+	a, err := savedAddressDomain.NewSavedAddress(ownerID, req.Label, address, req.AccessNotes, req.ContactPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= savedAddressDomain.MaxPerOwner {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"an address book holds at most %d addresses", savedAddressDomain.MaxPerOwner,
+		))
+	}
+	if err := s.ensureUniqueLabel(ctx, ownerID, a.Label(), a.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, a); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("saved address created",
+		zap.String("saved_address_id", a.ID().String()),
+		zap.String("owner_id", ownerID.String()),
+	)
+	result := toSavedAddressDTO(a)
+	return &result, nil
+}
+
+// ListAddresses returns the owner's saved addresses by label.
+func (s *SavedAddressService) ListAddresses(ctx context.Context, ownerID uuid.UUID) ([]SavedAddressDTO, error) {
+	list, err := s.repo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]SavedAddressDTO, len(list))
+	for i, a := range list {
+		dtos[i] = toSavedAddressDTO(a)
+	}
+	return dtos, nil
+}
+
+// GetAddress returns a single saved address, verifying ownership.
+func (s *SavedAddressService) GetAddress(ctx context.Context, ownerID, addressID uuid.UUID) (*SavedAddressDTO, error) {
+	a, err := s.findOwned(ctx, ownerID, addressID)
+	if err != nil {
+		return nil, err
+	}
+	result := toSavedAddressDTO(a)
+	return &result, nil
+}
+
+// UpdateAddress replaces a saved address, verifying ownership. Bookings already made
+// from it keep the snapshot taken when they were created.
+func (s *SavedAddressService) UpdateAddress(ctx context.Context, ownerID, addressID uuid.UUID, req SavedAddressRequest) (*SavedAddressDTO, error) {
+	a, err := s.findOwned(ctx, ownerID, addressID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := s.verify(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Update(req.Label, address, req.AccessNotes, req.ContactPhone); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueLabel(ctx, ownerID, a.Label(), a.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+
+	result := toSavedAddressDTO(a)
+	return &result, nil
+}
+
+// DeleteAddress removes a saved address, verifying ownership.
+func (s *SavedAddressService) DeleteAddress(ctx context.Context, ownerID, addressID uuid.UUID) error {
+	if _, err := s.findOwned(ctx, ownerID, addressID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, addressID); err != nil {
+		return err
+	}
+	s.logger.Info("saved address deleted", zap.String("saved_address_id", addressID.String()))
+	return nil
+}
+
+// snapshot returns the owner's saved address with the given ID as a booking address
+// and the details the booking keeps alongside it.
+func (s *SavedAddressService) snapshot(ctx context.Context, ownerID, addressID uuid.UUID) (dto.AddressDTO, *bookingDomain.AddressDetails, error) {
+	a, err := s.findOwned(ctx, ownerID, addressID)
+	if err != nil {
+		return dto.AddressDTO{}, nil, err
+	}
+	return a.Address(), &bookingDomain.AddressDetails{
+		SavedAddressID: a.ID(),
+		Label:          a.Label(),
+		AccessNotes:    a.AccessNotes(),
+		ContactPhone:   a.ContactPhone(),
+	}, nil
+}
+
+func (s *SavedAddressService) findOwned(ctx context.Context, ownerID, addressID uuid.UUID) (*savedAddressDomain.SavedAddress, error) {
+	a, err := s.repo.FindByID(ctx, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.IsOwnedBy(ownerID) {
+		return nil, domain.NewForbiddenError("you do not own this saved address")
+	}
+	return a, nil
+}
+
+func (s *SavedAddressService) verify(ctx context.Context, address dto.AddressDTO) (dto.AddressDTO, error) {
+	if s.addresses == nil {
+		return address, nil
+	}
+	return s.addresses.verify(ctx, "address", address)
+}
+
+func (s *SavedAddressService) ensureUniqueLabel(ctx context.Context, ownerID uuid.UUID, label string, addressID uuid.UUID) error {
+	exists, err := s.repo.ExistsByLabel(ctx, ownerID, label, addressID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.NewConflictError(fmt.Sprintf("you already have an address labelled %q", label))
+	}
+	return nil
+}
+
+// applySavedAddresses replaces the pickup and dropoff of req with the saved addresses
+// it refers to by ID and returns their snapshots; nil for addresses given inline.
+func (s *BookingService) applySavedAddresses(ctx context.Context, ownerID uuid.UUID, req *CreateBookingRequest) (*bookingDomain.AddressDetails, *bookingDomain.AddressDetails, error) {
+	if req.PickupAddressID == nil && req.DropoffAddressID == nil {
+		return nil, nil, nil
+	}
+	if s.savedAddresses == nil {
+		return nil, nil, domain.NewValidationError("saved addresses are not available")
+	}
+
+	var pickup, dropoff *bookingDomain.AddressDetails
+	var err error
+	if req.PickupAddressID != nil {
+		if req.PickupAddress.Line1 != "" {
+			return nil, nil, domain.NewValidationError("give either pickup_address or pickup_address_id, not both")
+		}
+		if req.PickupAddress, pickup, err = s.savedAddresses.snapshot(ctx, ownerID, *req.PickupAddressID); err != nil {
+			return nil, nil, err
+		}
+	}
+	if req.DropoffAddressID != nil {
+		if req.DropoffAddress.Line1 != "" {
+			return nil, nil, domain.NewValidationError("give either dropoff_address or dropoff_address_id, not both")
+		}
+		if req.DropoffAddress, dropoff, err = s.savedAddresses.snapshot(ctx, ownerID, *req.DropoffAddressID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return pickup, dropoff, nil
+}
+
+func toSavedAddressDTO(a *savedAddressDomain.SavedAddress) SavedAddressDTO {
+	return SavedAddressDTO{
+		ID:           a.ID(),
+		OwnerID:      a.OwnerID(),
+		Label:        a.Label(),
+		Address:      a.Address(),
+		AccessNotes:  a.AccessNotes(),
+		ContactPhone: a.ContactPhone(),
+		Version:      a.Version(),
+		CreatedAt:    a.CreatedAt(),
+		UpdatedAt:    a.UpdatedAt(),
+	}
+}
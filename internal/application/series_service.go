@@ -0,0 +1,479 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	seriesDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/series"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// upcomingOccurrenceCount is how many future trips a series response previews.
+const upcomingOccurrenceCount = 5
+
+// CreateSeriesRequest holds the data needed to create a recurring booking series.
+type CreateSeriesRequest struct {
+	PetSpec        dto.PetSpecDTO `json:"pet_spec" binding:"required"`
+	PickupAddress  dto.AddressDTO `json:"pickup_address" binding:"required"`
+	DropoffAddress dto.AddressDTO `json:"dropoff_address" binding:"required"`
+	Notes          string         `json:"notes"`
+	// RRule is an iCalendar RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12".
+	RRule string `json:"rrule" binding:"required"`
+	// StartsAt is the first possible pickup; its local time of day applies to every trip.
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	// Timezone is an IANA zone name; defaults to Asia/Kuala_Lumpur.
+	Timezone string `json:"timezone"`
+}
+
+// SkipSeriesDateRequest names a single local date to leave out of a series.
+type SkipSeriesDateRequest struct {
+	Date string `json:"date" binding:"required"` // YYYY-MM-DD in the series timezone
+}
+
+// SeriesDTO is the response representation of a recurring booking series.
+type SeriesDTO struct {
+	ID               uuid.UUID      `json:"id"`
+	OwnerID          uuid.UUID      `json:"owner_id"`
+	PetSpec          dto.PetSpecDTO `json:"pet_spec"`
+	PickupAddress    dto.AddressDTO `json:"pickup_address"`
+	DropoffAddress   dto.AddressDTO `json:"dropoff_address"`
+	Notes            string         `json:"notes,omitempty"`
+	RRule            string         `json:"rrule"`
+	Timezone         string         `json:"timezone"`
+	StartsAt         time.Time      `json:"starts_at"`
+	Status           string         `json:"status"`
+	SkippedDates     []string       `json:"skipped_dates"`
+	GeneratedThrough *time.Time     `json:"generated_through,omitempty"`
+	NextOccurrences  []time.Time    `json:"next_occurrences"`
+	EndedAt          *time.Time     `json:"ended_at,omitempty"`
+	Version          int64          `json:"version"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// SeriesService manages recurring booking series and materialises their bookings
+// ahead of time as ordinary scheduled bookings.
+type SeriesService struct {
+	repo     seriesDomain.SeriesRepository
+	bookings *BookingService
+	db       *gorm.DB
+	horizon  time.Duration
+	logger   *zap.Logger
+}
+
+// NewSeriesService creates a new SeriesService. Bookings are generated horizon ahead.
+func NewSeriesService(
+	repo seriesDomain.SeriesRepository,
+	bookings *BookingService,
+	db *gorm.DB,
+	horizon time.Duration,
+	logger *zap.Logger,
+) *SeriesService {
+	return &SeriesService{
+		repo:     repo,
+		bookings: bookings,
+		db:       db,
+		horizon:  horizon,
+		logger:   logger,
+	}
+}
+
+// CreateSeries validates and stores a new series, then generates its first bookings.
+func (s *SeriesService) CreateSeries(ctx context.Context, ownerID uuid.UUID, req CreateSeriesRequest) (*SeriesDTO, error) {
+	sr, err := seriesDomain.NewSeries(
+		ownerID,
+		req.PetSpec,
+		req.PickupAddress,
+		req.DropoffAddress,
+		req.Notes,
+		req.RRule,
+		req.Timezone,
+		req.StartsAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	upcoming := sr.UpcomingOccurrences(now, 1)
+	if len(upcoming) == 0 {
+		return nil, domain.NewValidationError("rrule has no occurrences after starts_at")
+	}
+	// Reject templates that could never become a booking before storing them.
+	if _, err := s.bookings.buildBooking(ctx, ownerID, seriesBookingRequest(sr, upcoming[0]), false); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, sr); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.generate(ctx, sr, now); err != nil {
+		s.logger.Error("failed to generate bookings for new series",
+			zap.String("series_id", sr.ID().String()),
+			zap.Error(err),
+		)
+	}
+
+	result := toSeriesDTO(sr, now)
+	return &result, nil
+}
+
+// ListSeries returns every series of an owner, newest first.
+func (s *SeriesService) ListSeries(ctx context.Context, ownerID uuid.UUID) ([]SeriesDTO, error) {
+	list, err := s.repo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	dtos := make([]SeriesDTO, len(list))
+	for i, sr := range list {
+		dtos[i] = toSeriesDTO(sr, now)
+	}
+	return dtos, nil
+}
+
+// GetSeries returns one of the owner's series.
+func (s *SeriesService) GetSeries(ctx context.Context, ownerID, seriesID uuid.UUID) (*SeriesDTO, error) {
+	sr, err := s.findOwned(ctx, ownerID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	result := toSeriesDTO(sr, time.Now().UTC())
+	return &result, nil
+}
+
+// PauseSeries stops generating bookings and cancels generated trips that have not
+// started yet.
+func (s *SeriesService) PauseSeries(ctx context.Context, ownerID, seriesID uuid.UUID) (*SeriesDTO, error) {
+	sr, err := s.findOwned(ctx, ownerID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := sr.Pause(now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return nil, err
+	}
+
+	s.cancelSeriesBookings(ctx, sr, now, farFuture, "series paused")
+
+	result := toSeriesDTO(sr, now)
+	return &result, nil
+}
+
+// ResumeSeries restarts a paused series from now and generates its upcoming bookings.
+func (s *SeriesService) ResumeSeries(ctx context.Context, ownerID, seriesID uuid.UUID) (*SeriesDTO, error) {
+	sr, err := s.findOwned(ctx, ownerID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := sr.Resume(now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.generate(ctx, sr, now); err != nil {
+		s.logger.Error("failed to generate bookings for resumed series",
+			zap.String("series_id", sr.ID().String()),
+			zap.Error(err),
+		)
+	}
+
+	result := toSeriesDTO(sr, now)
+	return &result, nil
+}
+
+// SkipSeriesDate leaves one date out of the series and cancels its booking if it
+// was already generated and has not started.
+func (s *SeriesService) SkipSeriesDate(ctx context.Context, ownerID, seriesID uuid.UUID, req SkipSeriesDateRequest) (*SeriesDTO, error) {
+	sr, err := s.findOwned(ctx, ownerID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart, dayEnd, err := sr.Skip(req.Date)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return nil, err
+	}
+
+	s.cancelSeriesBookings(ctx, sr, dayStart, dayEnd, fmt.Sprintf("series date %s skipped", req.Date))
+
+	result := toSeriesDTO(sr, time.Now().UTC())
+	return &result, nil
+}
+
+// EndSeries permanently stops the series and cancels generated trips that have not
+// started yet.
+func (s *SeriesService) EndSeries(ctx context.Context, ownerID, seriesID uuid.UUID) (*SeriesDTO, error) {
+	sr, err := s.findOwned(ctx, ownerID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := sr.End(now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return nil, err
+	}
+
+	s.cancelSeriesBookings(ctx, sr, now, farFuture, "series ended")
+
+	result := toSeriesDTO(sr, now)
+	return &result, nil
+}
+
+// GenerateDue materialises bookings for every active series up to the generation
+// horizon. It returns the number of bookings created.
+func (s *SeriesService) GenerateDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := s.repo.FindDue(ctx, now.Add(s.horizon))
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var firstErr error
+	for _, sr := range due {
+		n, err := s.generate(ctx, sr, now)
+		created += n
+		if err != nil {
+			s.logger.Error("failed to generate series bookings",
+				zap.String("series_id", sr.ID().String()),
+				zap.Error(err),
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return created, firstErr
+}
+
+// RunGenerator generates due series bookings every interval until ctx is cancelled.
+func (s *SeriesService) RunGenerator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			created, err := s.GenerateDue(ctx)
+			if err != nil {
+				// Bookings created before the failure are committed; report them too.
+				s.logger.Warn("series generation stopped early", zap.Int("created", created), zap.Error(err))
+				continue
+			}
+			if created > 0 {
+				s.logger.Info("generated series bookings", zap.Int("created", created))
+			}
+		}
+	}
+}
+
+// generate creates a scheduled booking for each pending occurrence of an active
+// series up to the horizon. Each booking is saved in the same transaction that
+// advances the series watermark, so a crash or a concurrent generator cannot
+// create the same occurrence twice. An occurrence the domain refuses to book (e.g.
+// the address left the service area) is skipped and the owner notified, so one bad
+// trip does not hold back the rest of the series. Any other failure (database,
+// geocoder) stops the run, and the next run retries the occurrence.
+func (s *SeriesService) generate(ctx context.Context, sr *seriesDomain.Series, now time.Time) (int, error) {
+	if sr.Status() != seriesDomain.SeriesStatusActive {
+		return 0, nil
+	}
+
+	horizon := now.Add(s.horizon)
+	occurrences, exhausted := sr.PendingOccurrences(horizon)
+
+	existing := make(map[int64]bool)
+	if len(occurrences) > 0 {
+		// Trips kept through a pause (e.g. already accepted) must not be generated again.
+		bookings, err := s.bookings.repo.FindScheduledBySeries(ctx, sr.ID(), occurrences[0], occurrences[len(occurrences)-1].Add(time.Second))
+		if err != nil {
+			return 0, err
+		}
+		for _, bk := range bookings {
+			existing[bk.ScheduledAt().Unix()] = true
+		}
+	}
+
+	created := 0
+	for _, at := range occurrences {
+		if !at.After(now) || existing[at.Unix()] {
+			continue
+		}
+
+		bk, err := s.bookings.buildBooking(ctx, sr.OwnerID(), seriesBookingRequest(sr, at), false)
+		if err != nil {
+			if !isDomainRefusal(err) {
+				return created, err
+			}
+			if err := s.skipOccurrence(ctx, sr, at, err); err != nil {
+				return created, err
+			}
+			continue
+		}
+		bk.AttachToSeries(sr.ID())
+		sr.MarkGeneratedThrough(at, false)
+
+		if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := repository.NewGormBookingRepository(tx).Save(ctx, bk); err != nil {
+				return err
+			}
+			return repository.NewGormSeriesRepository(tx).Update(ctx, sr)
+		}); err != nil {
+			return created, err
+		}
+		created++
+
+		s.bookings.publishBookingRequested(ctx, bk)
+		s.bookings.notifyUpdate(ctx, realtime.UpdateStatusChanged, bk, toBookingDTO(bk))
+	}
+
+	// A finite rule ends the series after its last trip, not when it is generated.
+	sr.MarkGeneratedThrough(horizon, exhausted && len(sr.UpcomingOccurrences(now, 1)) == 0)
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// skipOccurrence records an occurrence that could not be booked as skipped, advancing
+// the watermark past it, and tells the owner why.
+func (s *SeriesService) skipOccurrence(ctx context.Context, sr *seriesDomain.Series, at time.Time, cause error) error {
+	date := sr.SkipFailedOccurrence(at)
+	if err := s.repo.Update(ctx, sr); err != nil {
+		return err
+	}
+
+	s.logger.Warn("skipped series occurrence that could not be booked",
+		zap.String("series_id", sr.ID().String()),
+		zap.Time("scheduled_at", at),
+		zap.Error(cause),
+	)
+	publishCloudEvent(ctx, s.bookings.producer, s.logger, events.TopicBookingEvents, SeriesOccurrenceSkipped, SeriesOccurrenceSkippedEvent{
+		SeriesID:    sr.ID(),
+		OwnerID:     sr.OwnerID(),
+		ScheduledAt: at,
+		Date:        date,
+		Reason:      cause.Error(),
+		OccurredAt:  time.Now().UTC(),
+	})
+	return nil
+}
+
+// isDomainRefusal reports whether err is the domain turning the request down (invalid,
+// forbidden, not found, ineligible pet), which retrying would not change.
+func isDomainRefusal(err error) bool {
+	var domainErr *domain.DomainError
+	var ineligible *IneligiblePetError
+	return errors.As(err, &domainErr) || errors.As(err, &ineligible)
+}
+
+// cancelSeriesBookings cancels the series' generated bookings scheduled in [from, to)
+// that have not been picked up yet. Failures are logged so one stuck booking does
+// not block the owner's change to the series itself.
+func (s *SeriesService) cancelSeriesBookings(ctx context.Context, sr *seriesDomain.Series, from, to time.Time, reason string) {
+	bookings, err := s.bookings.repo.FindScheduledBySeries(ctx, sr.ID(), from, to)
+	if err != nil {
+		s.logger.Error("failed to find series bookings to cancel",
+			zap.String("series_id", sr.ID().String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	for _, bk := range bookings {
+		if bk.Status() != bookingDomain.StatusRequested && bk.Status() != bookingDomain.StatusAccepted {
+			continue
+		}
+		if _, err := s.bookings.CancelBooking(ctx, bk.ID(), sr.OwnerID(), reason); err != nil {
+			s.logger.Error("failed to cancel series booking",
+				zap.String("series_id", sr.ID().String()),
+				zap.String("booking_id", bk.ID().String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// findOwned loads a series and checks that it belongs to the owner.
+func (s *SeriesService) findOwned(ctx context.Context, ownerID, seriesID uuid.UUID) (*seriesDomain.Series, error) {
+	sr, err := s.repo.FindByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if !sr.IsOwnedBy(ownerID) {
+		return nil, domain.NewForbiddenError("series does not belong to this user")
+	}
+	return sr, nil
+}
+
+// farFuture bounds "every later booking" queries.
+var farFuture = time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// seriesBookingRequest builds the booking request for one occurrence of a series.
+func seriesBookingRequest(sr *seriesDomain.Series, at time.Time) CreateBookingRequest {
+	return CreateBookingRequest{
+		PetSpec:        sr.PetSpec(),
+		PickupAddress:  sr.PickupAddress(),
+		DropoffAddress: sr.DropoffAddress(),
+		ScheduledAt:    &at,
+		Notes:          sr.Notes(),
+	}
+}
+
+func toSeriesDTO(sr *seriesDomain.Series, now time.Time) SeriesDTO {
+	skipped := sr.SkippedDates()
+	if skipped == nil {
+		skipped = []string{}
+	}
+	next := sr.UpcomingOccurrences(now, upcomingOccurrenceCount)
+	if next == nil {
+		next = []time.Time{}
+	}
+	return SeriesDTO{
+		ID:               sr.ID(),
+		OwnerID:          sr.OwnerID(),
+		PetSpec:          sr.PetSpec(),
+		PickupAddress:    sr.PickupAddress(),
+		DropoffAddress:   sr.DropoffAddress(),
+		Notes:            sr.Notes(),
+		RRule:            sr.RRule(),
+		Timezone:         sr.Timezone(),
+		StartsAt:         sr.StartsAt(),
+		Status:           string(sr.Status()),
+		SkippedDates:     skipped,
+		GeneratedThrough: sr.GeneratedThrough(),
+		NextOccurrences:  next,
+		EndedAt:          sr.EndedAt(),
+		Version:          sr.Version(),
+		CreatedAt:        sr.CreatedAt(),
+		UpdatedAt:        sr.UpdatedAt(),
+	}
+}
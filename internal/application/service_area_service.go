@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	zoneDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/zone"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ZoneRequest creates or replaces a service zone.
+type ZoneRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Geometry is a GeoJSON Polygon or MultiPolygon, positions as [longitude, latitude].
+	Geometry json.RawMessage `json:"geometry" binding:"required"`
+	// BaseFareCents replaces the standard MYR 5.00 base fare for pickups in the zone.
+	BaseFareCents *int64 `json:"base_fare_cents"`
+	// CrossZoneSurchargeCents is charged on trips between this zone and another.
+	CrossZoneSurchargeCents int64 `json:"cross_zone_surcharge_cents"`
+	// Active defaults to true.
+	Active *bool `json:"active"`
+}
+
+// ZoneDTO is the response representation of a service zone.
+type ZoneDTO struct {
+	ID                      uuid.UUID           `json:"id"`
+	Name                    string              `json:"name"`
+	Geometry                zoneDomain.Geometry `json:"geometry"`
+	BaseFareCents           *int64              `json:"base_fare_cents,omitempty"`
+	CrossZoneSurchargeCents int64               `json:"cross_zone_surcharge_cents"`
+	Active                  bool                `json:"active"`
+	Version                 int64               `json:"version"`
+	CreatedAt               time.Time           `json:"created_at"`
+	UpdatedAt               time.Time           `json:"updated_at"`
+}
+
+// ServiceAreaService manages the operating zones and checks trips against them.
+type ServiceAreaService struct {
+	repo   zoneDomain.ZoneRepository
+	logger *zap.Logger
+}
+
+// NewServiceAreaService creates a new ServiceAreaService.
+func NewServiceAreaService(repo zoneDomain.ZoneRepository, logger *zap.Logger) *ServiceAreaService {
+	return &ServiceAreaService{repo: repo, logger: logger}
+}
+
+// CreateZone validates and stores a new zone.
+func (s *ServiceAreaService) CreateZone(ctx context.Context, req ZoneRequest) (*ZoneDTO, error) {
+	geometry, err := zoneDomain.ParseGeometry(req.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	active := req.Active == nil || *req.Active
+	z, err := zoneDomain.NewZone(req.Name, geometry, req.BaseFareCents, req.CrossZoneSurchargeCents, active)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueName(ctx, z.Name(), z.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, z); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("service zone created",
+		zap.String("zone_id", z.ID().String()),
+		zap.String("name", z.Name()),
+	)
+	result := toZoneDTO(z)
+	return &result, nil
+}
+
+// ListZones returns every zone, active or not, by name.
+func (s *ServiceAreaService) ListZones(ctx context.Context) ([]ZoneDTO, error) {
+	zones, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]ZoneDTO, len(zones))
+	for i, z := range zones {
+		dtos[i] = toZoneDTO(z)
+	}
+	return dtos, nil
+}
+
+// GetZone returns a single zone.
+func (s *ServiceAreaService) GetZone(ctx context.Context, zoneID uuid.UUID) (*ZoneDTO, error) {
+	z, err := s.repo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	result := toZoneDTO(z)
+	return &result, nil
+}
+
+// UpdateZone replaces a zone's name, geometry, pricing and active flag. Existing
+// bookings keep the price they were quoted.
+func (s *ServiceAreaService) UpdateZone(ctx context.Context, zoneID uuid.UUID, req ZoneRequest) (*ZoneDTO, error) {
+	z, err := s.repo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	geometry, err := zoneDomain.ParseGeometry(req.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	active := z.Active()
+	if req.Active != nil {
+		active = *req.Active
+	}
+	if err := z.Update(req.Name, geometry, req.BaseFareCents, req.CrossZoneSurchargeCents, active); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueName(ctx, z.Name(), z.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, z); err != nil {
+		return nil, err
+	}
+
+	result := toZoneDTO(z)
+	return &result, nil
+}
+
+// DeleteZone removes a zone. Bookings already inside it are unaffected.
+func (s *ServiceAreaService) DeleteZone(ctx context.Context, zoneID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, zoneID); err != nil {
+		return err
+	}
+	s.logger.Info("service zone deleted", zap.String("zone_id", zoneID.String()))
+	return nil
+}
+
+// tripZones checks that the pickup, every stop and the dropoff lie inside an active
+// zone and returns the fares of the pickup and dropoff zones. With no active zones
+// configured there is no coverage limit and both fares are nil.
+func (s *ServiceAreaService) tripZones(
+	ctx context.Context,
+	pickup dto.AddressDTO,
+	stops []bookingDomain.Stop,
+	dropoff dto.AddressDTO,
+) (*bookingDomain.ZoneFare, *bookingDomain.ZoneFare, error) {
+	zones, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(zones) == 0 {
+		return nil, nil, nil
+	}
+
+	pickupZone := zoneDomain.Locate(zones, pickup.Latitude, pickup.Longitude)
+	if pickupZone == nil {
+		return nil, nil, outsideServiceArea("pickup address", pickup)
+	}
+	for i, st := range stops {
+		if zoneDomain.Locate(zones, st.Address.Latitude, st.Address.Longitude) == nil {
+			return nil, nil, outsideServiceArea(fmt.Sprintf("stop %d", i+1), st.Address)
+		}
+	}
+	dropoffZone := zoneDomain.Locate(zones, dropoff.Latitude, dropoff.Longitude)
+	if dropoffZone == nil {
+		return nil, nil, outsideServiceArea("dropoff address", dropoff)
+	}
+
+	return toZoneFare(pickupZone), toZoneFare(dropoffZone), nil
+}
+
+func (s *ServiceAreaService) ensureUniqueName(ctx context.Context, name string, zoneID uuid.UUID) error {
+	exists, err := s.repo.ExistsByName(ctx, name, zoneID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.NewConflictError(fmt.Sprintf("a service zone named %q already exists", name))
+	}
+	return nil
+}
+
+func outsideServiceArea(what string, addr dto.AddressDTO) error {
+	return domain.NewValidationError(fmt.Sprintf(
+		"%s (%.6f, %.6f) is outside the service area", what, addr.Latitude, addr.Longitude,
+	))
+}
+
+func toZoneFare(z *zoneDomain.Zone) *bookingDomain.ZoneFare {
+	return &bookingDomain.ZoneFare{
+		ZoneID:                  z.ID(),
+		BaseFareCents:           z.BaseFareCents(),
+		CrossZoneSurchargeCents: z.CrossZoneSurchargeCents(),
+	}
+}
+
+func toZoneDTO(z *zoneDomain.Zone) ZoneDTO {
+	return ZoneDTO{
+		ID:                      z.ID(),
+		Name:                    z.Name(),
+		Geometry:                z.Geometry(),
+		BaseFareCents:           z.BaseFareCents(),
+		CrossZoneSurchargeCents: z.CrossZoneSurchargeCents(),
+		Active:                  z.Active(),
+		Version:                 z.Version(),
+		CreatedAt:               z.CreatedAt(),
+		UpdatedAt:               z.UpdatedAt(),
+	}
+}
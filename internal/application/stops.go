@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/google/uuid"
+)
+
+// StopRequest is an intermediate stop between pickup and dropoff.
+type StopRequest struct {
+	Address dto.AddressDTO `json:"address" binding:"required"`
+	// Purpose is one of vet, grooming, daycare, boarding, pet_store or other.
+	Purpose string `json:"purpose" binding:"required"`
+	// WaitMin is how long the runner waits at the stop before moving on.
+	WaitMin int `json:"wait_min"`
+}
+
+// ConfirmStop records that the assigned runner has reached the stop with the given
+// sequence number. Stops are confirmed in order; delivery can only be confirmed once
+// every stop has been.
+func (s *BookingService) ConfirmStop(ctx context.Context, bookingID, runnerID uuid.UUID, sequence int) (*BookingDTO, error) {
+	bk, err := s.repo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorization: the caller must be the runner assigned to this booking.
+	if bk.RunnerID() == nil || *bk.RunnerID() != runnerID {
+		return nil, domain.NewForbiddenError("not your booking")
+	}
+
+	now := time.Now().UTC()
+	if err := bk.ConfirmStop(sequence, now); err != nil {
+		return nil, err
+	}
+
+	bk.IncrementVersion()
+	if err := s.repo.Update(ctx, bk); err != nil {
+		return nil, err
+	}
+
+	stop := bk.Stops()[sequence-1]
+	remaining := 0
+	for _, st := range bk.Stops() {
+		if st.ConfirmedAt == nil {
+			remaining++
+		}
+	}
+	evt := BookingStopConfirmedEvent{
+		BookingID:      bk.ID(),
+		BookingNumber:  bk.BookingNumber(),
+		OwnerID:        bk.OwnerID(),
+		RunnerID:       runnerID,
+		Sequence:       stop.Sequence,
+		Purpose:        string(stop.Purpose),
+		RemainingStops: remaining,
+		ConfirmedAt:    *stop.ConfirmedAt,
+		OccurredAt:     now,
+	}
+	s.publishEvent(ctx, events.TopicBookingEvents, BookingStopConfirmed, bk.ID().String(), evt)
+
+	result := toBookingDTO(bk)
+	s.notifyUpdate(ctx, realtime.UpdateStopConfirmed, bk, result)
+	return &result, nil
+}
+
+func toDomainStops(reqs []StopRequest) []bookingDomain.Stop {
+	stops := make([]bookingDomain.Stop, len(reqs))
+	for i, r := range reqs {
+		stops[i] = bookingDomain.Stop{
+			Address: r.Address,
+			Purpose: bookingDomain.StopPurpose(r.Purpose),
+			WaitMin: r.WaitMin,
+		}
+	}
+	return stops
+}
+
+func toStopRequests(stops []bookingDomain.Stop) []StopRequest {
+	reqs := make([]StopRequest, len(stops))
+	for i, st := range stops {
+		reqs[i] = StopRequest{
+			Address: st.Address,
+			Purpose: string(st.Purpose),
+			WaitMin: st.WaitMin,
+		}
+	}
+	return reqs
+}
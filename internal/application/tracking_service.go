@@ -0,0 +1,274 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-common/kafka"
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	trackingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/tracking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// locationSubscriberBuffer is the per-subscriber channel size; slow readers drop pings.
+const locationSubscriberBuffer = 8
+
+// RecordLocationRequest holds a single GPS ping from the runner app.
+type RecordLocationRequest struct {
+	Latitude   *float64  `json:"latitude" binding:"required"`
+	Longitude  *float64  `json:"longitude" binding:"required"`
+	AccuracyM  float64   `json:"accuracy_m"`
+	HeadingDeg float64   `json:"heading_deg"`
+	SpeedKmh   float64   `json:"speed_kmh"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// LocationDTO is the API response representation of a runner location ping.
+type LocationDTO struct {
+	BookingID  uuid.UUID `json:"booking_id"`
+	RunnerID   uuid.UUID `json:"runner_id"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	AccuracyM  float64   `json:"accuracy_m"`
+	HeadingDeg float64   `json:"heading_deg"`
+	SpeedKmh   float64   `json:"speed_kmh"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// TrackingService handles live runner location use cases. Pings are persisted and
+// purged after the retention window, and fanned out to live subscribers on every
+// replica through the real-time hub. Each ping also recomputes the booking's
+// remaining distance and ETA.
+type TrackingService struct {
+	bookingRepo    bookingDomain.BookingRepository
+	repo           trackingDomain.LocationRepository
+	producer       *kafka.Producer
+	updates        *realtime.Hub
+	retention      time.Duration
+	delayThreshold time.Duration
+	logger         *zap.Logger
+}
+
+// NewTrackingService creates a new TrackingService. A nil updates hub disables live
+// location streaming.
+func NewTrackingService(
+	bookingRepo bookingDomain.BookingRepository,
+	repo trackingDomain.LocationRepository,
+	producer *kafka.Producer,
+	updates *realtime.Hub,
+	retention time.Duration,
+	delayThreshold time.Duration,
+	logger *zap.Logger,
+) *TrackingService {
+	return &TrackingService{
+		bookingRepo:    bookingRepo,
+		repo:           repo,
+		producer:       producer,
+		updates:        updates,
+		retention:      retention,
+		delayThreshold: delayThreshold,
+		logger:         logger,
+	}
+}
+
+// RecordLocation stores a ping from the runner assigned to an in-progress booking.
+func (s *TrackingService) RecordLocation(ctx context.Context, bookingID, runnerID uuid.UUID, req RecordLocationRequest) (*LocationDTO, error) {
+	bk, err := s.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if bk.RunnerID() == nil || *bk.RunnerID() != runnerID {
+		return nil, domain.NewForbiddenError("not your booking")
+	}
+	if bk.Status() != bookingDomain.StatusInProgress {
+		return nil, domain.NewConflictError(
+			fmt.Sprintf("cannot track booking in state '%s'", bk.Status()),
+		)
+	}
+
+	ping, err := trackingDomain.NewLocationPing(
+		bookingID, runnerID,
+		*req.Latitude, *req.Longitude,
+		req.AccuracyM, req.HeadingDeg, req.SpeedKmh,
+		req.RecordedAt,
+	)
+	if err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	if err := s.repo.Save(ctx, ping); err != nil {
+		return nil, err
+	}
+
+	result := toLocationDTO(ping)
+	publishUpdate(ctx, s.updates, s.logger, realtime.UpdateLocation, bk.ID(), bk.OwnerID(), bk.RunnerID(), string(bk.Status()), result)
+	s.recalculateETA(ctx, bk, ping)
+	return &result, nil
+}
+
+// GetLatestLocation returns the runner's latest position for the booking owner or runner.
+func (s *TrackingService) GetLatestLocation(ctx context.Context, bookingID, userID uuid.UUID) (*LocationDTO, error) {
+	if _, err := s.authorizeViewer(ctx, bookingID, userID); err != nil {
+		return nil, err
+	}
+
+	// Read from the table rather than a per-replica cache: the ping may have been
+	// received by any replica.
+	ping, err := s.repo.FindLatest(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toLocationDTO(ping)
+	return &result, nil
+}
+
+// GetLocationHistory returns up to limit recent pings for the booking, newest first.
+func (s *TrackingService) GetLocationHistory(ctx context.Context, bookingID, userID uuid.UUID, limit int) ([]LocationDTO, error) {
+	if _, err := s.authorizeViewer(ctx, bookingID, userID); err != nil {
+		return nil, err
+	}
+
+	pings, err := s.repo.FindRecent(ctx, bookingID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]LocationDTO, len(pings))
+	for i, p := range pings {
+		dtos[i] = toLocationDTO(p)
+	}
+	return dtos, nil
+}
+
+// SubscribeLocation registers a live location listener for the booking. The latest
+// stored ping, if any, is sent first. The returned cancel func must be called when the
+// subscriber goes away.
+func (s *TrackingService) SubscribeLocation(ctx context.Context, bookingID, userID uuid.UUID) (<-chan LocationDTO, func(), error) {
+	if s.updates == nil {
+		return nil, nil, fmt.Errorf("real-time updates are not enabled")
+	}
+	if _, err := s.authorizeViewer(ctx, bookingID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	updates, cancel := s.updates.Subscribe(realtime.Subscription{UserID: userID, BookingID: &bookingID, Locations: true})
+	ch := make(chan LocationDTO, locationSubscriberBuffer)
+	if ping, err := s.repo.FindLatest(ctx, bookingID); err == nil {
+		ch <- toLocationDTO(ping)
+	}
+
+	// The hub closes updates on cancel, which ends the relay and closes ch.
+	go func() {
+		defer close(ch)
+		for u := range updates {
+			var loc LocationDTO
+			if err := json.Unmarshal(u.Payload, &loc); err != nil {
+				s.logger.Warn("dropping malformed location update", zap.String("booking_id", u.BookingID.String()), zap.Error(err))
+				continue
+			}
+			select {
+			case ch <- loc:
+			default:
+			}
+		}
+	}()
+	return ch, cancel, nil
+}
+
+// PurgeExpired deletes location history older than the retention window.
+func (s *TrackingService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteRecordedBefore(ctx, time.Now().UTC().Add(-s.retention))
+}
+
+// RunRetention periodically purges expired location history until the context is cancelled.
+func (s *TrackingService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.PurgeExpired(ctx)
+			if err != nil {
+				s.logger.Error("failed to purge location history", zap.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Info("purged expired location history", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
+
+// recalculateETA updates the booking's remaining distance and ETA from the ping and
+// emits BookingDelayedEvent when the ETA slips past the threshold. Failures are logged
+// only: the ping itself has already been stored.
+func (s *TrackingService) recalculateETA(ctx context.Context, bk *bookingDomain.Booking, ping *trackingDomain.LocationPing) {
+	// Ignore replayed pings older than the progress we already have.
+	if p := bk.Progress(); p != nil && p.UpdatedAt.After(ping.RecordedAt()) {
+		return
+	}
+
+	delayed, err := bk.UpdateProgress(ping.Latitude(), ping.Longitude(), ping.SpeedKmh(), ping.RecordedAt(), s.delayThreshold)
+	if err != nil {
+		s.logger.Warn("failed to recalculate ETA", zap.String("booking_id", bk.ID().String()), zap.Error(err))
+		return
+	}
+	if err := s.bookingRepo.UpdateProgress(ctx, bk); err != nil {
+		s.logger.Error("failed to persist delivery progress", zap.String("booking_id", bk.ID().String()), zap.Error(err))
+		return
+	}
+
+	if delayed {
+		progress := bk.Progress()
+		evt := BookingDelayedEvent{
+			BookingID:           bk.ID(),
+			BookingNumber:       bk.BookingNumber(),
+			OwnerID:             bk.OwnerID(),
+			RunnerID:            ping.RunnerID(),
+			OriginalArrivalAt:   progress.OriginalArrivalAt,
+			EstimatedArrivalAt:  progress.EstimatedArrivalAt,
+			DelayMin:            progress.DelayMin,
+			RemainingDistanceKm: progress.RemainingDistanceKm,
+			OccurredAt:          time.Now().UTC(),
+		}
+		publishCloudEvent(ctx, s.producer, s.logger, events.TopicBookingEvents, BookingDelayed, evt)
+	}
+}
+
+// authorizeViewer allows only the booking owner and the assigned runner to see its location.
+func (s *TrackingService) authorizeViewer(ctx context.Context, bookingID, userID uuid.UUID) (*bookingDomain.Booking, error) {
+	bk, err := s.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if bk.OwnerID() == userID {
+		return bk, nil
+	}
+	if bk.RunnerID() != nil && *bk.RunnerID() == userID {
+		return bk, nil
+	}
+	return nil, domain.NewForbiddenError("not your booking")
+}
+
+func toLocationDTO(l *trackingDomain.LocationPing) LocationDTO {
+	return LocationDTO{
+		BookingID:  l.BookingID(),
+		RunnerID:   l.RunnerID(),
+		Latitude:   l.Latitude(),
+		Longitude:  l.Longitude(),
+		AccuracyM:  l.AccuracyM(),
+		HeadingDeg: l.HeadingDeg(),
+		SpeedKmh:   l.SpeedKmh(),
+		RecordedAt: l.RecordedAt(),
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy bounds how often a booking transition is re-applied after it loses an
+// optimistic-lock race to another writer.
+type RetryPolicy struct {
+	// Attempts is the total number of tries, including the first.
+	Attempts int
+	// BaseDelay is the longest wait before the second try. It doubles for each later
+	// try, up to MaxDelay; the actual wait is a random fraction of it.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// NoRetry applies a transition once. User-facing calls use it, so a concurrent change
+// surfaces as 409 and the client decides what to do.
+var NoRetry = RetryPolicy{Attempts: 1}
+
+// BackgroundRetry is used by transitions triggered from events and background jobs,
+// where nobody is there to retry by hand.
+var BackgroundRetry = RetryPolicy{Attempts: 5, BaseDelay: 25 * time.Millisecond, MaxDelay: 400 * time.Millisecond}
+
+// backoff returns the jittered wait after the given failed try (counting from 1).
+func (p RetryPolicy) backoff(try int) time.Duration {
+	limit := p.BaseDelay << (try - 1)
+	if limit <= 0 || limit > p.MaxDelay {
+		limit = p.MaxDelay
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit))) + 1
+}
+
+// updateBooking loads a booking and runs apply on it, which must run the domain command
+// and persist the result. If apply fails and the booking's version has moved on since it
+// was loaded, another writer won the race: the booking is reloaded and apply runs again
+// on the fresh state, up to policy.Attempts times. Any other error, including the domain
+// command refusing the fresh state, is returned as is. apply may run more than once, so
+// events and notifications belong after updateBooking returns.
+func (s *BookingService) updateBooking(ctx context.Context, bookingID uuid.UUID, policy RetryPolicy, apply func(bk *bookingDomain.Booking) error) (*bookingDomain.Booking, error) {
+	for try := 1; ; try++ {
+		bk, err := s.repo.FindByID(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		loaded := bk.Version()
+		err = apply(bk)
+		if err == nil {
+			return bk, nil
+		}
+		if try >= policy.Attempts || !s.lostVersionRace(ctx, bookingID, loaded) {
+			return nil, err
+		}
+
+		s.logger.Info("booking changed concurrently, retrying transition",
+			zap.String("booking_id", bookingID.String()),
+			zap.Int("attempt", try),
+		)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(policy.backoff(try)):
+		}
+	}
+}
+
+// lostVersionRace reports whether the booking has moved past the loaded version, i.e. a
+// failed write lost to a concurrent one rather than being refused.
+func (s *BookingService) lostVersionRace(ctx context.Context, bookingID uuid.UUID, loaded int64) bool {
+	current, err := s.repo.FindByID(ctx, bookingID)
+	return err == nil && current.Version() != loaded
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"os"
+	"time"
+
 	"github.com/Kilat-Pet-Delivery/lib-common/config"
 )
 
@@ -11,6 +14,79 @@ type ServiceConfig struct {
 	DBConfig    config.DatabaseConfig
 	JWTConfig   config.JWTConfig
 	KafkaConfig config.KafkaConfig
+
+	// LocationRetention is how long runner GPS history is kept.
+	LocationRetention time.Duration
+	// IdempotencyKeyTTL is how long responses to requests with an Idempotency-Key are
+	// kept for replay.
+	IdempotencyKeyTTL time.Duration
+	// ETADelayThreshold is how far the live ETA may slip before BookingDelayed is emitted.
+	ETADelayThreshold time.Duration
+	// RealtimeBroadcaster selects how booking updates reach other replicas:
+	// "postgres" (LISTEN/NOTIFY), "kafka", or "local" (single replica).
+	RealtimeBroadcaster string
+	// InstanceID names this replica. The Kafka broadcaster derives its consumer group
+	// from it, so it should survive restarts (e.g. the pod name); defaults to the hostname.
+	InstanceID string
+	// AnalyticsRollupEnabled serves admin analytics from the daily rollup table.
+	AnalyticsRollupEnabled bool
+	// AnalyticsRollupRefresh is how often the daily rollups are recomputed.
+	AnalyticsRollupRefresh time.Duration
+	// ReliabilityPublishInterval is how often runner reliability scores are published;
+	// zero (the default) disables publishing. Enable it on a single replica only, or
+	// every replica publishes the same scores.
+	ReliabilityPublishInterval time.Duration
+	// ReliabilityWindow is the trailing window runner reliability is scored over.
+	ReliabilityWindow time.Duration
+	// SeriesGenerationHorizon is how far ahead recurring series bookings are materialised.
+	SeriesGenerationHorizon time.Duration
+	// SeriesGenerationInterval is how often the series generator runs; zero disables it.
+	SeriesGenerationInterval time.Duration
+	// Geocoder selects how booking addresses are verified: "nominatim", "gazetteer"
+	// (offline file) or "none" (addresses are trusted as given).
+	Geocoder string
+	// GazetteerPath is the JSON address list used by the gazetteer geocoder.
+	GazetteerPath string
+	// NominatimURL is the base URL of the Nominatim-compatible search API. There is no
+	// default: the public server's usage policy does not allow production traffic.
+	NominatimURL string
+	// NominatimUserAgent identifies the service to the Nominatim server.
+	NominatimUserAgent string
+	// GeocoderTimeout bounds each geocoding request.
+	GeocoderTimeout time.Duration
+	// GeocoderCacheSize is how many Nominatim results are kept in memory; 0 disables
+	// the cache.
+	GeocoderCacheSize int
+	// GeocoderCacheTTL is how long a cached Nominatim result is reused.
+	GeocoderCacheTTL time.Duration
+	// GeocodeMaxMismatchM is how far, in metres, client coordinates may lie from the
+	// geocoded position of their address.
+	GeocodeMaxMismatchM float64
+	// EligibilityPolicyPath is a JSON pet eligibility policy replacing the built-in
+	// one; empty uses the built-in policy.
+	EligibilityPolicyPath string
+	// RunnerCapabilitiesRequired stops runners without a capability profile from
+	// accepting bookings; otherwise they are not checked.
+	RunnerCapabilitiesRequired bool
+	// MaxActiveBookingsPerRunner caps how many accepted and in-progress bookings a
+	// runner may hold at once, including ones scheduled far ahead. 0 (the default)
+	// disables the cap.
+	MaxActiveBookingsPerRunner int
+	// RunnerPayoutPercent is the share of a booking's price quoted to runners as their
+	// payout on the job board and in dispatch offers.
+	RunnerPayoutPercent int
+	// DispatchEnabled turns on the automatic dispatcher, which offers requested
+	// bookings to nearby runners one at a time.
+	DispatchEnabled bool
+	// DispatchInterval is how often the dispatcher expires offers and makes new ones.
+	DispatchInterval time.Duration
+	// DispatchOfferTimeout is how long a runner has to answer an offer.
+	DispatchOfferTimeout time.Duration
+	// DispatchRadiusKm is how far from the pickup runners are offered bookings.
+	DispatchRadiusKm float64
+	// DispatchMaxRounds is how many runners a booking is offered to before it is left
+	// on the job board.
+	DispatchMaxRounds int
 }
 
 // Load reads configuration from environment variables.
@@ -20,11 +96,77 @@ func Load() (*ServiceConfig, error) {
 		return nil, err
 	}
 
+	v.SetDefault("LOCATION_RETENTION_HOURS", 72)
+	v.SetDefault("IDEMPOTENCY_KEY_TTL_HOURS", 24)
+	v.SetDefault("ETA_DELAY_THRESHOLD_MIN", 10)
+	v.SetDefault("REALTIME_BROADCASTER", "postgres")
+	hostname, _ := os.Hostname()
+	v.SetDefault("INSTANCE_ID", hostname)
+	v.SetDefault("ANALYTICS_ROLLUP_ENABLED", false)
+	v.SetDefault("ANALYTICS_ROLLUP_REFRESH_MIN", 15)
+	v.SetDefault("RELIABILITY_PUBLISH_INTERVAL_MIN", 0)
+	v.SetDefault("RELIABILITY_WINDOW_DAYS", 30)
+	v.SetDefault("SERIES_GENERATION_HORIZON_DAYS", 14)
+	v.SetDefault("SERIES_GENERATION_INTERVAL_MIN", 60)
+	v.SetDefault("GEOCODER", "none")
+	v.SetDefault("GEOCODER_GAZETTEER_PATH", "gazetteer.json")
+	v.SetDefault("NOMINATIM_URL", "")
+	v.SetDefault("NOMINATIM_USER_AGENT", "kilat-service-booking")
+	v.SetDefault("GEOCODER_TIMEOUT_SEC", 5)
+	v.SetDefault("GEOCODER_CACHE_SIZE", 10000)
+	v.SetDefault("GEOCODER_CACHE_TTL_HOURS", 24)
+	v.SetDefault("GEOCODE_MAX_MISMATCH_M", 500)
+	v.SetDefault("ELIGIBILITY_POLICY_PATH", "")
+	v.SetDefault("RUNNER_CAPABILITIES_REQUIRED", false)
+	v.SetDefault("MAX_ACTIVE_BOOKINGS_PER_RUNNER", 0)
+	v.SetDefault("RUNNER_PAYOUT_PERCENT", 85)
+	v.SetDefault("DISPATCH_ENABLED", false)
+	v.SetDefault("DISPATCH_INTERVAL_SEC", 5)
+	v.SetDefault("DISPATCH_OFFER_TIMEOUT_SEC", 45)
+	v.SetDefault("DISPATCH_RADIUS_KM", 10)
+	v.SetDefault("DISPATCH_MAX_ROUNDS", 5)
+
 	return &ServiceConfig{
 		Port:        config.GetServicePort(v, "SERVICE_PORT"),
 		AppEnv:      config.GetAppEnv(v),
 		DBConfig:    config.LoadDatabaseConfig(v, "DB_NAME"),
 		JWTConfig:   config.LoadJWTConfig(v),
 		KafkaConfig: config.LoadKafkaConfig(v),
+
+		LocationRetention: time.Duration(v.GetInt("LOCATION_RETENTION_HOURS")) * time.Hour,
+		IdempotencyKeyTTL: time.Duration(v.GetInt("IDEMPOTENCY_KEY_TTL_HOURS")) * time.Hour,
+		ETADelayThreshold: time.Duration(v.GetInt("ETA_DELAY_THRESHOLD_MIN")) * time.Minute,
+
+		RealtimeBroadcaster: v.GetString("REALTIME_BROADCASTER"),
+		InstanceID:          v.GetString("INSTANCE_ID"),
+
+		AnalyticsRollupEnabled: v.GetBool("ANALYTICS_ROLLUP_ENABLED"),
+		AnalyticsRollupRefresh: time.Duration(v.GetInt("ANALYTICS_ROLLUP_REFRESH_MIN")) * time.Minute,
+
+		ReliabilityPublishInterval: time.Duration(v.GetInt("RELIABILITY_PUBLISH_INTERVAL_MIN")) * time.Minute,
+		ReliabilityWindow:          time.Duration(v.GetInt("RELIABILITY_WINDOW_DAYS")) * 24 * time.Hour,
+
+		SeriesGenerationHorizon:  time.Duration(v.GetInt("SERIES_GENERATION_HORIZON_DAYS")) * 24 * time.Hour,
+		SeriesGenerationInterval: time.Duration(v.GetInt("SERIES_GENERATION_INTERVAL_MIN")) * time.Minute,
+
+		Geocoder:            v.GetString("GEOCODER"),
+		GazetteerPath:       v.GetString("GEOCODER_GAZETTEER_PATH"),
+		NominatimURL:        v.GetString("NOMINATIM_URL"),
+		NominatimUserAgent:  v.GetString("NOMINATIM_USER_AGENT"),
+		GeocoderTimeout:     time.Duration(v.GetInt("GEOCODER_TIMEOUT_SEC")) * time.Second,
+		GeocoderCacheSize:   v.GetInt("GEOCODER_CACHE_SIZE"),
+		GeocoderCacheTTL:    time.Duration(v.GetInt("GEOCODER_CACHE_TTL_HOURS")) * time.Hour,
+		GeocodeMaxMismatchM: v.GetFloat64("GEOCODE_MAX_MISMATCH_M"),
+
+		EligibilityPolicyPath:      v.GetString("ELIGIBILITY_POLICY_PATH"),
+		RunnerCapabilitiesRequired: v.GetBool("RUNNER_CAPABILITIES_REQUIRED"),
+		MaxActiveBookingsPerRunner: v.GetInt("MAX_ACTIVE_BOOKINGS_PER_RUNNER"),
+		RunnerPayoutPercent:        v.GetInt("RUNNER_PAYOUT_PERCENT"),
+
+		DispatchEnabled:      v.GetBool("DISPATCH_ENABLED"),
+		DispatchInterval:     time.Duration(v.GetInt("DISPATCH_INTERVAL_SEC")) * time.Second,
+		DispatchOfferTimeout: time.Duration(v.GetInt("DISPATCH_OFFER_TIMEOUT_SEC")) * time.Second,
+		DispatchRadiusKm:     v.GetFloat64("DISPATCH_RADIUS_KM"),
+		DispatchMaxRounds:    v.GetInt("DISPATCH_MAX_ROUNDS"),
 	}, nil
 }
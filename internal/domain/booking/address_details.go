@@ -0,0 +1,31 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddressDetails is the part of an owner's saved address that a booking keeps
+// besides the address itself: what the runner needs at the door. It is a snapshot,
+// so later edits to the saved address do not change existing bookings.
+type AddressDetails struct {
+	SavedAddressID uuid.UUID `json:"saved_address_id"`
+	Label          string    `json:"label"`
+	AccessNotes    string    `json:"access_notes,omitempty"`
+	ContactPhone   string    `json:"contact_phone,omitempty"`
+}
+
+// PickupDetails returns the saved-address details of the pickup, or nil when the
+// pickup address was entered directly.
+func (b *Booking) PickupDetails() *AddressDetails { return b.pickupDetails }
+
+// DropoffDetails returns the saved-address details of the dropoff, or nil.
+func (b *Booking) DropoffDetails() *AddressDetails { return b.dropoffDetails }
+
+// SetAddressDetails records the saved addresses a new booking was created from.
+func (b *Booking) SetAddressDetails(pickup, dropoff *AddressDetails) {
+	b.pickupDetails = pickup
+	b.dropoffDetails = dropoff
+	b.updatedAt = time.Now().UTC()
+}
@@ -0,0 +1,132 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/google/uuid"
+)
+
+// AdminAction identifies an admin override applied to a stuck booking.
+type AdminAction string
+
+const (
+	AdminActionForceCancel      AdminAction = "force_cancel"
+	AdminActionReassign         AdminAction = "reassign"
+	AdminActionRevertToAccepted AdminAction = "revert_to_accepted"
+	AdminActionForceComplete    AdminAction = "force_complete"
+)
+
+// adminTransition describes which states an admin action may be applied from and
+// the state it leaves the booking in.
+type adminTransition struct {
+	from []BookingStatus
+	to   BookingStatus
+}
+
+// adminTransitions is the override table consulted only by admin actions. It is kept
+// separate from validTransitions so the normal booking flows can never take these paths.
+var adminTransitions = map[AdminAction]adminTransition{
+	AdminActionForceCancel: {
+		from: []BookingStatus{StatusRequested, StatusAccepted, StatusInProgress, StatusDelivered},
+		to:   StatusCancelled,
+	},
+	AdminActionReassign: {
+		from: []BookingStatus{StatusRequested, StatusAccepted},
+		to:   StatusAccepted,
+	},
+	AdminActionRevertToAccepted: {
+		from: []BookingStatus{StatusInProgress},
+		to:   StatusAccepted,
+	},
+	AdminActionForceComplete: {
+		from: []BookingStatus{StatusInProgress, StatusDelivered},
+		to:   StatusCompleted,
+	},
+}
+
+// IsValid returns true if the action is a recognized admin action.
+func (a AdminAction) IsValid() bool {
+	_, exists := adminTransitions[a]
+	return exists
+}
+
+// String returns the string representation of the action.
+func (a AdminAction) String() string {
+	return string(a)
+}
+
+// checkAdminTransition verifies the action may be applied from the booking's current state.
+func (b *Booking) checkAdminTransition(action AdminAction) error {
+	t, exists := adminTransitions[action]
+	if !exists {
+		return domain.NewValidationError("unknown admin action: " + string(action))
+	}
+	for _, from := range t.from {
+		if b.status == from {
+			return nil
+		}
+	}
+	return domain.NewInvalidStateError(string(b.status), string(t.to))
+}
+
+// AdminForceCancel cancels the booking from any non-terminal state.
+func (b *Booking) AdminForceCancel(reason string) error {
+	if err := b.checkAdminTransition(AdminActionForceCancel); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	b.status = StatusCancelled
+	b.cancelNote = reason
+	b.cancelledAt = &now
+	b.updatedAt = now
+	return nil
+}
+
+// AdminReassign assigns the booking to the given runner, replacing any current runner.
+func (b *Booking) AdminReassign(runnerID uuid.UUID) error {
+	if err := b.checkAdminTransition(AdminActionReassign); err != nil {
+		return err
+	}
+	if runnerID == uuid.Nil {
+		return domain.NewValidationError("runner ID is required")
+	}
+	if b.runnerID != nil && *b.runnerID == runnerID {
+		return domain.NewValidationError("booking is already assigned to this runner")
+	}
+	b.runnerID = &runnerID
+	b.status = StatusAccepted
+	b.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// AdminRevertToAccepted moves an in-progress booking back to accepted, clearing the
+// pickup time and live delivery progress.
+func (b *Booking) AdminRevertToAccepted() error {
+	if err := b.checkAdminTransition(AdminActionRevertToAccepted); err != nil {
+		return err
+	}
+	b.status = StatusAccepted
+	b.pickedUpAt = nil
+	b.progress = nil
+	b.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// AdminForceComplete completes the booking with an admin-adjusted final price.
+func (b *Booking) AdminForceComplete(finalPriceCents int64) error {
+	if err := b.checkAdminTransition(AdminActionForceComplete); err != nil {
+		return err
+	}
+	if finalPriceCents < 0 {
+		return domain.NewValidationError("final price must not be negative")
+	}
+	now := time.Now().UTC()
+	if b.deliveredAt == nil {
+		b.deliveredAt = &now
+	}
+	b.status = StatusCompleted
+	b.finalPriceCents = &finalPriceCents
+	b.updatedAt = now
+	return nil
+}
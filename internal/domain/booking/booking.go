@@ -25,6 +25,7 @@ type Booking struct {
 	pickupAddress  dto.AddressDTO
 	dropoffAddress dto.AddressDTO
 	routeSpec      *RouteSpecification
+	progress       *DeliveryProgress
 
 	estimatedPriceCents int64
 	finalPriceCents     *int64
@@ -37,6 +38,19 @@ type Booking struct {
 	cancelNote  string
 	notes       string
 
+	// seriesID links a booking generated from a recurring series back to it.
+	seriesID *uuid.UUID
+	// parentID is shared by both legs of a round trip; leg says which one this is.
+	parentID *uuid.UUID
+	leg      TripLeg
+	// stops are visited in order between pickup and dropoff.
+	stops []Stop
+	// pickupDetails and dropoffDetails snapshot the saved addresses used, if any.
+	pickupDetails  *AddressDetails
+	dropoffDetails *AddressDetails
+	// eligibility holds the result of the pet eligibility check at booking time.
+	eligibility *EligibilityReport
+
 	version   int64
 	createdAt time.Time
 	updatedAt time.Time
@@ -126,6 +140,7 @@ func ReconstructBooking(
 	pickupAddress dto.AddressDTO,
 	dropoffAddress dto.AddressDTO,
 	routeSpec *RouteSpecification,
+	progress *DeliveryProgress,
 	estimatedPriceCents int64,
 	finalPriceCents *int64,
 	currency string,
@@ -135,6 +150,13 @@ func ReconstructBooking(
 	cancelledAt *time.Time,
 	cancelNote string,
 	notes string,
+	seriesID *uuid.UUID,
+	parentID *uuid.UUID,
+	leg TripLeg,
+	stops []Stop,
+	pickupDetails *AddressDetails,
+	dropoffDetails *AddressDetails,
+	eligibility *EligibilityReport,
 	version int64,
 	createdAt time.Time,
 	updatedAt time.Time,
@@ -150,6 +172,7 @@ func ReconstructBooking(
 		pickupAddress:       pickupAddress,
 		dropoffAddress:      dropoffAddress,
 		routeSpec:           routeSpec,
+		progress:            progress,
 		estimatedPriceCents: estimatedPriceCents,
 		finalPriceCents:     finalPriceCents,
 		currency:            currency,
@@ -159,6 +182,13 @@ func ReconstructBooking(
 		cancelledAt:         cancelledAt,
 		cancelNote:          cancelNote,
 		notes:               notes,
+		seriesID:            seriesID,
+		parentID:            parentID,
+		leg:                 leg,
+		stops:               stops,
+		pickupDetails:       pickupDetails,
+		dropoffDetails:      dropoffDetails,
+		eligibility:         eligibility,
 		version:             version,
 		createdAt:           createdAt,
 		updatedAt:           updatedAt,
@@ -197,6 +227,9 @@ func (b *Booking) DropoffAddress() dto.AddressDTO { return b.dropoffAddress }
 // RouteSpec returns the route specification, or nil if not yet calculated.
 func (b *Booking) RouteSpec() *RouteSpecification { return b.routeSpec }
 
+// Progress returns the live delivery progress, or nil if no location has been reported.
+func (b *Booking) Progress() *DeliveryProgress { return b.progress }
+
 // EstimatedPriceCents returns the estimated price in cents.
 func (b *Booking) EstimatedPriceCents() int64 { return b.estimatedPriceCents }
 
@@ -224,6 +257,15 @@ func (b *Booking) CancelNote() string { return b.cancelNote }
 // Notes returns any additional notes for the booking.
 func (b *Booking) Notes() string { return b.notes }
 
+// SeriesID returns the recurring series this booking was generated from, if any.
+func (b *Booking) SeriesID() *uuid.UUID { return b.seriesID }
+
+// ParentID returns the round-trip ID shared with the other leg, if any.
+func (b *Booking) ParentID() *uuid.UUID { return b.parentID }
+
+// Leg returns which leg of a round trip this booking is, or "" for a one-way trip.
+func (b *Booking) Leg() TripLeg { return b.leg }
+
 // Version returns the entity version for optimistic locking.
 func (b *Booking) Version() int64 { return b.version }
 
@@ -262,10 +304,16 @@ func (b *Booking) StartDelivery() error {
 }
 
 // ConfirmDelivery transitions the booking from in_progress to delivered.
+// Every stop must have been confirmed first.
 func (b *Booking) ConfirmDelivery() error {
 	if !b.status.CanTransitionTo(StatusDelivered) {
 		return domain.NewInvalidStateError(string(b.status), string(StatusDelivered))
 	}
+	if next := b.NextStop(); next != nil {
+		return domain.NewConflictError(
+			fmt.Sprintf("stop %d has not been confirmed", next.Sequence),
+		)
+	}
 	now := time.Now().UTC()
 	b.status = StatusDelivered
 	b.deliveredAt = &now
@@ -312,12 +360,83 @@ func (b *Booking) Decline(reason string) error {
 	return nil
 }
 
+// OriginalArrivalAt returns the arrival time promised at pickup: the pickup time plus
+// the route's estimated duration. Returns nil until the pet has been picked up.
+func (b *Booking) OriginalArrivalAt() *time.Time {
+	if b.pickedUpAt == nil {
+		return nil
+	}
+	arrival := b.pickedUpAt.Add(b.estimatedDuration())
+	return &arrival
+}
+
+// estimatedDuration is the route's estimated trip time, or a straight-line estimate
+// when the booking has no route.
+func (b *Booking) estimatedDuration() time.Duration {
+	durationMin := 0
+	if b.routeSpec != nil && b.routeSpec.EstimatedDurationMin > 0 {
+		durationMin = b.routeSpec.EstimatedDurationMin
+	} else {
+		durationMin = EstimateDurationMin(HaversineDistanceKm(
+			b.pickupAddress.Latitude, b.pickupAddress.Longitude,
+			b.dropoffAddress.Latitude, b.dropoffAddress.Longitude,
+		))
+	}
+	return time.Duration(durationMin) * time.Minute
+}
+
+// UpdateProgress recomputes the remaining distance and ETA from the runner's current
+// position, via any stops not yet confirmed and their wait times. It returns true when
+// the ETA has slipped past the original estimate by at least delayThreshold more than
+// at the last notification, i.e. the owner should be told.
+// Progress is telemetry: it does not bump the version or the updated timestamp.
+func (b *Booking) UpdateProgress(lat, lng, speedKmh float64, at time.Time, delayThreshold time.Duration) (bool, error) {
+	if b.status != StatusInProgress {
+		return false, domain.NewConflictError(
+			fmt.Sprintf("cannot update progress of booking in state '%s'", b.status),
+		)
+	}
+
+	remainingKm, remainingWait := b.remainingRoute(lat, lng)
+	eta := at.Add(estimateRemainingDuration(remainingKm, speedKmh) + remainingWait).UTC()
+
+	progress := DeliveryProgress{
+		RemainingDistanceKm: remainingKm,
+		EstimatedArrivalAt:  eta,
+		UpdatedAt:           at.UTC(),
+	}
+	if b.progress != nil {
+		progress.NotifiedDelayMin = b.progress.NotifiedDelayMin
+	}
+
+	notify := false
+	if original := b.OriginalArrivalAt(); original != nil {
+		progress.OriginalArrivalAt = *original
+		if delay := eta.Sub(*original); delay > 0 {
+			progress.DelayMin = int(delay / time.Minute)
+			thresholdMin := int(delayThreshold / time.Minute)
+			if delay >= delayThreshold && progress.DelayMin >= progress.NotifiedDelayMin+thresholdMin {
+				progress.NotifiedDelayMin = progress.DelayMin
+				notify = true
+			}
+		}
+	}
+
+	b.progress = &progress
+	return notify, nil
+}
+
 // IncrementVersion bumps the version for optimistic locking.
 func (b *Booking) IncrementVersion() {
 	b.version++
 	b.updatedAt = time.Now().UTC()
 }
 
+// AttachToSeries records the recurring series a new booking was generated from.
+func (b *Booking) AttachToSeries(seriesID uuid.UUID) {
+	b.seriesID = &seriesID
+}
+
 // SetRouteSpec sets the route specification for this booking.
 func (b *Booking) SetRouteSpec(routeSpec *RouteSpecification) {
 	b.routeSpec = routeSpec
@@ -0,0 +1,212 @@
+package booking
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// crateRulesJSON is the built-in crate rule set. Bump its version whenever the rules
+// change; each booking records the version its crate requirement came from.
+//
+//go:embed crate_rules.json
+var crateRulesJSON []byte
+
+// crateSizes lists the crate sizes from smallest to largest.
+var crateSizes = []CrateSize{CrateSizeSmall, CrateSizeMedium, CrateSizeLarge, CrateSizeXLarge}
+
+// CrateRule adjusts the crate requirement of pets it matches. Empty match fields match
+// any pet; pet types and breeds are compared ignoring case.
+type CrateRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+
+	PetTypes     []string `json:"pet_types"`
+	Breeds       []string `json:"breeds"`
+	MinWeightKg  float64  `json:"min_weight_kg"`
+	MaxWeightKg  float64  `json:"max_weight_kg"`
+	MinAgeMonths int      `json:"min_age_months"`
+	// MaxAgeMonths only matches pets whose age is known.
+	MaxAgeMonths int `json:"max_age_months"`
+
+	// MinSize raises the crate to at least this size.
+	MinSize CrateSize `json:"min_size"`
+	// SizeUp moves the crate up this many sizes, after MinSize.
+	SizeUp           int      `json:"size_up"`
+	ExtraVentilation bool     `json:"extra_ventilation"`
+	TempControl      bool     `json:"temp_control"`
+	Cover            bool     `json:"cover"`
+	Skills           []string `json:"skills"`
+	// Advice is shown to the runner as a handling warning in the booking's pet
+	// eligibility check.
+	Advice string `json:"advice"`
+}
+
+// CrateRuleSet is a versioned list of crate rules. Every matching rule applies.
+type CrateRuleSet struct {
+	Version int         `json:"version"`
+	Rules   []CrateRule `json:"rules"`
+}
+
+var (
+	defaultCrateRules     *CrateRuleSet
+	defaultCrateRulesOnce sync.Once
+)
+
+// DefaultCrateRules returns the built-in crate rule set.
+func DefaultCrateRules() *CrateRuleSet {
+	defaultCrateRulesOnce.Do(func() {
+		rs, err := ParseCrateRules(crateRulesJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in crate rules: %v", err))
+		}
+		defaultCrateRules = rs
+	})
+	return defaultCrateRules
+}
+
+// ParseCrateRules parses and validates a JSON crate rule set.
+func ParseCrateRules(data []byte) (*CrateRuleSet, error) {
+	var rs CrateRuleSet
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("failed to parse crate rules: %w", err)
+	}
+	if rs.Version <= 0 {
+		return nil, fmt.Errorf("crate rules need a positive version")
+	}
+	seen := make(map[string]bool, len(rs.Rules))
+	for _, r := range rs.Rules {
+		if r.ID == "" {
+			return nil, fmt.Errorf("crate rule without an id")
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("duplicate crate rule %q", r.ID)
+		}
+		seen[r.ID] = true
+		for _, pt := range r.PetTypes {
+			if !PetType(strings.ToLower(pt)).IsValid() {
+				return nil, fmt.Errorf("crate rule %q: invalid pet type %q", r.ID, pt)
+			}
+		}
+		if r.MinSize != "" && !r.MinSize.IsValid() {
+			return nil, fmt.Errorf("crate rule %q: invalid min_size %q", r.ID, r.MinSize)
+		}
+		if r.SizeUp < 0 {
+			return nil, fmt.Errorf("crate rule %q: size_up cannot be negative", r.ID)
+		}
+	}
+	return &rs, nil
+}
+
+// Determine derives the crate requirement for a pet: a size from its weight, adjusted
+// and extended by every matching rule.
+func (rs *CrateRuleSet) Determine(spec PetSpecification) CrateRequirement {
+	req := CrateRequirement{
+		MinimumSize:           crateSizeForWeight(spec.WeightKg),
+		NeedsVentilation:      true,
+		MinimumWeightCapacity: spec.WeightKg * 1.2, // 20% buffer
+		RuleVersion:           rs.Version,
+	}
+
+	skills := make(map[string]bool)
+	for _, r := range rs.Rules {
+		if !r.matches(spec) {
+			continue
+		}
+		if r.MinSize != "" && crateSizeIndex(r.MinSize) > crateSizeIndex(req.MinimumSize) {
+			req.MinimumSize = r.MinSize
+		}
+		if r.SizeUp > 0 {
+			i := crateSizeIndex(req.MinimumSize) + r.SizeUp
+			if i >= len(crateSizes) {
+				i = len(crateSizes) - 1
+			}
+			req.MinimumSize = crateSizes[i]
+		}
+		req.ExtraVentilation = req.ExtraVentilation || r.ExtraVentilation
+		req.NeedsTempControl = req.NeedsTempControl || r.TempControl
+		req.NeedsCover = req.NeedsCover || r.Cover
+		for _, s := range r.Skills {
+			skills[s] = true
+		}
+		req.AppliedRules = append(req.AppliedRules, r.ID)
+	}
+
+	for s := range skills {
+		req.RunnerSkills = append(req.RunnerSkills, s)
+	}
+	sort.Strings(req.RunnerSkills)
+	return req
+}
+
+// Advice returns the handling advice of every rule matching the pet, in rule order.
+func (rs *CrateRuleSet) Advice(spec PetSpecification) []string {
+	var advice []string
+	for _, r := range rs.Rules {
+		if r.Advice != "" && r.matches(spec) {
+			advice = append(advice, r.Advice)
+		}
+	}
+	return advice
+}
+
+func (r CrateRule) matches(spec PetSpecification) bool {
+	if len(r.PetTypes) > 0 && !containsFold(r.PetTypes, spec.PetType) {
+		return false
+	}
+	if len(r.Breeds) > 0 && !containsFold(r.Breeds, strings.TrimSpace(spec.Breed)) {
+		return false
+	}
+	if r.MinWeightKg > 0 && spec.WeightKg < r.MinWeightKg {
+		return false
+	}
+	if r.MaxWeightKg > 0 && spec.WeightKg > r.MaxWeightKg {
+		return false
+	}
+	if r.MinAgeMonths > 0 && spec.Age < r.MinAgeMonths {
+		return false
+	}
+	if r.MaxAgeMonths > 0 && (spec.Age <= 0 || spec.Age > r.MaxAgeMonths) {
+		return false
+	}
+	return true
+}
+
+func crateSizeForWeight(weightKg float64) CrateSize {
+	switch {
+	case weightKg <= 5:
+		return CrateSizeSmall
+	case weightKg <= 15:
+		return CrateSizeMedium
+	case weightKg <= 30:
+		return CrateSizeLarge
+	default:
+		return CrateSizeXLarge
+	}
+}
+
+func crateSizeIndex(size CrateSize) int {
+	for i, s := range crateSizes {
+		if s == size {
+			return i
+		}
+	}
+	return 0
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CrateSizeLess reports whether crate size a is smaller than b.
+func CrateSizeLess(a, b CrateSize) bool {
+	return crateSizeIndex(a) < crateSizeIndex(b)
+}
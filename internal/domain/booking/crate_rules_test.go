@@ -0,0 +1,164 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrateRuleSet_Determine(t *testing.T) {
+	rules := DefaultCrateRules()
+
+	tests := []struct {
+		name string
+		spec PetSpecification
+		want CrateRequirement
+	}{
+		{
+			name: "plain cat keeps its weight band",
+			spec: PetSpecification{PetType: "cat", Name: "Kiki", WeightKg: 4},
+			want: CrateRequirement{MinimumSize: CrateSizeSmall},
+		},
+		{
+			name: "brachycephalic dog needs airflow, cooling and a skilled runner",
+			spec: PetSpecification{PetType: "dog", Breed: "Pug", Name: "Mochi", WeightKg: 8},
+			want: CrateRequirement{
+				MinimumSize:      CrateSizeMedium,
+				ExtraVentilation: true,
+				NeedsTempControl: true,
+				RunnerSkills:     []string{"brachycephalic_care"},
+				AppliedRules:     []string{"brachycephalic-dog"},
+			},
+		},
+		{
+			name: "breeds match ignoring case and surrounding space",
+			spec: PetSpecification{PetType: "cat", Breed: " PERSIAN ", Name: "Snow", WeightKg: 5},
+			want: CrateRequirement{
+				MinimumSize:      CrateSizeSmall,
+				ExtraVentilation: true,
+				NeedsTempControl: true,
+				RunnerSkills:     []string{"brachycephalic_care"},
+				AppliedRules:     []string{"brachycephalic-cat"},
+			},
+		},
+		{
+			name: "large-breed puppy goes one size up",
+			spec: PetSpecification{PetType: "dog", Breed: "German Shepherd", Name: "Rex", WeightKg: 14, Age: 5},
+			want: CrateRequirement{MinimumSize: CrateSizeLarge, AppliedRules: []string{"large-breed-puppy"}},
+		},
+		{
+			name: "large-breed adult keeps its weight band",
+			spec: PetSpecification{PetType: "dog", Breed: "German Shepherd", Name: "Max", WeightKg: 14, Age: 36},
+			want: CrateRequirement{MinimumSize: CrateSizeMedium},
+		},
+		{
+			name: "unknown age does not match an age cap",
+			spec: PetSpecification{PetType: "dog", Breed: "Husky", Name: "Luna", WeightKg: 14},
+			want: CrateRequirement{MinimumSize: CrateSizeMedium},
+		},
+		{
+			name: "giant dog needs the largest crate",
+			spec: PetSpecification{PetType: "dog", Breed: "Mastiff", Name: "Tank", WeightKg: 45},
+			want: CrateRequirement{
+				MinimumSize:  CrateSizeXLarge,
+				RunnerSkills: []string{"large_dog_handling"},
+				AppliedRules: []string{"giant-dog"},
+			},
+		},
+		{
+			name: "size up stops at the largest crate",
+			spec: PetSpecification{PetType: "dog", Breed: "Great Dane", Name: "Duke", WeightKg: 42, Age: 11},
+			want: CrateRequirement{
+				MinimumSize:  CrateSizeXLarge,
+				RunnerSkills: []string{"large_dog_handling"},
+				AppliedRules: []string{"large-breed-puppy", "giant-dog"},
+			},
+		},
+		{
+			name: "bird travels covered",
+			spec: PetSpecification{PetType: "bird", Name: "Kiwi", WeightKg: 0.2},
+			want: CrateRequirement{
+				MinimumSize:  CrateSizeSmall,
+				NeedsCover:   true,
+				RunnerSkills: []string{"bird_handling"},
+				AppliedRules: []string{"bird-covered-carrier"},
+			},
+		},
+		{
+			name: "reptile needs heat",
+			spec: PetSpecification{PetType: "reptile", Name: "Iggy", WeightKg: 2},
+			want: CrateRequirement{
+				MinimumSize:      CrateSizeSmall,
+				NeedsTempControl: true,
+				RunnerSkills:     []string{"reptile_handling"},
+				AppliedRules:     []string{"reptile-heat"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.NeedsVentilation = true
+			tt.want.MinimumWeightCapacity = tt.spec.WeightKg * 1.2
+			tt.want.RuleVersion = rules.Version
+
+			assert.Equal(t, tt.want, rules.Determine(tt.spec))
+		})
+	}
+}
+
+func TestParseCrateRules_RejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		err  string
+	}{
+		{name: "malformed", json: `{`, err: "failed to parse crate rules"},
+		{name: "no version", json: `{"rules": []}`, err: "positive version"},
+		{name: "rule without id", json: `{"version": 1, "rules": [{}]}`, err: "without an id"},
+		{name: "duplicate id", json: `{"version": 1, "rules": [{"id": "a"}, {"id": "a"}]}`, err: `duplicate crate rule "a"`},
+		{name: "unknown pet type", json: `{"version": 1, "rules": [{"id": "a", "pet_types": ["dragon"]}]}`, err: `invalid pet type "dragon"`},
+		{name: "unknown size", json: `{"version": 1, "rules": [{"id": "a", "min_size": "huge"}]}`, err: `invalid min_size "huge"`},
+		{name: "negative size up", json: `{"version": 1, "rules": [{"id": "a", "size_up": -1}]}`, err: "size_up cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCrateRules([]byte(tt.json))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestCrateRuleSet_Advice(t *testing.T) {
+	rules := DefaultCrateRules()
+	brachycephalic := "short-nosed breed: keep the crate cool and well ventilated, watch for laboured breathing"
+
+	tests := []struct {
+		name string
+		spec PetSpecification
+		want []string
+	}{
+		{name: "short-nosed dog", spec: PetSpecification{PetType: "dog", Breed: "Boston Terrier"}, want: []string{brachycephalic}},
+		{name: "short-nosed cat", spec: PetSpecification{PetType: "cat", Breed: "himalayan"}, want: []string{brachycephalic}},
+		{name: "breed of another pet type", spec: PetSpecification{PetType: "cat", Breed: "Pug"}},
+		{name: "rule without advice", spec: PetSpecification{PetType: "reptile"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Advice(tt.spec))
+		})
+	}
+}
+
+// Every crate rule that needs brachycephalic care must also warn the runner.
+func TestCrateRuleSet_BrachycephalicRulesCarryAdvice(t *testing.T) {
+	for _, r := range DefaultCrateRules().Rules {
+		if containsFold(r.Skills, "brachycephalic_care") {
+			assert.NotEmpty(t, r.Advice, "rule %s", r.ID)
+		}
+	}
+}
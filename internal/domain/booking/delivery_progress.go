@@ -0,0 +1,39 @@
+package booking
+
+import (
+	"math"
+	"time"
+)
+
+// Live speed readings outside this band (stopped at lights, GPS spikes) fall back
+// to the average city speed.
+const (
+	minLiveSpeedKmh = 5.0
+	maxLiveSpeedKmh = 90.0
+)
+
+// DeliveryProgress is a value object with the live remaining distance and ETA of an
+// in-progress booking, recomputed from each runner location update.
+type DeliveryProgress struct {
+	RemainingDistanceKm float64   `json:"remaining_distance_km"`
+	EstimatedArrivalAt  time.Time `json:"estimated_arrival_at"`
+	OriginalArrivalAt   time.Time `json:"original_arrival_at"`
+	DelayMin            int       `json:"delay_min"`
+	NotifiedDelayMin    int       `json:"notified_delay_min"`
+	UpdatedAt           time.Time `json:"updated_at"` // time of the location fix it was computed from
+}
+
+// IsDelayed returns true if the current ETA is later than the original estimate.
+func (p DeliveryProgress) IsDelayed() bool {
+	return p.DelayMin > 0
+}
+
+// estimateRemainingDuration converts a remaining distance into travel time using the
+// runner's reported speed when plausible.
+func estimateRemainingDuration(remainingKm, speedKmh float64) time.Duration {
+	speed := speedKmh
+	if speed < minLiveSpeedKmh || speed > maxLiveSpeedKmh {
+		speed = averageCitySpeedKmh
+	}
+	return time.Duration(math.Round(remainingKm / speed * float64(time.Hour)))
+}
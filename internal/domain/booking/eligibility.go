@@ -0,0 +1,235 @@
+package booking
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EligibilitySeverity says whether an eligibility finding stops the booking.
+type EligibilitySeverity string
+
+const (
+	// EligibilityWarning is shown to the runner but does not stop the booking.
+	EligibilityWarning EligibilitySeverity = "warning"
+	// EligibilityBlocking rejects the booking.
+	EligibilityBlocking EligibilitySeverity = "blocking"
+)
+
+// Eligibility finding codes.
+const (
+	EligibilityVaccinationMissing    = "vaccination_missing"
+	EligibilityVaccinationExpired    = "vaccination_expired"
+	EligibilityVaccinationUnverified = "vaccination_unverified"
+	EligibilityBreedExtraHandling    = "breed_extra_handling"
+	EligibilityAgeNeedsVetNote       = "age_needs_vet_note"
+	EligibilityAgeVetNoteGiven       = "age_vet_note_given"
+	EligibilityAllergies             = "allergies"
+	EligibilitySpecialNeeds          = "special_needs"
+)
+
+// EligibilityFinding is one result of checking a pet against the eligibility policy.
+type EligibilityFinding struct {
+	Code     string              `json:"code"`
+	Severity EligibilitySeverity `json:"severity"`
+	Message  string              `json:"message"`
+}
+
+// EligibilityReport is what a booking keeps of its eligibility check, so the runner
+// knows what to expect at pickup.
+type EligibilityReport struct {
+	Findings []EligibilityFinding `json:"findings"`
+	// VetNote is the owner's vet note for pets outside the usual age range.
+	VetNote     string    `json:"vet_note,omitempty"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
+
+// Blocking returns the findings that reject the booking.
+func (r *EligibilityReport) Blocking() []EligibilityFinding {
+	var blocking []EligibilityFinding
+	for _, f := range r.Findings {
+		if f.Severity == EligibilityBlocking {
+			blocking = append(blocking, f)
+		}
+	}
+	return blocking
+}
+
+// AgeLimit is the age range, in months, a pet type travels in without a vet note.
+// Zero means no limit on that side.
+type AgeLimit struct {
+	MinMonths int `json:"min_months"`
+	MaxMonths int `json:"max_months"`
+}
+
+// EligibilityPolicy decides which pets may travel and what the runner must be told.
+// Pet types, breeds and vaccine names are matched ignoring case.
+type EligibilityPolicy struct {
+	// RequiredVaccines lists, per pet type, the vaccinations that must be valid on the
+	// day of the trip.
+	RequiredVaccines map[string][]string `json:"required_vaccines"`
+	// VaccineValidityMonths is how long a vaccination without an expiry date counts
+	// as valid after it was given.
+	VaccineValidityMonths int `json:"vaccine_validity_months"`
+	// RequireVerifiedVaccines blocks bookings whose required vaccinations are not
+	// verified; otherwise they only produce a warning.
+	RequireVerifiedVaccines bool `json:"require_verified_vaccines"`
+	// ExtraHandlingBreeds lists, per pet type, breeds the runner is warned about, with
+	// the handling advice to show. Breeds with crate rule advice (e.g. short-nosed
+	// breeds) are warned about through the crate rules and need no entry here.
+	ExtraHandlingBreeds map[string]map[string]string `json:"extra_handling_breeds"`
+	// AgeLimits gives, per pet type, the ages that need no vet note.
+	AgeLimits map[string]AgeLimit `json:"age_limits"`
+}
+
+// DefaultEligibilityPolicy returns the policy used when none is configured.
+func DefaultEligibilityPolicy() EligibilityPolicy {
+	return EligibilityPolicy{
+		RequiredVaccines: map[string][]string{
+			string(PetTypeDog): {"rabies"},
+		},
+		VaccineValidityMonths: 12,
+		ExtraHandlingBreeds: map[string]map[string]string{
+			string(PetTypeDog): {
+				"rottweiler":                "strong guarding breed: muzzle on request, keep other pets apart",
+				"american pit bull terrier": "strong breed: muzzle on request, keep other pets apart",
+			},
+		},
+		AgeLimits: map[string]AgeLimit{
+			string(PetTypeDog):    {MinMonths: 2, MaxMonths: 144},
+			string(PetTypeCat):    {MinMonths: 2, MaxMonths: 180},
+			string(PetTypeRabbit): {MinMonths: 2, MaxMonths: 96},
+		},
+	}
+}
+
+// EligibilityInput is what the policy looks at.
+type EligibilityInput struct {
+	PetSpec   PetSpecification
+	Allergies string
+	VetNote   string
+	// TripAt is when the pet travels; vaccinations must be valid then.
+	TripAt time.Time
+}
+
+// Evaluate checks a pet against the policy and returns the report. Any blocking
+// finding means the pet may not travel.
+func (p EligibilityPolicy) Evaluate(in EligibilityInput, now time.Time) *EligibilityReport {
+	petType := strings.ToLower(in.PetSpec.PetType)
+	report := &EligibilityReport{
+		Findings:    []EligibilityFinding{},
+		VetNote:     strings.TrimSpace(in.VetNote),
+		EvaluatedAt: now,
+	}
+	add := func(code string, severity EligibilitySeverity, format string, args ...interface{}) {
+		report.Findings = append(report.Findings, EligibilityFinding{
+			Code: code, Severity: severity, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, vaccine := range lookup(p.RequiredVaccines, petType) {
+		record := latestVaccination(in.PetSpec.Vaccinations, vaccine, in.TripAt)
+		switch {
+		case record == nil:
+			add(EligibilityVaccinationMissing, EligibilityBlocking,
+				"%s vaccination is required for a %s", vaccine, petType)
+		case !p.vaccinationValidAt(*record, in.TripAt):
+			add(EligibilityVaccinationExpired, EligibilityBlocking,
+				"%s vaccination is not valid on %s", vaccine, in.TripAt.Format("2006-01-02"))
+		case !record.Verified && p.RequireVerifiedVaccines:
+			add(EligibilityVaccinationUnverified, EligibilityBlocking,
+				"%s vaccination has not been verified", vaccine)
+		case !record.Verified:
+			add(EligibilityVaccinationUnverified, EligibilityWarning,
+				"%s vaccination has not been verified; ask to see the certificate", vaccine)
+		}
+	}
+
+	// The crate rules' advice comes from the same rules that set the crate, so a pet
+	// never gets the crate without the warning or the other way round.
+	advice := DefaultCrateRules().Advice(in.PetSpec)
+	label := strings.TrimSpace(in.PetSpec.Breed)
+	if label != "" {
+		if extra := lookup(lookup(p.ExtraHandlingBreeds, petType), label); extra != "" && !containsFold(advice, extra) {
+			advice = append(advice, extra)
+		}
+	} else {
+		label = petType
+	}
+	for _, a := range advice {
+		add(EligibilityBreedExtraHandling, EligibilityWarning, "%s: %s", label, a)
+	}
+
+	if limit := lookup(p.AgeLimits, petType); in.PetSpec.Age > 0 {
+		tooYoung := limit.MinMonths > 0 && in.PetSpec.Age < limit.MinMonths
+		tooOld := limit.MaxMonths > 0 && in.PetSpec.Age > limit.MaxMonths
+		if tooYoung || tooOld {
+			if report.VetNote == "" {
+				add(EligibilityAgeNeedsVetNote, EligibilityBlocking,
+					"a %d-month-old %s needs a vet note to travel", in.PetSpec.Age, petType)
+			} else {
+				add(EligibilityAgeVetNoteGiven, EligibilityWarning,
+					"%d months old; vet note: %s", in.PetSpec.Age, report.VetNote)
+			}
+		}
+	}
+
+	if allergies := strings.TrimSpace(in.Allergies); allergies != "" {
+		add(EligibilityAllergies, EligibilityWarning, "allergies: %s", allergies)
+	}
+	if needs := strings.TrimSpace(in.PetSpec.SpecialNeeds); needs != "" {
+		add(EligibilitySpecialNeeds, EligibilityWarning, "special needs: %s", needs)
+	}
+
+	return report
+}
+
+func (p EligibilityPolicy) vaccinationValidAt(v VaccinationRecord, at time.Time) bool {
+	if v.ExpiresAt != nil {
+		return at.Before(*v.ExpiresAt)
+	}
+	if p.VaccineValidityMonths <= 0 {
+		return true
+	}
+	return at.Before(v.DateGiven.AddDate(0, p.VaccineValidityMonths, 0))
+}
+
+// latestVaccination returns the most recent vaccination against the named disease
+// given before at, or nil.
+func latestVaccination(records []VaccinationRecord, vaccine string, at time.Time) *VaccinationRecord {
+	var latest *VaccinationRecord
+	for i := range records {
+		r := &records[i]
+		if !strings.EqualFold(strings.TrimSpace(r.VaccineName), vaccine) || r.DateGiven.After(at) {
+			continue
+		}
+		if latest == nil || r.DateGiven.After(latest.DateGiven) {
+			latest = r
+		}
+	}
+	return latest
+}
+
+// lookup returns the entry for key from a map whose keys are matched ignoring case.
+func lookup[V any](m map[string]V, key string) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	var zero V
+	return zero
+}
+
+// Eligibility returns the pet eligibility check made when the booking was created,
+// or nil if none was made.
+func (b *Booking) Eligibility() *EligibilityReport { return b.eligibility }
+
+// SetEligibility records the eligibility check of a new booking.
+func (b *Booking) SetEligibility(report *EligibilityReport) {
+	b.eligibility = report
+	b.updatedAt = time.Now().UTC()
+}
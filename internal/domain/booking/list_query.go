@@ -0,0 +1,72 @@
+package booking
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListFilter narrows a booking listing. Zero values mean "no constraint".
+type ListFilter struct {
+	Statuses      []BookingStatus
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	PetType       PetType
+	Scheduled     *bool
+	SeriesID      *uuid.UUID
+	ParentID      *uuid.UUID
+	MinPriceCents *int64
+	MaxPriceCents *int64
+}
+
+// Cursor is a keyset position in a listing ordered by (created_at, id) descending.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorAfter returns the cursor positioned after the given booking.
+func CursorAfter(b *Booking) Cursor {
+	return Cursor{CreatedAt: b.CreatedAt(), ID: b.ID()}
+}
+
+// Encode returns the opaque string form of the cursor handed to clients.
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses an opaque cursor produced by Cursor.Encode.
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	return Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// ListQuery describes a keyset-paginated booking listing. OwnerID and RunnerID
+// scope the listing; both nil lists every booking (admin).
+type ListQuery struct {
+	OwnerID  *uuid.UUID
+	RunnerID *uuid.UUID
+	Filter   ListFilter
+	Search   string // free-text match on number, IDs, pet name and address lines
+	After    *Cursor
+	Limit    int
+}
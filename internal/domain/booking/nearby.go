@@ -0,0 +1,49 @@
+package booking
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRunnerPayoutPercent is the share of a booking's price paid out to the runner
+// when none is configured. It matches the payment service's 85/15 runner/platform split.
+const DefaultRunnerPayoutPercent = 85
+
+// RunnerPayoutCents returns the runner's payout for a booking priced at priceCents
+// when runners are paid percent of the price.
+func RunnerPayoutCents(priceCents int64, percent int) int64 {
+	return priceCents * int64(percent) / 100
+}
+
+// NearbyQuery selects requested bookings whose pickup lies within RadiusKm of a point,
+// nearest first.
+type NearbyQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	// ExcludeDeclinedBy leaves out bookings the runner has declined before.
+	ExcludeDeclinedBy *uuid.UUID
+	Limit             int
+}
+
+// BoundingBox returns the latitude and longitude range that contains the search
+// circle, so candidates can be narrowed with a plain index before distances are
+// computed.
+func (q NearbyQuery) BoundingBox() (minLat, maxLat, minLng, maxLng float64) {
+	const kmPerDegreeLat = 111.32
+	dLat := q.RadiusKm / kmPerDegreeLat
+	minLat, maxLat = q.Latitude-dLat, q.Latitude+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		// The circle reaches a pole: every longitude is in range.
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180
+	}
+	dLng := dLat / math.Cos(degreesToRadians(q.Latitude))
+	return minLat, maxLat, q.Longitude - dLng, q.Longitude + dLng
+}
+
+// NearbyBooking is a requested booking with its pickup distance from the search point.
+type NearbyBooking struct {
+	Booking          *Booking
+	PickupDistanceKm float64
+}
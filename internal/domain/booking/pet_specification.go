@@ -69,32 +69,19 @@ type CrateRequirement struct {
 	NeedsVentilation       bool      `json:"needs_ventilation"`
 	NeedsTempControl       bool      `json:"needs_temp_control"`
 	MinimumWeightCapacity  float64   `json:"minimum_weight_capacity"`
+	// ExtraVentilation asks for a crate ventilated on all sides.
+	ExtraVentilation bool `json:"extra_ventilation"`
+	// NeedsCover asks for a covered carrier.
+	NeedsCover bool `json:"needs_cover"`
+	// RunnerSkills lists the skills a runner needs to take the booking.
+	RunnerSkills []string `json:"runner_skills,omitempty"`
+	// RuleVersion and AppliedRules record which crate rules produced the requirement.
+	RuleVersion  int      `json:"rule_version,omitempty"`
+	AppliedRules []string `json:"applied_rules,omitempty"`
 }
 
-// DetermineCrateRequirement automatically determines the crate requirements based on pet specs.
+// DetermineCrateRequirement derives the crate requirements from the pet specs using the
+// built-in crate rules.
 func DetermineCrateRequirement(spec PetSpecification) CrateRequirement {
-	req := CrateRequirement{
-		NeedsVentilation:      true,
-		NeedsTempControl:      false,
-		MinimumWeightCapacity: spec.WeightKg * 1.2, // 20% buffer
-	}
-
-	// Determine crate size based on weight
-	switch {
-	case spec.WeightKg <= 5:
-		req.MinimumSize = CrateSizeSmall
-	case spec.WeightKg <= 15:
-		req.MinimumSize = CrateSizeMedium
-	case spec.WeightKg <= 30:
-		req.MinimumSize = CrateSizeLarge
-	default:
-		req.MinimumSize = CrateSizeXLarge
-	}
-
-	// Reptiles need temperature control
-	if PetType(spec.PetType) == PetTypeReptile {
-		req.NeedsTempControl = true
-	}
-
-	return req
+	return DefaultCrateRules().Determine(spec)
 }
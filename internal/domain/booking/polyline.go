@@ -0,0 +1,38 @@
+package booking
+
+import (
+	"math"
+	"strings"
+)
+
+// routePoint is a coordinate a route passes through.
+type routePoint struct {
+	lat, lng float64
+}
+
+// encodePolyline encodes points with the Google encoded polyline algorithm
+// (precision 5), the format map clients decode directly.
+func encodePolyline(points []routePoint) string {
+	var sb strings.Builder
+	var prevLat, prevLng int64
+	for _, p := range points {
+		lat := int64(math.Round(p.lat * 1e5))
+		lng := int64(math.Round(p.lng * 1e5))
+		encodePolylineValue(&sb, lat-prevLat)
+		encodePolylineValue(&sb, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return sb.String()
+}
+
+func encodePolylineValue(sb *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte(0x20|(u&0x1f)) + 63)
+		u >>= 5
+	}
+	sb.WriteByte(byte(u) + 63)
+}
@@ -1,6 +1,10 @@
 package booking
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 // PricingStrategy defines the interface for calculating booking prices.
 type PricingStrategy interface {
@@ -14,6 +18,23 @@ type PricingParams struct {
 	PetType     PetType
 	CrateSize   CrateSize
 	IsScheduled bool
+	// IsRoundTrip prices one leg of a round trip booked together with its other leg.
+	IsRoundTrip bool
+	// StopWaitMin holds the wait time of each intermediate stop, in visiting order.
+	StopWaitMin []int
+	// PickupZone and DropoffZone are the service zones the trip starts and ends in;
+	// nil when no service area is configured.
+	PickupZone  *ZoneFare
+	DropoffZone *ZoneFare
+}
+
+// ZoneFare is the pricing of a service zone.
+type ZoneFare struct {
+	ZoneID uuid.UUID
+	// BaseFareCents replaces the standard base fare for trips picked up in the zone.
+	BaseFareCents *int64
+	// CrossZoneSurchargeCents applies to trips that end in a different zone.
+	CrossZoneSurchargeCents int64
 }
 
 // StandardPricingStrategy implements the default pricing logic for Kilat Pet Runner.
@@ -27,10 +48,13 @@ func NewStandardPricingStrategy() *StandardPricingStrategy {
 // Calculate computes the estimated price in cents (sen for MYR).
 //
 // Pricing formula:
-//   - Base fare: MYR 5.00 (500 sen)
+//   - Base fare: MYR 5.00 (500 sen), or the pickup zone's base fare
+//   - Cross-zone: the higher surcharge of the two zones when pickup and dropoff zones differ
 //   - Distance: MYR 2.50/km (250 sen/km)
 //   - Pet surcharge: varies by pet type
 //   - Crate surcharge: varies by crate size
+//   - Stops: MYR 2.00 per stop plus MYR 0.30 per minute of wait
+//   - Round trip: RoundTripDiscountPercent off each leg
 func (s *StandardPricingStrategy) Calculate(params PricingParams) (int64, error) {
 	if params.DistanceKm < 0 {
 		return 0, fmt.Errorf("distance cannot be negative")
@@ -38,6 +62,14 @@ func (s *StandardPricingStrategy) Calculate(params PricingParams) (int64, error)
 
 	// Base fare: MYR 5.00
 	var totalCents int64 = 500
+	if params.PickupZone != nil && params.PickupZone.BaseFareCents != nil {
+		totalCents = *params.PickupZone.BaseFareCents
+	}
+
+	// Cross-zone surcharge
+	if params.PickupZone != nil && params.DropoffZone != nil && params.PickupZone.ZoneID != params.DropoffZone.ZoneID {
+		totalCents += max(params.PickupZone.CrossZoneSurchargeCents, params.DropoffZone.CrossZoneSurchargeCents)
+	}
 
 	// Distance charge: MYR 2.50 per km
 	totalCents += int64(params.DistanceKm * 250)
@@ -52,6 +84,18 @@ func (s *StandardPricingStrategy) Calculate(params PricingParams) (int64, error)
 	// Crate size surcharge
 	totalCents += crateSizeSurcharge(params.CrateSize)
 
+	// Stop and wait fees
+	for _, waitMin := range params.StopWaitMin {
+		if waitMin < 0 {
+			return 0, fmt.Errorf("stop wait time cannot be negative")
+		}
+		totalCents += 200 + int64(waitMin)*30
+	}
+
+	if params.IsRoundTrip {
+		totalCents -= totalCents * RoundTripDiscountPercent / 100
+	}
+
 	return totalCents, nil
 }
 
@@ -2,6 +2,7 @@ package booking
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,9 +21,30 @@ type BookingRepository interface {
 	// FindByRunnerID retrieves bookings assigned to a specific runner with pagination.
 	FindByRunnerID(ctx context.Context, runnerID uuid.UUID, page, limit int) ([]*Booking, int64, error)
 
+	// FindByRunnerIDAndStatus retrieves every booking assigned to a runner in the given status.
+	FindByRunnerIDAndStatus(ctx context.Context, runnerID uuid.UUID, status BookingStatus) ([]*Booking, error)
+
+	// FindActiveByRunnerID retrieves the runner's accepted and in-progress bookings.
+	FindActiveByRunnerID(ctx context.Context, runnerID uuid.UUID) ([]*Booking, error)
+
+	// FindScheduledBySeries retrieves the non-cancelled bookings generated from a
+	// recurring series with scheduled_at in [from, to), earliest first.
+	FindScheduledBySeries(ctx context.Context, seriesID uuid.UUID, from, to time.Time) ([]*Booking, error)
+
+	// FindByParentID retrieves both legs of a round trip.
+	FindByParentID(ctx context.Context, parentID uuid.UUID) ([]*Booking, error)
+
 	// ListAll retrieves all bookings with pagination (admin).
 	ListAll(ctx context.Context, page, limit int) ([]*Booking, int64, error)
 
+	// List retrieves bookings matching the query using keyset pagination on
+	// (created_at, id), newest first.
+	List(ctx context.Context, query ListQuery) ([]*Booking, error)
+
+	// FindRequestedNear retrieves requested bookings whose pickup is within the query
+	// radius, nearest first.
+	FindRequestedNear(ctx context.Context, query NearbyQuery) ([]NearbyBooking, error)
+
 	// CountByStatus returns booking counts grouped by status (admin).
 	CountByStatus(ctx context.Context) (map[string]int64, error)
 
@@ -31,4 +53,7 @@ type BookingRepository interface {
 
 	// Update persists changes to an existing booking with optimistic locking.
 	Update(ctx context.Context, booking *Booking) error
+
+	// UpdateProgress persists only the live delivery progress (no optimistic locking).
+	UpdateProgress(ctx context.Context, booking *Booking) error
 }
@@ -0,0 +1,42 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TripLeg identifies which half of a round trip a booking is.
+type TripLeg string
+
+const (
+	LegOutbound TripLeg = "outbound"
+	LegReturn   TripLeg = "return"
+)
+
+// RoundTripDiscountPercent is taken off each leg when both are booked together.
+const RoundTripDiscountPercent = 10
+
+// LinkRoundTrip marks a new booking as one leg of a round trip. Both legs share parentID.
+func (b *Booking) LinkRoundTrip(parentID uuid.UUID, leg TripLeg) {
+	b.parentID = &parentID
+	b.leg = leg
+}
+
+// IsOutboundLeg reports whether the booking is the outbound leg of a round trip.
+func (b *Booking) IsOutboundLeg() bool {
+	return b.parentID != nil && b.leg == LegOutbound
+}
+
+// EarliestReturnAt is the earliest time a return leg may be scheduled: the outbound
+// pickup time (or now for an immediate booking) plus the estimated trip duration.
+func (b *Booking) EarliestReturnAt(now time.Time) time.Time {
+	start := now
+	if b.scheduledAt != nil && b.scheduledAt.After(now) {
+		start = *b.scheduledAt
+	}
+	if b.routeSpec != nil {
+		start = start.Add(time.Duration(b.routeSpec.EstimatedDurationMin) * time.Minute)
+	}
+	return start
+}
@@ -1,12 +1,99 @@
 package booking
 
+import (
+	"math"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// averageCitySpeedKmh is the assumed door-to-door speed when no live speed is known.
+const averageCitySpeedKmh = 25.0
+
 // RouteSpecification is a value object representing the calculated route between pickup and dropoff.
+// DistanceKm is the sum of its legs; EstimatedDurationMin also includes the wait at each stop.
 type RouteSpecification struct {
-	PickupLat           float64 `json:"pickup_lat"`
-	PickupLng           float64 `json:"pickup_lng"`
-	DropoffLat          float64 `json:"dropoff_lat"`
-	DropoffLng          float64 `json:"dropoff_lng"`
-	DistanceKm          float64 `json:"distance_km"`
-	EstimatedDurationMin int    `json:"estimated_duration_min"`
-	Polyline            string  `json:"polyline"`
+	PickupLat            float64    `json:"pickup_lat"`
+	PickupLng            float64    `json:"pickup_lng"`
+	DropoffLat           float64    `json:"dropoff_lat"`
+	DropoffLng           float64    `json:"dropoff_lng"`
+	DistanceKm           float64    `json:"distance_km"`
+	EstimatedDurationMin int        `json:"estimated_duration_min"`
+	Polyline             string     `json:"polyline"`
+	Legs                 []RouteLeg `json:"legs,omitempty"`
+}
+
+// RouteLeg is the part of a route between two consecutive points: pickup, each stop, dropoff.
+type RouteLeg struct {
+	FromLat              float64 `json:"from_lat"`
+	FromLng              float64 `json:"from_lng"`
+	ToLat                float64 `json:"to_lat"`
+	ToLng                float64 `json:"to_lng"`
+	DistanceKm           float64 `json:"distance_km"`
+	EstimatedDurationMin int     `json:"estimated_duration_min"`
+	Polyline             string  `json:"polyline"`
+}
+
+// PlanRoute builds the route from pickup through each stop, in order, to dropoff.
+func PlanRoute(pickup dto.AddressDTO, stops []Stop, dropoff dto.AddressDTO) *RouteSpecification {
+	points := make([]routePoint, 0, len(stops)+2)
+	points = append(points, routePoint{pickup.Latitude, pickup.Longitude})
+	waitMin := 0
+	for _, st := range stops {
+		points = append(points, routePoint{st.Address.Latitude, st.Address.Longitude})
+		waitMin += st.WaitMin
+	}
+	points = append(points, routePoint{dropoff.Latitude, dropoff.Longitude})
+
+	spec := &RouteSpecification{
+		PickupLat:            pickup.Latitude,
+		PickupLng:            pickup.Longitude,
+		DropoffLat:           dropoff.Latitude,
+		DropoffLng:           dropoff.Longitude,
+		EstimatedDurationMin: waitMin,
+		Polyline:             encodePolyline(points),
+		Legs:                 make([]RouteLeg, 0, len(points)-1),
+	}
+	for i := 1; i < len(points); i++ {
+		from, to := points[i-1], points[i]
+		distanceKm := HaversineDistanceKm(from.lat, from.lng, to.lat, to.lng)
+		leg := RouteLeg{
+			FromLat:              from.lat,
+			FromLng:              from.lng,
+			ToLat:                to.lat,
+			ToLng:                to.lng,
+			DistanceKm:           distanceKm,
+			EstimatedDurationMin: EstimateDurationMin(distanceKm),
+			Polyline:             encodePolyline([]routePoint{from, to}),
+		}
+		spec.Legs = append(spec.Legs, leg)
+		spec.DistanceKm += leg.DistanceKm
+		spec.EstimatedDurationMin += leg.EstimatedDurationMin
+	}
+	return spec
+}
+
+// EstimateDurationMin returns the estimated travel time in whole minutes for a distance.
+func EstimateDurationMin(distanceKm float64) int {
+	return int(math.Ceil(distanceKm / averageCitySpeedKmh * 60))
+}
+
+// HaversineDistanceKm calculates the great-circle distance between two coordinates in kilometers.
+func HaversineDistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371.0
+
+	dLat := degreesToRadians(lat2 - lat1)
+	dLng := degreesToRadians(lng2 - lng1)
+
+	lat1Rad := degreesToRadians(lat1)
+	lat2Rad := degreesToRadians(lat2)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Sin(dLng/2)*math.Sin(dLng/2)*math.Cos(lat1Rad)*math.Cos(lat2Rad)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusKm * c
+}
+
+func degreesToRadians(deg float64) float64 {
+	return deg * math.Pi / 180.0
 }
@@ -0,0 +1,54 @@
+package booking
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+)
+
+// Window returns the time the booking keeps its runner busy. It starts at pickup once
+// the pet is picked up, at the scheduled time for a future booking, and otherwise now.
+// It lasts the estimated trip time.
+func (b *Booking) Window(now time.Time) (start, end time.Time) {
+	start = now
+	switch {
+	case b.pickedUpAt != nil:
+		start = *b.pickedUpAt
+	case b.scheduledAt != nil && b.scheduledAt.After(now):
+		start = *b.scheduledAt
+	}
+	return start, start.Add(b.estimatedDuration())
+}
+
+// CheckRunnerLoad checks that a runner holding the active bookings may also take bk.
+// The runner must hold fewer than maxActive bookings (0 means no limit), and a
+// scheduled booking must not overlap any booking the runner holds.
+func CheckRunnerLoad(bk *Booking, active []*Booking, maxActive int, now time.Time) error {
+	held := make([]*Booking, 0, len(active))
+	for _, other := range active {
+		if other.ID() != bk.ID() {
+			held = append(held, other)
+		}
+	}
+
+	if maxActive > 0 && len(held) >= maxActive {
+		return domain.NewConflictError(fmt.Sprintf(
+			"runner already has %d active bookings (limit %d)", len(held), maxActive))
+	}
+	if bk.ScheduledAt() == nil {
+		return nil
+	}
+
+	start, end := bk.Window(now)
+	for _, other := range held {
+		otherStart, otherEnd := other.Window(now)
+		if start.Before(otherEnd) && otherStart.Before(end) {
+			return domain.NewConflictError(fmt.Sprintf(
+				"booking overlaps %s, which the runner holds from %s to %s",
+				other.BookingNumber(),
+				otherStart.Format(time.RFC3339), otherEnd.Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
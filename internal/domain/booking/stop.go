@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// Limits on the intermediate stops of a single booking.
+const (
+	MaxStops       = 5
+	MaxStopWaitMin = 120
+)
+
+// StopPurpose says why the runner stops between pickup and dropoff.
+type StopPurpose string
+
+const (
+	StopPurposeVet      StopPurpose = "vet"
+	StopPurposeGrooming StopPurpose = "grooming"
+	StopPurposeDaycare  StopPurpose = "daycare"
+	StopPurposeBoarding StopPurpose = "boarding"
+	StopPurposePetStore StopPurpose = "pet_store"
+	StopPurposeOther    StopPurpose = "other"
+)
+
+// IsValid returns true if the purpose is a recognised value.
+func (p StopPurpose) IsValid() bool {
+	switch p {
+	case StopPurposeVet, StopPurposeGrooming, StopPurposeDaycare,
+		StopPurposeBoarding, StopPurposePetStore, StopPurposeOther:
+		return true
+	}
+	return false
+}
+
+// Stop is an intermediate stop of a multi-stop booking. Stops are visited in
+// sequence order; the runner confirms each one on arrival.
+type Stop struct {
+	Sequence    int            `json:"sequence"`
+	Address     dto.AddressDTO `json:"address"`
+	Purpose     StopPurpose    `json:"purpose"`
+	WaitMin     int            `json:"wait_min"`
+	ConfirmedAt *time.Time     `json:"confirmed_at,omitempty"`
+}
+
+// NewStops validates the intermediate stops of a new booking and numbers them
+// from 1 in the given order.
+func NewStops(stops []Stop) ([]Stop, error) {
+	if len(stops) > MaxStops {
+		return nil, domain.NewValidationError(fmt.Sprintf("a booking can have at most %d stops", MaxStops))
+	}
+	result := make([]Stop, len(stops))
+	for i, st := range stops {
+		if st.Address.Line1 == "" {
+			return nil, domain.NewValidationError(fmt.Sprintf("stops[%d].address is required", i))
+		}
+		if !st.Purpose.IsValid() {
+			return nil, domain.NewValidationError(fmt.Sprintf("stops[%d].purpose is invalid: %s", i, st.Purpose))
+		}
+		if st.WaitMin < 0 || st.WaitMin > MaxStopWaitMin {
+			return nil, domain.NewValidationError(
+				fmt.Sprintf("stops[%d].wait_min must be between 0 and %d", i, MaxStopWaitMin),
+			)
+		}
+		result[i] = Stop{
+			Sequence: i + 1,
+			Address:  st.Address,
+			Purpose:  st.Purpose,
+			WaitMin:  st.WaitMin,
+		}
+	}
+	return result, nil
+}
+
+// Stops returns the intermediate stops in visiting order (empty for a direct trip).
+func (b *Booking) Stops() []Stop { return b.stops }
+
+// SetStops sets the stops of a new booking, as returned by NewStops.
+func (b *Booking) SetStops(stops []Stop) {
+	b.stops = stops
+	b.updatedAt = time.Now().UTC()
+}
+
+// NextStop returns the first stop the runner has not confirmed yet, or nil.
+func (b *Booking) NextStop() *Stop {
+	for i := range b.stops {
+		if b.stops[i].ConfirmedAt == nil {
+			return &b.stops[i]
+		}
+	}
+	return nil
+}
+
+// ConfirmStop records that the runner reached the stop with the given sequence
+// number. Stops can only be confirmed in order while the delivery is in progress.
+func (b *Booking) ConfirmStop(sequence int, at time.Time) error {
+	if b.status != StatusInProgress {
+		return domain.NewConflictError(
+			fmt.Sprintf("cannot confirm a stop of booking in state '%s'", b.status),
+		)
+	}
+	if sequence < 1 || sequence > len(b.stops) {
+		return domain.NewNotFoundError("Stop", strconv.Itoa(sequence))
+	}
+	stop := &b.stops[sequence-1]
+	if stop.ConfirmedAt != nil {
+		return domain.NewConflictError(fmt.Sprintf("stop %d is already confirmed", sequence))
+	}
+	if next := b.NextStop(); next.Sequence != sequence {
+		return domain.NewConflictError(fmt.Sprintf("stop %d must be confirmed first", next.Sequence))
+	}
+	confirmedAt := at.UTC()
+	stop.ConfirmedAt = &confirmedAt
+	b.updatedAt = confirmedAt
+	return nil
+}
+
+// remainingRoute returns the distance from the given position through every
+// unconfirmed stop to the dropoff, and the wait time still to be spent at those stops.
+func (b *Booking) remainingRoute(lat, lng float64) (float64, time.Duration) {
+	var distanceKm float64
+	var waitMin int
+	for _, st := range b.stops {
+		if st.ConfirmedAt != nil {
+			continue
+		}
+		distanceKm += HaversineDistanceKm(lat, lng, st.Address.Latitude, st.Address.Longitude)
+		lat, lng = st.Address.Latitude, st.Address.Longitude
+		waitMin += st.WaitMin
+	}
+	distanceKm += HaversineDistanceKm(lat, lng, b.dropoffAddress.Latitude, b.dropoffAddress.Longitude)
+	return distanceKm, time.Duration(waitMin) * time.Minute
+}
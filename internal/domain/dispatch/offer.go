@@ -0,0 +1,183 @@
+// Package dispatch holds the automatic dispatcher's model: offers of a booking to one
+// runner at a time, and the ranking that picks the runner.
+package dispatch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/google/uuid"
+)
+
+// OfferState is where an offer stands.
+type OfferState string
+
+const (
+	// OfferOffered is waiting for the runner to answer.
+	OfferOffered OfferState = "offered"
+	// OfferAccepted means the runner took the booking.
+	OfferAccepted OfferState = "accepted"
+	// OfferRejected means the runner turned the booking down.
+	OfferRejected OfferState = "rejected"
+	// OfferExpired means the runner did not answer in time, or the booking was taken
+	// or cancelled before they did.
+	OfferExpired OfferState = "expired"
+)
+
+// Offer is one round of dispatching a booking: the booking is held for a single runner
+// until they answer or the offer expires.
+type Offer struct {
+	id        uuid.UUID
+	bookingID uuid.UUID
+	runnerID  uuid.UUID
+	// round counts the offers made for the booking, starting at 1.
+	round int
+	// score and distanceKm record why the runner was picked.
+	score       float64
+	distanceKm  float64
+	state       OfferState
+	offeredAt   time.Time
+	expiresAt   time.Time
+	respondedAt *time.Time
+	version     int64
+}
+
+// NewOffer offers a booking to a runner until now+timeout.
+func NewOffer(bookingID, runnerID uuid.UUID, round int, score, distanceKm float64, now time.Time, timeout time.Duration) (*Offer, error) {
+	if bookingID == uuid.Nil || runnerID == uuid.Nil {
+		return nil, domain.NewValidationError("booking ID and runner ID are required")
+	}
+	if round < 1 {
+		return nil, domain.NewValidationError("offer round must be at least 1")
+	}
+	if timeout <= 0 {
+		return nil, domain.NewValidationError("offer timeout must be positive")
+	}
+	return &Offer{
+		id:         uuid.New(),
+		bookingID:  bookingID,
+		runnerID:   runnerID,
+		round:      round,
+		score:      score,
+		distanceKm: distanceKm,
+		state:      OfferOffered,
+		offeredAt:  now,
+		expiresAt:  now.Add(timeout),
+		version:    1,
+	}, nil
+}
+
+// ReconstructOffer rebuilds an Offer from persistence data (no validation).
+func ReconstructOffer(
+	id, bookingID, runnerID uuid.UUID,
+	round int,
+	score, distanceKm float64,
+	state OfferState,
+	offeredAt, expiresAt time.Time,
+	respondedAt *time.Time,
+	version int64,
+) *Offer {
+	return &Offer{
+		id:          id,
+		bookingID:   bookingID,
+		runnerID:    runnerID,
+		round:       round,
+		score:       score,
+		distanceKm:  distanceKm,
+		state:       state,
+		offeredAt:   offeredAt,
+		expiresAt:   expiresAt,
+		respondedAt: respondedAt,
+		version:     version,
+	}
+}
+
+// --- Getters ---
+
+// ID returns the offer's unique identifier.
+func (o *Offer) ID() uuid.UUID { return o.id }
+
+// BookingID returns the booking on offer.
+func (o *Offer) BookingID() uuid.UUID { return o.bookingID }
+
+// RunnerID returns the runner the booking is offered to.
+func (o *Offer) RunnerID() uuid.UUID { return o.runnerID }
+
+// Round returns which offer this is for the booking, starting at 1.
+func (o *Offer) Round() int { return o.round }
+
+// Score returns the runner's ranking score when the offer was made; lower is better.
+func (o *Offer) Score() float64 { return o.score }
+
+// DistanceKm returns the runner's distance from the pickup when the offer was made.
+func (o *Offer) DistanceKm() float64 { return o.distanceKm }
+
+// State returns where the offer stands.
+func (o *Offer) State() OfferState { return o.state }
+
+// OfferedAt returns when the offer was made.
+func (o *Offer) OfferedAt() time.Time { return o.offeredAt }
+
+// ExpiresAt returns when the offer lapses if unanswered.
+func (o *Offer) ExpiresAt() time.Time { return o.expiresAt }
+
+// RespondedAt returns when the offer left the offered state, or nil.
+func (o *Offer) RespondedAt() *time.Time { return o.respondedAt }
+
+// Version returns the optimistic locking version.
+func (o *Offer) Version() int64 { return o.version }
+
+// --- Behavior ---
+
+// IsOpen reports whether the offer is still waiting for an answer at now.
+func (o *Offer) IsOpen(now time.Time) bool {
+	return o.state == OfferOffered && now.Before(o.expiresAt)
+}
+
+// IsFor reports whether the offer was made to the runner.
+func (o *Offer) IsFor(runnerID uuid.UUID) bool { return o.runnerID == runnerID }
+
+// Accept records that the runner took the booking.
+func (o *Offer) Accept(now time.Time) error {
+	if err := o.ensureOpen(now); err != nil {
+		return err
+	}
+	o.close(OfferAccepted, now)
+	return nil
+}
+
+// Reject records that the runner turned the booking down.
+func (o *Offer) Reject(now time.Time) error {
+	if err := o.ensureOpen(now); err != nil {
+		return err
+	}
+	o.close(OfferRejected, now)
+	return nil
+}
+
+// Expire closes an unanswered offer, either because it timed out or because the
+// booking no longer needs a runner.
+func (o *Offer) Expire(now time.Time) error {
+	if o.state != OfferOffered {
+		return domain.NewInvalidStateError(string(o.state), string(OfferExpired))
+	}
+	o.close(OfferExpired, now)
+	return nil
+}
+
+func (o *Offer) ensureOpen(now time.Time) error {
+	if o.state != OfferOffered {
+		return domain.NewConflictError(fmt.Sprintf("offer is already %s", o.state))
+	}
+	if !now.Before(o.expiresAt) {
+		return domain.NewConflictError("offer has expired")
+	}
+	return nil
+}
+
+func (o *Offer) close(state OfferState, now time.Time) {
+	o.state = state
+	o.respondedAt = &now
+	o.version++
+}
@@ -0,0 +1,74 @@
+package dispatch
+
+import (
+	"sort"
+
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+)
+
+// NeutralRating is the rating assumed for runners who have not been rated yet.
+const NeutralRating = 4.0
+
+// Candidate is a runner who could be offered a booking.
+type Candidate struct {
+	RunnerID  uuid.UUID
+	Latitude  float64
+	Longitude float64
+	// Rating is the runner's average rating out of 5; zero means unrated.
+	Rating float64
+	// RecentRefusals counts the bookings the runner declined, rejected or let expire
+	// recently.
+	RecentRefusals int
+}
+
+// RankWeights sets how much each factor moves a runner's score. Lower scores rank
+// first.
+type RankWeights struct {
+	// PerKm is added for every kilometre between the runner and the pickup.
+	PerKm float64
+	// PerRatingPoint is subtracted for every rating point.
+	PerRatingPoint float64
+	// PerRefusal is added for every recent refusal.
+	PerRefusal float64
+}
+
+// DefaultRankWeights trades one kilometre for a third of a rating point or half a
+// refusal.
+func DefaultRankWeights() RankWeights {
+	return RankWeights{PerKm: 1, PerRatingPoint: 3, PerRefusal: 2}
+}
+
+// RankedCandidate is a candidate with their distance from the pickup and score.
+type RankedCandidate struct {
+	Candidate
+	DistanceKm float64
+	Score      float64
+}
+
+// Rank scores candidates for a pickup and orders them best first. Ties are broken
+// by runner ID so the order is deterministic.
+func Rank(candidates []Candidate, pickupLat, pickupLng float64, w RankWeights) []RankedCandidate {
+	ranked := make([]RankedCandidate, len(candidates))
+	for i, c := range candidates {
+		rating := c.Rating
+		if rating <= 0 {
+			rating = NeutralRating
+		}
+		distance := bookingDomain.HaversineDistanceKm(c.Latitude, c.Longitude, pickupLat, pickupLng)
+		ranked[i] = RankedCandidate{
+			Candidate:  c,
+			DistanceKm: distance,
+			Score: distance*w.PerKm -
+				rating*w.PerRatingPoint +
+				float64(c.RecentRefusals)*w.PerRefusal,
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score < ranked[j].Score
+		}
+		return ranked[i].RunnerID.String() < ranked[j].RunnerID.String()
+	})
+	return ranked
+}
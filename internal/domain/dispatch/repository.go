@@ -0,0 +1,45 @@
+package dispatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OfferRepository defines persistence operations for booking offers.
+type OfferRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*Offer, error)
+	// FindByBookingID returns every offer made for the booking, first round first.
+	FindByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*Offer, error)
+	// FindOpenByRunnerID returns the runner's offers still in the offered state.
+	FindOpenByRunnerID(ctx context.Context, runnerID uuid.UUID) ([]*Offer, error)
+	// FindStale returns offered offers that have expired at now or whose booking is no
+	// longer requested.
+	FindStale(ctx context.Context, now time.Time) ([]*Offer, error)
+	// FindBookingsToDispatch returns requested bookings without an open offer that
+	// have had fewer than maxRounds offers, least recently tried first.
+	FindBookingsToDispatch(ctx context.Context, maxRounds, limit int) ([]uuid.UUID, error)
+	// MarkDispatchAttempted records that the dispatcher tried the booking at the given time.
+	MarkDispatchAttempted(ctx context.Context, bookingID uuid.UUID, at time.Time) error
+	// FindBusyRunnerIDs returns the runners holding an offered offer.
+	FindBusyRunnerIDs(ctx context.Context) ([]uuid.UUID, error)
+	// FindDeclinedRunnerIDs returns the runners who declined the booking after
+	// accepting it.
+	FindDeclinedRunnerIDs(ctx context.Context, bookingID uuid.UUID) ([]uuid.UUID, error)
+	// CountRecentRefusals counts, per runner, declines, rejected offers and offers
+	// that timed out since the given time.
+	CountRecentRefusals(ctx context.Context, runnerIDs []uuid.UUID, since time.Time) (map[uuid.UUID]int, error)
+	// Save persists a new offer. It stores nothing and returns false if the booking or
+	// the runner already has an open offer.
+	Save(ctx context.Context, offer *Offer) (bool, error)
+	// Update persists a state change with optimistic locking on version.
+	Update(ctx context.Context, offer *Offer) error
+}
+
+// RunnerLocator finds runners available for dispatch.
+type RunnerLocator interface {
+	// FindAvailableNear returns online runners whose last known position, reported
+	// after seenSince, lies within radiusKm of the point.
+	FindAvailableNear(ctx context.Context, lat, lng, radiusKm float64, seenSince time.Time) ([]Candidate, error)
+}
@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/google/uuid"
+)
+
+// Availability is a runner's last reported position, whether they are taking work
+// and their rating, as used by the automatic dispatcher.
+type Availability struct {
+	runnerID  uuid.UUID
+	online    bool
+	latitude  float64
+	longitude float64
+	// rating is the runner's average rating out of 5; zero means unrated.
+	rating    float64
+	updatedAt time.Time
+}
+
+// NewAvailability validates and builds a runner availability report.
+func NewAvailability(runnerID uuid.UUID, online bool, latitude, longitude, rating float64, updatedAt time.Time) (*Availability, error) {
+	if runnerID == uuid.Nil {
+		return nil, domain.NewValidationError("runner ID is required")
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, domain.NewValidationError("runner position is out of range")
+	}
+	if rating < 0 || rating > 5 {
+		return nil, domain.NewValidationError("rating must be between 0 and 5")
+	}
+	return &Availability{
+		runnerID:  runnerID,
+		online:    online,
+		latitude:  latitude,
+		longitude: longitude,
+		rating:    rating,
+		updatedAt: updatedAt.UTC(),
+	}, nil
+}
+
+// RunnerID returns the runner the report belongs to.
+func (a *Availability) RunnerID() uuid.UUID { return a.runnerID }
+
+// Online reports whether the runner is taking work.
+func (a *Availability) Online() bool { return a.online }
+
+// Latitude returns the runner's last reported latitude.
+func (a *Availability) Latitude() float64 { return a.latitude }
+
+// Longitude returns the runner's last reported longitude.
+func (a *Availability) Longitude() float64 { return a.longitude }
+
+// Rating returns the runner's average rating, or zero if unrated.
+func (a *Availability) Rating() float64 { return a.rating }
+
+// UpdatedAt returns when the runner service recorded the report.
+func (a *Availability) UpdatedAt() time.Time { return a.updatedAt }
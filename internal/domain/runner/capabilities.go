@@ -0,0 +1,164 @@
+// Package runner holds this service's view of runners, as published by the runner
+// service.
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+)
+
+// Capabilities is what a runner can carry: the crates they own, whether they have a
+// covered carrier, whether their vehicle is climate controlled, their handling
+// certifications and the heaviest pet they can manage.
+type Capabilities struct {
+	runnerID       uuid.UUID
+	crateSizes     []bookingDomain.CrateSize
+	coveredCarrier bool
+	climateControl bool
+	// certifications are handling skills such as "reptile_handling", matched against
+	// the runner skills of a crate requirement.
+	certifications []string
+	maxPetWeightKg float64
+	// updatedAt is when the runner service recorded the profile; older updates are
+	// ignored.
+	updatedAt time.Time
+}
+
+// NewCapabilities validates and builds a capability profile.
+func NewCapabilities(
+	runnerID uuid.UUID,
+	crateSizes []string,
+	coveredCarrier bool,
+	climateControl bool,
+	certifications []string,
+	maxPetWeightKg float64,
+	updatedAt time.Time,
+) (*Capabilities, error) {
+	if runnerID == uuid.Nil {
+		return nil, domain.NewValidationError("runner ID is required")
+	}
+	if maxPetWeightKg < 0 {
+		return nil, domain.NewValidationError("max pet weight cannot be negative")
+	}
+	sizes := make([]bookingDomain.CrateSize, 0, len(crateSizes))
+	for _, s := range crateSizes {
+		size := bookingDomain.CrateSize(strings.ToLower(strings.TrimSpace(s)))
+		if !size.IsValid() {
+			return nil, domain.NewValidationError(fmt.Sprintf("invalid crate size: %s", s))
+		}
+		sizes = append(sizes, size)
+	}
+	certs := make([]string, 0, len(certifications))
+	for _, c := range certifications {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			certs = append(certs, c)
+		}
+	}
+	sort.Strings(certs)
+	return &Capabilities{
+		runnerID:       runnerID,
+		crateSizes:     sizes,
+		coveredCarrier: coveredCarrier,
+		climateControl: climateControl,
+		certifications: certs,
+		maxPetWeightKg: maxPetWeightKg,
+		updatedAt:      updatedAt.UTC(),
+	}, nil
+}
+
+// ReconstructCapabilities rebuilds a Capabilities from persistence data (no validation).
+func ReconstructCapabilities(
+	runnerID uuid.UUID,
+	crateSizes []bookingDomain.CrateSize,
+	coveredCarrier bool,
+	climateControl bool,
+	certifications []string,
+	maxPetWeightKg float64,
+	updatedAt time.Time,
+) *Capabilities {
+	return &Capabilities{
+		runnerID:       runnerID,
+		crateSizes:     crateSizes,
+		coveredCarrier: coveredCarrier,
+		climateControl: climateControl,
+		certifications: certifications,
+		maxPetWeightKg: maxPetWeightKg,
+		updatedAt:      updatedAt,
+	}
+}
+
+// --- Getters ---
+
+// RunnerID returns the runner the profile belongs to.
+func (c *Capabilities) RunnerID() uuid.UUID { return c.runnerID }
+
+// CrateSizes returns the crate sizes the runner owns.
+func (c *Capabilities) CrateSizes() []bookingDomain.CrateSize { return c.crateSizes }
+
+// CoveredCarrier reports whether the runner has a covered carrier.
+func (c *Capabilities) CoveredCarrier() bool { return c.coveredCarrier }
+
+// ClimateControl reports whether the runner's vehicle is climate controlled.
+func (c *Capabilities) ClimateControl() bool { return c.climateControl }
+
+// Certifications returns the runner's handling certifications.
+func (c *Capabilities) Certifications() []string { return c.certifications }
+
+// MaxPetWeightKg returns the heaviest pet the runner can carry.
+func (c *Capabilities) MaxPetWeightKg() float64 { return c.maxPetWeightKg }
+
+// UpdatedAt returns when the runner service recorded the profile.
+func (c *Capabilities) UpdatedAt() time.Time { return c.updatedAt }
+
+// --- Behavior ---
+
+// Missing lists what the runner lacks to carry a pet with the given crate
+// requirement; it is empty when the runner qualifies. A crate at least as large as
+// the required one will do.
+func (c *Capabilities) Missing(req bookingDomain.CrateRequirement) []string {
+	var missing []string
+	if req.MinimumSize != "" && !c.hasCrateOfAtLeast(req.MinimumSize) {
+		missing = append(missing, fmt.Sprintf("%s crate or larger", req.MinimumSize))
+	}
+	if req.NeedsCover && !c.coveredCarrier {
+		missing = append(missing, "a covered carrier")
+	}
+	if req.NeedsTempControl && !c.climateControl {
+		missing = append(missing, "a climate-controlled vehicle")
+	}
+	for _, skill := range req.RunnerSkills {
+		if !c.hasCertification(skill) {
+			missing = append(missing, fmt.Sprintf("%s certification", skill))
+		}
+	}
+	if req.MinimumWeightCapacity > c.maxPetWeightKg {
+		missing = append(missing, fmt.Sprintf(
+			"weight capacity of %.1f kg (has %.1f kg)", req.MinimumWeightCapacity, c.maxPetWeightKg,
+		))
+	}
+	return missing
+}
+
+func (c *Capabilities) hasCrateOfAtLeast(size bookingDomain.CrateSize) bool {
+	for _, owned := range c.crateSizes {
+		if !bookingDomain.CrateSizeLess(owned, size) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Capabilities) hasCertification(skill string) bool {
+	for _, cert := range c.certifications {
+		if strings.EqualFold(cert, skill) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// CapabilitiesRepository defines persistence operations for runner capability profiles.
+type CapabilitiesRepository interface {
+	// FindByRunnerID returns the runner's profile, or nil if none has been received.
+	FindByRunnerID(ctx context.Context, runnerID uuid.UUID) (*Capabilities, error)
+	// Upsert stores the profile unless a newer one is already stored, and reports
+	// whether it was stored.
+	Upsert(ctx context.Context, c *Capabilities) (bool, error)
+}
+
+// AvailabilityRepository stores runner availability reports.
+type AvailabilityRepository interface {
+	// Upsert stores the report unless a newer one is already stored, and reports
+	// whether it was stored.
+	Upsert(ctx context.Context, a *Availability) (bool, error)
+}
@@ -0,0 +1,22 @@
+package savedaddress
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SavedAddressRepository defines persistence operations for saved addresses.
+type SavedAddressRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*SavedAddress, error)
+	// FindByOwnerID returns the owner's saved addresses ordered by label.
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*SavedAddress, error)
+	CountByOwnerID(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	// ExistsByLabel reports whether the owner has another address with the label,
+	// ignoring case.
+	ExistsByLabel(ctx context.Context, ownerID uuid.UUID, label string, excludeID uuid.UUID) (bool, error)
+	Save(ctx context.Context, address *SavedAddress) error
+	// Update persists changes with optimistic locking on version.
+	Update(ctx context.Context, address *SavedAddress) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,164 @@
+// Package savedaddress holds an owner's address book of named pickup and dropoff
+// addresses.
+package savedaddress
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/google/uuid"
+)
+
+// MaxPerOwner caps the size of one owner's address book.
+const MaxPerOwner = 20
+
+// SavedAddress is the aggregate root for a named address, such as "Home" or "Vet",
+// that an owner can book from or to without typing it again.
+type SavedAddress struct {
+	id      uuid.UUID
+	ownerID uuid.UUID
+	label   string
+	address dto.AddressDTO
+	// accessNotes tell the runner how to get in: gate codes, floor, parking.
+	accessNotes  string
+	contactPhone string
+
+	version   int64
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewSavedAddress creates a saved address for the owner.
+func NewSavedAddress(ownerID uuid.UUID, label string, address dto.AddressDTO, accessNotes, contactPhone string) (*SavedAddress, error) {
+	if ownerID == uuid.Nil {
+		return nil, domain.NewValidationError("owner ID is required")
+	}
+	now := time.Now().UTC()
+	a := &SavedAddress{
+		id:        uuid.New(),
+		ownerID:   ownerID,
+		version:   1,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := a.set(label, address, accessNotes, contactPhone); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ReconstructSavedAddress rebuilds a SavedAddress from persistence data (no validation).
+func ReconstructSavedAddress(
+	id, ownerID uuid.UUID,
+	label string,
+	address dto.AddressDTO,
+	accessNotes, contactPhone string,
+	version int64,
+	createdAt, updatedAt time.Time,
+) *SavedAddress {
+	return &SavedAddress{
+		id:           id,
+		ownerID:      ownerID,
+		label:        label,
+		address:      address,
+		accessNotes:  accessNotes,
+		contactPhone: contactPhone,
+		version:      version,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+// --- Getters ---
+
+// ID returns the saved address's unique identifier.
+func (a *SavedAddress) ID() uuid.UUID { return a.id }
+
+// OwnerID returns the owner whose address book this belongs to.
+func (a *SavedAddress) OwnerID() uuid.UUID { return a.ownerID }
+
+// Label returns the owner's name for the address, unique within their address book.
+func (a *SavedAddress) Label() string { return a.label }
+
+// Address returns the postal address with its coordinates.
+func (a *SavedAddress) Address() dto.AddressDTO { return a.address }
+
+// AccessNotes returns the runner's instructions for getting in.
+func (a *SavedAddress) AccessNotes() string { return a.accessNotes }
+
+// ContactPhone returns the phone number to call on arrival.
+func (a *SavedAddress) ContactPhone() string { return a.contactPhone }
+
+// Version returns the entity version for optimistic locking.
+func (a *SavedAddress) Version() int64 { return a.version }
+
+// CreatedAt returns the creation timestamp.
+func (a *SavedAddress) CreatedAt() time.Time { return a.createdAt }
+
+// UpdatedAt returns the last-updated timestamp.
+func (a *SavedAddress) UpdatedAt() time.Time { return a.updatedAt }
+
+// --- Behavior ---
+
+// IsOwnedBy checks if the saved address belongs to the given owner.
+func (a *SavedAddress) IsOwnedBy(ownerID uuid.UUID) bool {
+	return a.ownerID == ownerID
+}
+
+// Update replaces the saved address and bumps its version. Bookings already made
+// from it keep their snapshot.
+func (a *SavedAddress) Update(label string, address dto.AddressDTO, accessNotes, contactPhone string) error {
+	if err := a.set(label, address, accessNotes, contactPhone); err != nil {
+		return err
+	}
+	a.version++
+	a.updatedAt = time.Now().UTC()
+	return nil
+}
+
+func (a *SavedAddress) set(label string, address dto.AddressDTO, accessNotes, contactPhone string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return domain.NewValidationError("label is required")
+	}
+	if len(label) > 50 {
+		return domain.NewValidationError("label must be at most 50 characters")
+	}
+	if strings.TrimSpace(address.Line1) == "" {
+		return domain.NewValidationError("address line 1 is required")
+	}
+	if address.Latitude < -90 || address.Latitude > 90 || address.Longitude < -180 || address.Longitude > 180 {
+		return domain.NewValidationError("address coordinates are out of range")
+	}
+	if len(accessNotes) > 500 {
+		return domain.NewValidationError("access notes must be at most 500 characters")
+	}
+	contactPhone = strings.TrimSpace(contactPhone)
+	if contactPhone != "" && !isPhoneNumber(contactPhone) {
+		return domain.NewValidationError("contact phone must be a phone number")
+	}
+	a.label = label
+	a.address = address
+	a.accessNotes = strings.TrimSpace(accessNotes)
+	a.contactPhone = contactPhone
+	return nil
+}
+
+// isPhoneNumber accepts digits with an optional leading "+" and the usual separators,
+// with 7 to 15 digits as allowed by E.164.
+func isPhoneNumber(s string) bool {
+	digits := 0
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= 7 && digits <= 15
+}
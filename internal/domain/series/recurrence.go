@@ -0,0 +1,206 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+)
+
+// Frequency is the RRULE FREQ part supported by booking series.
+type Frequency string
+
+const (
+	FrequencyDaily  Frequency = "DAILY"
+	FrequencyWeekly Frequency = "WEEKLY"
+)
+
+// maxInterval bounds INTERVAL so a typo cannot push every occurrence out of reach.
+const maxInterval = 52
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the subset of an iCalendar RRULE (RFC 5545) that booking series
+// support: FREQ=DAILY|WEEKLY with optional INTERVAL, BYDAY, and one of COUNT or UNTIL.
+type Recurrence struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    *time.Time
+}
+
+// ParseRecurrence parses an RRULE such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// The "RRULE:" prefix is optional. UNTIL may be a UTC timestamp (20261231T170000Z)
+// or a date (20261231), which is inclusive of that whole day in the series timezone.
+func ParseRecurrence(rule string, loc *time.Location) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return r, domain.NewValidationError("rrule is required")
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return r, domain.NewValidationError(fmt.Sprintf("invalid rrule part: %q", part))
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if r.Freq != FrequencyDaily && r.Freq != FrequencyWeekly {
+				return r, domain.NewValidationError(fmt.Sprintf("unsupported rrule FREQ: %s (use DAILY or WEEKLY)", value))
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxInterval {
+				return r, domain.NewValidationError(fmt.Sprintf("rrule INTERVAL must be between 1 and %d", maxInterval))
+			}
+			r.Interval = n
+		case "BYDAY":
+			seen := make(map[time.Weekday]bool)
+			for _, code := range strings.Split(strings.ToUpper(value), ",") {
+				wd, ok := weekdayCodes[code]
+				if !ok {
+					return r, domain.NewValidationError(fmt.Sprintf("invalid rrule BYDAY value: %s", code))
+				}
+				if !seen[wd] {
+					seen[wd] = true
+					r.ByDay = append(r.ByDay, wd)
+				}
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return r, domain.NewValidationError("rrule COUNT must be a positive integer")
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value, loc)
+			if err != nil {
+				return r, err
+			}
+			r.Until = &until
+		default:
+			return r, domain.NewValidationError(fmt.Sprintf("unsupported rrule part: %s", key))
+		}
+	}
+
+	if r.Freq == "" {
+		return r, domain.NewValidationError("rrule FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return r, domain.NewValidationError("rrule must not contain both COUNT and UNTIL")
+	}
+
+	// Order BYDAY Monday-first so weekly expansion walks each week chronologically.
+	sort.Slice(r.ByDay, func(i, j int) bool { return weekOffset(r.ByDay[i]) < weekOffset(r.ByDay[j]) })
+	return r, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, domain.NewValidationError("rrule UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+// Finite reports whether the rule ends on its own.
+func (r Recurrence) Finite() bool {
+	return r.Count > 0 || r.Until != nil
+}
+
+// Between expands the rule from start and returns the occurrences in (after, to].
+// exhausted is true when the rule has no occurrences after to.
+//
+// Occurrences keep start's wall-clock time in start's location, so a 08:00 series
+// stays at 08:00 across DST changes. COUNT counts every occurrence from start,
+// including ones before after.
+func (r Recurrence) Between(start, after, to time.Time) (occurrences []time.Time, exhausted bool) {
+	n := 0
+	for period := 0; ; period++ {
+		// Every candidate lies on or after its period's first day, so stopping on
+		// the period start also ends rules whose BYDAY can never match.
+		first, candidates := r.periodCandidates(start, period)
+		if r.Until != nil && first.After(*r.Until) {
+			return occurrences, true
+		}
+		if first.After(to) {
+			return occurrences, false
+		}
+		for _, t := range candidates {
+			if t.Before(start) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return occurrences, true
+			}
+			if t.After(to) {
+				return occurrences, false
+			}
+			n++
+			if t.After(after) {
+				occurrences = append(occurrences, t)
+			}
+			if r.Count > 0 && n >= r.Count {
+				return occurrences, true
+			}
+		}
+	}
+}
+
+// periodCandidates returns the first day of the period-th repetition (day or week)
+// of the rule and the candidate instants in it, in chronological order.
+func (r Recurrence) periodCandidates(start time.Time, period int) (time.Time, []time.Time) {
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+
+	switch r.Freq {
+	case FrequencyDaily:
+		day := time.Date(y, m, d+period*r.Interval, hh, mm, ss, 0, loc)
+		if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, day.Weekday()) {
+			return day, nil
+		}
+		return day, []time.Time{day}
+	default: // FrequencyWeekly
+		monday := d - weekOffset(start.Weekday()) + period*r.Interval*7
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		out := make([]time.Time, len(days))
+		for i, wd := range days {
+			out[i] = time.Date(y, m, monday+weekOffset(wd), hh, mm, ss, 0, loc)
+		}
+		return time.Date(y, m, monday, hh, mm, ss, 0, loc), out
+	}
+}
+
+// weekOffset is the number of days from Monday to wd.
+func weekOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package series
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeriesRepository defines persistence operations for recurring booking series.
+type SeriesRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*Series, error)
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*Series, error)
+	// FindDue returns active series whose bookings have not been generated up to horizon.
+	FindDue(ctx context.Context, horizon time.Time) ([]*Series, error)
+	Save(ctx context.Context, series *Series) error
+	// Update persists changes with optimistic locking on version.
+	Update(ctx context.Context, series *Series) error
+}
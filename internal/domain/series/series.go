@@ -0,0 +1,344 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone is used when a series is created without an explicit timezone.
+const DefaultTimezone = "Asia/Kuala_Lumpur"
+
+// dateLayout is the format of skipped dates, interpreted in the series timezone.
+const dateLayout = "2006-01-02"
+
+// SeriesStatus is the lifecycle state of a recurring booking series.
+type SeriesStatus string
+
+const (
+	SeriesStatusActive SeriesStatus = "active"
+	SeriesStatusPaused SeriesStatus = "paused"
+	SeriesStatusEnded  SeriesStatus = "ended"
+)
+
+// Series is the aggregate root for a recurring booking template. Its bookings are
+// materialised ahead of time as ordinary scheduled bookings linked by series ID.
+type Series struct {
+	id             uuid.UUID
+	ownerID        uuid.UUID
+	petSpec        dto.PetSpecDTO
+	pickupAddress  dto.AddressDTO
+	dropoffAddress dto.AddressDTO
+	notes          string
+
+	rrule      string
+	recurrence Recurrence
+	timezone   string
+	location   *time.Location
+	startsAt   time.Time
+
+	status           SeriesStatus
+	skippedDates     []string
+	generatedThrough *time.Time
+	endedAt          *time.Time
+
+	version   int64
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewSeries creates an active series whose first occurrence is no earlier than startsAt.
+// startsAt also fixes the local pickup time of every occurrence.
+func NewSeries(
+	ownerID uuid.UUID,
+	petSpec dto.PetSpecDTO,
+	pickupAddress dto.AddressDTO,
+	dropoffAddress dto.AddressDTO,
+	notes string,
+	rrule string,
+	timezone string,
+	startsAt time.Time,
+) (*Series, error) {
+	if ownerID == uuid.Nil {
+		return nil, domain.NewValidationError("owner ID is required")
+	}
+	if petSpec.Name == "" {
+		return nil, domain.NewValidationError("pet name is required")
+	}
+	if pickupAddress.Line1 == "" {
+		return nil, domain.NewValidationError("pickup address is required")
+	}
+	if dropoffAddress.Line1 == "" {
+		return nil, domain.NewValidationError("dropoff address is required")
+	}
+	if startsAt.IsZero() {
+		return nil, domain.NewValidationError("starts_at is required")
+	}
+	if !startsAt.After(time.Now()) {
+		return nil, domain.NewValidationError("starts_at must be in the future")
+	}
+
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("invalid timezone: %s", timezone))
+	}
+	recurrence, err := ParseRecurrence(rrule, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &Series{
+		id:             uuid.New(),
+		ownerID:        ownerID,
+		petSpec:        petSpec,
+		pickupAddress:  pickupAddress,
+		dropoffAddress: dropoffAddress,
+		notes:          notes,
+		rrule:          rrule,
+		recurrence:     recurrence,
+		timezone:       timezone,
+		location:       loc,
+		startsAt:       startsAt.UTC(),
+		status:         SeriesStatusActive,
+		version:        1,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// ReconstructSeries rebuilds a Series from persistence data. The stored rule and
+// timezone were validated on creation, so parse failures indicate corrupt data.
+func ReconstructSeries(
+	id, ownerID uuid.UUID,
+	petSpec dto.PetSpecDTO,
+	pickupAddress, dropoffAddress dto.AddressDTO,
+	notes, rrule, timezone string,
+	startsAt time.Time,
+	status SeriesStatus,
+	skippedDates []string,
+	generatedThrough, endedAt *time.Time,
+	version int64,
+	createdAt, updatedAt time.Time,
+) (*Series, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("series %s has invalid timezone %q: %w", id, timezone, err)
+	}
+	recurrence, err := ParseRecurrence(rrule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("series %s has invalid rrule %q: %w", id, rrule, err)
+	}
+	return &Series{
+		id:               id,
+		ownerID:          ownerID,
+		petSpec:          petSpec,
+		pickupAddress:    pickupAddress,
+		dropoffAddress:   dropoffAddress,
+		notes:            notes,
+		rrule:            rrule,
+		recurrence:       recurrence,
+		timezone:         timezone,
+		location:         loc,
+		startsAt:         startsAt,
+		status:           status,
+		skippedDates:     skippedDates,
+		generatedThrough: generatedThrough,
+		endedAt:          endedAt,
+		version:          version,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}, nil
+}
+
+// --- Getters ---
+
+func (s *Series) ID() uuid.UUID                  { return s.id }
+func (s *Series) OwnerID() uuid.UUID             { return s.ownerID }
+func (s *Series) PetSpec() dto.PetSpecDTO        { return s.petSpec }
+func (s *Series) PickupAddress() dto.AddressDTO  { return s.pickupAddress }
+func (s *Series) DropoffAddress() dto.AddressDTO { return s.dropoffAddress }
+func (s *Series) Notes() string                  { return s.notes }
+func (s *Series) RRule() string                  { return s.rrule }
+func (s *Series) Timezone() string               { return s.timezone }
+func (s *Series) StartsAt() time.Time            { return s.startsAt }
+func (s *Series) Status() SeriesStatus           { return s.status }
+func (s *Series) SkippedDates() []string         { return s.skippedDates }
+func (s *Series) GeneratedThrough() *time.Time   { return s.generatedThrough }
+func (s *Series) EndedAt() *time.Time            { return s.endedAt }
+func (s *Series) Version() int64                 { return s.version }
+func (s *Series) CreatedAt() time.Time           { return s.createdAt }
+func (s *Series) UpdatedAt() time.Time           { return s.updatedAt }
+
+// --- Behavior ---
+
+// IsOwnedBy checks if the series belongs to the given owner.
+func (s *Series) IsOwnedBy(ownerID uuid.UUID) bool {
+	return s.ownerID == ownerID
+}
+
+// Pause stops generating bookings. Occurrences up to the moment of pausing stay
+// generated; the caller cancels any later ones that were already materialised.
+func (s *Series) Pause(now time.Time) error {
+	if s.status != SeriesStatusActive {
+		return domain.NewInvalidStateError(string(s.status), string(SeriesStatusPaused))
+	}
+	s.status = SeriesStatusPaused
+	if s.generatedThrough == nil || s.generatedThrough.After(now) {
+		t := now.UTC()
+		s.generatedThrough = &t
+	}
+	s.touch()
+	return nil
+}
+
+// Resume restarts generation from now; occurrences missed while paused are not back-filled.
+func (s *Series) Resume(now time.Time) error {
+	if s.status != SeriesStatusPaused {
+		return domain.NewInvalidStateError(string(s.status), string(SeriesStatusActive))
+	}
+	s.status = SeriesStatusActive
+	if s.generatedThrough == nil || s.generatedThrough.Before(now) {
+		t := now.UTC()
+		s.generatedThrough = &t
+	}
+	s.touch()
+	return nil
+}
+
+// End permanently stops the series.
+func (s *Series) End(now time.Time) error {
+	if s.status == SeriesStatusEnded {
+		return domain.NewInvalidStateError(string(s.status), string(SeriesStatusEnded))
+	}
+	t := now.UTC()
+	s.status = SeriesStatusEnded
+	s.endedAt = &t
+	s.touch()
+	return nil
+}
+
+// Skip excludes the occurrence on the given local date (YYYY-MM-DD) from generation.
+// It returns the start and end of that day so the caller can cancel a booking that
+// was already generated for it.
+func (s *Series) Skip(date string) (dayStart, dayEnd time.Time, err error) {
+	if s.status == SeriesStatusEnded {
+		return dayStart, dayEnd, domain.NewConflictError("cannot skip a date of an ended series")
+	}
+	day, err := time.ParseInLocation(dateLayout, date, s.location)
+	if err != nil {
+		return dayStart, dayEnd, domain.NewValidationError("date must be YYYY-MM-DD")
+	}
+	dayStart, dayEnd = day, day.AddDate(0, 0, 1)
+	if !dayEnd.After(time.Now()) {
+		return dayStart, dayEnd, domain.NewValidationError("cannot skip a date in the past")
+	}
+	if occ, _ := s.recurrence.Between(s.startsAt.In(s.location), dayStart.Add(-time.Nanosecond), dayEnd.Add(-time.Nanosecond)); len(occ) == 0 {
+		return dayStart, dayEnd, domain.NewValidationError(fmt.Sprintf("series has no occurrence on %s", date))
+	}
+
+	if !s.IsSkipped(dayStart) {
+		s.skippedDates = append(s.skippedDates, date)
+		sort.Strings(s.skippedDates)
+	}
+	s.touch()
+	return dayStart, dayEnd, nil
+}
+
+// IsSkipped reports whether the occurrence at t falls on a skipped date.
+func (s *Series) IsSkipped(t time.Time) bool {
+	date := t.In(s.location).Format(dateLayout)
+	for _, d := range s.skippedDates {
+		if d == date {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingOccurrences returns the occurrences after the generation watermark up to
+// horizon, excluding skipped dates. exhausted is true when the rule has no further
+// occurrences beyond horizon.
+func (s *Series) PendingOccurrences(horizon time.Time) (occurrences []time.Time, exhausted bool) {
+	after := s.startsAt.Add(-time.Nanosecond)
+	if s.generatedThrough != nil && s.generatedThrough.After(after) {
+		after = *s.generatedThrough
+	}
+	all, exhausted := s.recurrence.Between(s.startsAt.In(s.location), after, horizon)
+	for _, t := range all {
+		if !s.IsSkipped(t) {
+			occurrences = append(occurrences, t.UTC())
+		}
+	}
+	return occurrences, exhausted
+}
+
+// UpcomingOccurrences returns up to n future occurrences of an active series from
+// now, excluding skipped dates, regardless of whether they have been generated yet.
+func (s *Series) UpcomingOccurrences(now time.Time, n int) []time.Time {
+	if s.status != SeriesStatusActive {
+		return nil
+	}
+	var out []time.Time
+	// Look ahead in widening windows so sparse rules still fill n slots.
+	for window := 30 * 24 * time.Hour; window <= 2*366*24*time.Hour; window *= 4 {
+		out = out[:0]
+		all, exhausted := s.recurrence.Between(s.startsAt.In(s.location), now, now.Add(window))
+		for _, t := range all {
+			if !s.IsSkipped(t) {
+				out = append(out, t.UTC())
+				if len(out) == n {
+					return out
+				}
+			}
+		}
+		if exhausted {
+			break
+		}
+	}
+	return out
+}
+
+// SkipFailedOccurrence leaves out the occurrence at t after its booking could not be
+// created, and moves the generation watermark past it so later trips still run. It
+// returns the skipped local date.
+func (s *Series) SkipFailedOccurrence(t time.Time) string {
+	date := t.In(s.location).Format(dateLayout)
+	if !s.IsSkipped(t) {
+		s.skippedDates = append(s.skippedDates, date)
+		sort.Strings(s.skippedDates)
+	}
+	if s.generatedThrough == nil || t.After(*s.generatedThrough) {
+		t = t.UTC()
+		s.generatedThrough = &t
+	}
+	s.touch()
+	return date
+}
+
+// MarkGeneratedThrough advances the generation watermark to t. finished ends the
+// series once its rule has no occurrences left to run.
+func (s *Series) MarkGeneratedThrough(t time.Time, finished bool) {
+	if s.generatedThrough == nil || t.After(*s.generatedThrough) {
+		t = t.UTC()
+		s.generatedThrough = &t
+	}
+	if finished && s.status != SeriesStatusEnded {
+		now := time.Now().UTC()
+		s.status = SeriesStatusEnded
+		s.endedAt = &now
+	}
+	s.touch()
+}
+
+func (s *Series) touch() {
+	s.version++
+	s.updatedAt = time.Now().UTC()
+}
@@ -0,0 +1,101 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxClockSkew bounds how far in the future a device timestamp may be before it is rejected.
+const maxClockSkew = 2 * time.Minute
+
+// LocationPing is a single GPS fix reported by the assigned runner during delivery.
+type LocationPing struct {
+	id         uuid.UUID
+	bookingID  uuid.UUID
+	runnerID   uuid.UUID
+	latitude   float64
+	longitude  float64
+	accuracyM  float64
+	headingDeg float64
+	speedKmh   float64
+	recordedAt time.Time
+	createdAt  time.Time
+}
+
+// NewLocationPing creates a validated location ping. A zero recordedAt defaults to now.
+func NewLocationPing(
+	bookingID, runnerID uuid.UUID,
+	latitude, longitude, accuracyM, headingDeg, speedKmh float64,
+	recordedAt time.Time,
+) (*LocationPing, error) {
+	if bookingID == uuid.Nil {
+		return nil, fmt.Errorf("booking ID is required")
+	}
+	if runnerID == uuid.Nil {
+		return nil, fmt.Errorf("runner ID is required")
+	}
+	if latitude < -90 || latitude > 90 {
+		return nil, fmt.Errorf("latitude out of range: %f", latitude)
+	}
+	if longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("longitude out of range: %f", longitude)
+	}
+	if accuracyM < 0 || speedKmh < 0 {
+		return nil, fmt.Errorf("accuracy and speed must not be negative")
+	}
+
+	now := time.Now().UTC()
+	if recordedAt.IsZero() {
+		recordedAt = now
+	}
+	if recordedAt.After(now.Add(maxClockSkew)) {
+		return nil, fmt.Errorf("recorded_at is in the future")
+	}
+
+	return &LocationPing{
+		id:         uuid.New(),
+		bookingID:  bookingID,
+		runnerID:   runnerID,
+		latitude:   latitude,
+		longitude:  longitude,
+		accuracyM:  accuracyM,
+		headingDeg: headingDeg,
+		speedKmh:   speedKmh,
+		recordedAt: recordedAt.UTC(),
+		createdAt:  now,
+	}, nil
+}
+
+// Reconstruct rebuilds a LocationPing from persistence.
+func Reconstruct(
+	id, bookingID, runnerID uuid.UUID,
+	latitude, longitude, accuracyM, headingDeg, speedKmh float64,
+	recordedAt, createdAt time.Time,
+) *LocationPing {
+	return &LocationPing{
+		id:         id,
+		bookingID:  bookingID,
+		runnerID:   runnerID,
+		latitude:   latitude,
+		longitude:  longitude,
+		accuracyM:  accuracyM,
+		headingDeg: headingDeg,
+		speedKmh:   speedKmh,
+		recordedAt: recordedAt,
+		createdAt:  createdAt,
+	}
+}
+
+// Getters.
+func (l *LocationPing) ID() uuid.UUID         { return l.id }
+func (l *LocationPing) BookingID() uuid.UUID  { return l.bookingID }
+func (l *LocationPing) RunnerID() uuid.UUID   { return l.runnerID }
+func (l *LocationPing) Latitude() float64     { return l.latitude }
+func (l *LocationPing) Longitude() float64    { return l.longitude }
+func (l *LocationPing) AccuracyM() float64    { return l.accuracyM }
+func (l *LocationPing) HeadingDeg() float64   { return l.headingDeg }
+func (l *LocationPing) SpeedKmh() float64     { return l.speedKmh }
+func (l *LocationPing) RecordedAt() time.Time { return l.recordedAt }
+func (l *LocationPing) CreatedAt() time.Time  { return l.createdAt }
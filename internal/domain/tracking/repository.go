@@ -0,0 +1,16 @@
+package tracking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocationRepository defines persistence operations for runner location history.
+type LocationRepository interface {
+	Save(ctx context.Context, ping *LocationPing) error
+	FindLatest(ctx context.Context, bookingID uuid.UUID) (*LocationPing, error)
+	FindRecent(ctx context.Context, bookingID uuid.UUID, limit int) ([]*LocationPing, error)
+	DeleteRecordedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,180 @@
+package zone
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+)
+
+// maxVertices bounds the size of a zone geometry so containment checks stay cheap.
+const maxVertices = 10000
+
+// GeoJSON geometry types accepted for a zone.
+const (
+	GeometryPolygon      = "Polygon"
+	GeometryMultiPolygon = "MultiPolygon"
+)
+
+// position is a GeoJSON position: longitude first, then latitude.
+type position [2]float64
+
+// ring is a closed linear ring; the first and last positions are equal.
+type ring []position
+
+// polygon is an outer ring followed by zero or more holes.
+type polygon []ring
+
+// Geometry is a value object holding a zone's GeoJSON Polygon or MultiPolygon.
+type Geometry struct {
+	geoType  string
+	polygons []polygon
+	// bounding box, used to reject far-away points before the ring tests
+	minLng, minLat, maxLng, maxLat float64
+}
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParseGeometry parses and validates a GeoJSON Polygon or MultiPolygon geometry.
+func ParseGeometry(data []byte) (Geometry, error) {
+	var raw geoJSONGeometry
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Geometry{}, domain.NewValidationError(fmt.Sprintf("geometry is not valid GeoJSON: %v", err))
+	}
+
+	var polygons []polygon
+	switch raw.Type {
+	case GeometryPolygon:
+		var p polygon
+		if err := json.Unmarshal(raw.Coordinates, &p); err != nil {
+			return Geometry{}, domain.NewValidationError(fmt.Sprintf("invalid Polygon coordinates: %v", err))
+		}
+		polygons = []polygon{p}
+	case GeometryMultiPolygon:
+		if err := json.Unmarshal(raw.Coordinates, &polygons); err != nil {
+			return Geometry{}, domain.NewValidationError(fmt.Sprintf("invalid MultiPolygon coordinates: %v", err))
+		}
+	default:
+		return Geometry{}, domain.NewValidationError(
+			fmt.Sprintf("geometry type must be Polygon or MultiPolygon, got %q", raw.Type),
+		)
+	}
+
+	return newGeometry(raw.Type, polygons)
+}
+
+func newGeometry(geoType string, polygons []polygon) (Geometry, error) {
+	if len(polygons) == 0 {
+		return Geometry{}, domain.NewValidationError("geometry has no polygons")
+	}
+
+	g := Geometry{geoType: geoType, minLng: 180, minLat: 90, maxLng: -180, maxLat: -90}
+	vertices := 0
+	for i, p := range polygons {
+		if len(p) == 0 {
+			return Geometry{}, domain.NewValidationError(fmt.Sprintf("polygon %d has no rings", i))
+		}
+		for j, r := range p {
+			if len(r) < 4 {
+				return Geometry{}, domain.NewValidationError(
+					fmt.Sprintf("polygon %d ring %d needs at least 4 positions", i, j),
+				)
+			}
+			if r[0] != r[len(r)-1] {
+				return Geometry{}, domain.NewValidationError(
+					fmt.Sprintf("polygon %d ring %d is not closed", i, j),
+				)
+			}
+			for _, pos := range r {
+				lng, lat := pos[0], pos[1]
+				if lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+					return Geometry{}, domain.NewValidationError(
+						fmt.Sprintf("polygon %d ring %d has an out-of-range position [%g, %g]", i, j, lng, lat),
+					)
+				}
+				if j == 0 {
+					g.minLng, g.maxLng = min(g.minLng, lng), max(g.maxLng, lng)
+					g.minLat, g.maxLat = min(g.minLat, lat), max(g.maxLat, lat)
+				}
+			}
+			vertices += len(r)
+		}
+	}
+	if vertices > maxVertices {
+		return Geometry{}, domain.NewValidationError(
+			fmt.Sprintf("geometry has %d vertices, at most %d are allowed", vertices, maxVertices),
+		)
+	}
+
+	g.polygons = polygons
+	return g, nil
+}
+
+// Type returns the GeoJSON geometry type.
+func (g Geometry) Type() string { return g.geoType }
+
+// IsZero reports whether the geometry is unset.
+func (g Geometry) IsZero() bool { return len(g.polygons) == 0 }
+
+// Contains reports whether the point lies inside any polygon of the geometry and
+// outside that polygon's holes. Points exactly on an edge may fall either way.
+func (g Geometry) Contains(lat, lng float64) bool {
+	if lng < g.minLng || lng > g.maxLng || lat < g.minLat || lat > g.maxLat {
+		return false
+	}
+	for _, p := range g.polygons {
+		if !p[0].contains(lat, lng) {
+			continue
+		}
+		inHole := false
+		for _, hole := range p[1:] {
+			if hole.contains(lat, lng) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// contains is the even-odd ray casting test, treating coordinates as planar. Zones
+// are city-sized, so the error from ignoring the earth's curvature is negligible.
+func (r ring) contains(lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// MarshalJSON encodes the geometry back to GeoJSON.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	var coordinates interface{} = g.polygons
+	if g.geoType == GeometryPolygon {
+		coordinates = g.polygons[0]
+	}
+	return json.Marshal(struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{g.geoType, coordinates})
+}
+
+// UnmarshalJSON parses and validates a GeoJSON geometry.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseGeometry(data)
+	if err != nil {
+		return err
+	}
+	*g = parsed
+	return nil
+}
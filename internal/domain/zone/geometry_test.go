@@ -0,0 +1,98 @@
+package zone
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// square is a closed ring of the square between (lng, lat) and (lng+size, lat+size).
+func square(lng, lat, size float64) string {
+	return fmt.Sprintf("[[%g,%g],[%g,%g],[%g,%g],[%g,%g],[%g,%g]]",
+		lng, lat, lng+size, lat, lng+size, lat+size, lng, lat+size, lng, lat)
+}
+
+func TestGeometry_Contains(t *testing.T) {
+	// A 10x10 square with a 2x2 hole in the middle, and a separate 1x1 island.
+	withHole := `{"type": "Polygon", "coordinates": [` + square(100, 0, 10) + `,` + square(104, 4, 2) + `]}`
+	multi := `{"type": "MultiPolygon", "coordinates": [[` + square(100, 0, 10) + `,` + square(104, 4, 2) + `], [` + square(120, 0, 1) + `]]}`
+	// An L shape: its bounding box covers the missing corner.
+	lShape := `{"type": "Polygon", "coordinates": [[[0,0],[2,0],[2,1],[1,1],[1,2],[0,2],[0,0]]]}`
+
+	tests := []struct {
+		name     string
+		geometry string
+		lat, lng float64
+		want     bool
+	}{
+		{name: "inside polygon", geometry: withHole, lat: 1, lng: 101, want: true},
+		{name: "inside hole", geometry: withHole, lat: 5, lng: 105, want: false},
+		{name: "outside bounding box", geometry: withHole, lat: 50, lng: 150, want: false},
+		{name: "first polygon of multipolygon", geometry: multi, lat: 9, lng: 109, want: true},
+		{name: "hole of first polygon", geometry: multi, lat: 5, lng: 105, want: false},
+		{name: "second polygon of multipolygon", geometry: multi, lat: 0.5, lng: 120.5, want: true},
+		{name: "between multipolygon parts", geometry: multi, lat: 0.5, lng: 115, want: false},
+		{name: "inside concave polygon", geometry: lShape, lat: 1.5, lng: 0.5, want: true},
+		{name: "missing corner of concave polygon", geometry: lShape, lat: 1.5, lng: 1.5, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseGeometry([]byte(tt.geometry))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, g.Contains(tt.lat, tt.lng))
+		})
+	}
+}
+
+func TestParseGeometry_RejectsInvalidGeometry(t *testing.T) {
+	tooMany := make([]string, maxVertices+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("[%g,0]", float64(i)/float64(maxVertices))
+	}
+	tooMany[len(tooMany)-1] = "[0,0]"
+	tooMany[len(tooMany)-2] = "[0,1]"
+
+	tests := []struct {
+		name     string
+		geometry string
+		err      string
+	}{
+		{name: "malformed", geometry: `{`, err: "not valid GeoJSON"},
+		{name: "unsupported type", geometry: `{"type": "Point", "coordinates": [0, 0]}`, err: `got "Point"`},
+		{name: "bad polygon coordinates", geometry: `{"type": "Polygon", "coordinates": [1, 2]}`, err: "invalid Polygon coordinates"},
+		{name: "bad multipolygon coordinates", geometry: `{"type": "MultiPolygon", "coordinates": "x"}`, err: "invalid MultiPolygon coordinates"},
+		{name: "no polygons", geometry: `{"type": "MultiPolygon", "coordinates": []}`, err: "has no polygons"},
+		{name: "polygon without rings", geometry: `{"type": "Polygon", "coordinates": []}`, err: "polygon 0 has no rings"},
+		{name: "ring too short", geometry: `{"type": "Polygon", "coordinates": [[[0,0],[1,0],[0,0]]]}`, err: "at least 4 positions"},
+		{name: "unclosed ring", geometry: `{"type": "Polygon", "coordinates": [[[0,0],[1,0],[1,1],[0,1]]]}`, err: "ring 0 is not closed"},
+		{name: "unclosed hole", geometry: `{"type": "Polygon", "coordinates": [` + square(0, 0, 4) + `, [[1,1],[2,1],[2,2],[1,2]]]}`, err: "ring 1 is not closed"},
+		{name: "longitude out of range", geometry: `{"type": "Polygon", "coordinates": [` + square(179, 0, 2) + `]}`, err: "out-of-range position"},
+		{name: "latitude out of range", geometry: `{"type": "Polygon", "coordinates": [` + square(0, 89, 2) + `]}`, err: "out-of-range position"},
+		{name: "too many vertices", geometry: `{"type": "Polygon", "coordinates": [[` + strings.Join(tooMany, ",") + `]]}`, err: "at most 10000 are allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGeometry([]byte(tt.geometry))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestGeometry_MarshalJSONRoundTrips(t *testing.T) {
+	for _, in := range []string{
+		`{"type":"Polygon","coordinates":[` + square(100, 0, 10) + `]}`,
+		`{"type":"MultiPolygon","coordinates":[[` + square(100, 0, 10) + `],[` + square(120, 0, 1) + `]]}`,
+	} {
+		g, err := ParseGeometry([]byte(in))
+		require.NoError(t, err)
+		out, err := g.MarshalJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, in, string(out))
+	}
+}
@@ -0,0 +1,21 @@
+package zone
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ZoneRepository defines persistence operations for service zones.
+type ZoneRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*Zone, error)
+	// FindAll returns every zone ordered by name.
+	FindAll(ctx context.Context) ([]*Zone, error)
+	// FindActive returns the active zones, oldest first.
+	FindActive(ctx context.Context) ([]*Zone, error)
+	ExistsByName(ctx context.Context, name string, excludeID uuid.UUID) (bool, error)
+	Save(ctx context.Context, zone *Zone) error
+	// Update persists changes with optimistic locking on version.
+	Update(ctx context.Context, zone *Zone) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,145 @@
+package zone
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/google/uuid"
+)
+
+// Zone is the aggregate root for an operating area. Bookings may only start, stop
+// and end inside an active zone, and each zone can carry its own pricing.
+type Zone struct {
+	id       uuid.UUID
+	name     string
+	geometry Geometry
+	// baseFareCents replaces the standard base fare for trips picked up in the zone.
+	baseFareCents *int64
+	// crossZoneSurchargeCents is added to trips between this zone and another one.
+	crossZoneSurchargeCents int64
+	active                  bool
+
+	version   int64
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewZone creates a zone. Inactive zones are kept for later but give no coverage.
+func NewZone(name string, geometry Geometry, baseFareCents *int64, crossZoneSurchargeCents int64, active bool) (*Zone, error) {
+	now := time.Now().UTC()
+	z := &Zone{
+		id:        uuid.New(),
+		active:    active,
+		version:   1,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := z.set(name, geometry, baseFareCents, crossZoneSurchargeCents); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// ReconstructZone rebuilds a Zone from persistence data (no validation).
+func ReconstructZone(
+	id uuid.UUID,
+	name string,
+	geometry Geometry,
+	baseFareCents *int64,
+	crossZoneSurchargeCents int64,
+	active bool,
+	version int64,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Zone {
+	return &Zone{
+		id:                      id,
+		name:                    name,
+		geometry:                geometry,
+		baseFareCents:           baseFareCents,
+		crossZoneSurchargeCents: crossZoneSurchargeCents,
+		active:                  active,
+		version:                 version,
+		createdAt:               createdAt,
+		updatedAt:               updatedAt,
+	}
+}
+
+// --- Getters ---
+
+// ID returns the zone's unique identifier.
+func (z *Zone) ID() uuid.UUID { return z.id }
+
+// Name returns the zone's display name.
+func (z *Zone) Name() string { return z.name }
+
+// Geometry returns the zone's polygon geometry.
+func (z *Zone) Geometry() Geometry { return z.geometry }
+
+// BaseFareCents returns the zone's base fare override, or nil for the standard fare.
+func (z *Zone) BaseFareCents() *int64 { return z.baseFareCents }
+
+// CrossZoneSurchargeCents returns the surcharge for trips leaving or entering the zone.
+func (z *Zone) CrossZoneSurchargeCents() int64 { return z.crossZoneSurchargeCents }
+
+// Active reports whether the zone currently counts as coverage.
+func (z *Zone) Active() bool { return z.active }
+
+// Version returns the entity version for optimistic locking.
+func (z *Zone) Version() int64 { return z.version }
+
+// CreatedAt returns the creation timestamp.
+func (z *Zone) CreatedAt() time.Time { return z.createdAt }
+
+// UpdatedAt returns the last-updated timestamp.
+func (z *Zone) UpdatedAt() time.Time { return z.updatedAt }
+
+// --- Behavior ---
+
+// Contains reports whether the coordinate lies inside the zone.
+func (z *Zone) Contains(lat, lng float64) bool {
+	return z.geometry.Contains(lat, lng)
+}
+
+// Update replaces the zone's definition and bumps its version.
+func (z *Zone) Update(name string, geometry Geometry, baseFareCents *int64, crossZoneSurchargeCents int64, active bool) error {
+	if err := z.set(name, geometry, baseFareCents, crossZoneSurchargeCents); err != nil {
+		return err
+	}
+	z.active = active
+	z.version++
+	z.updatedAt = time.Now().UTC()
+	return nil
+}
+
+func (z *Zone) set(name string, geometry Geometry, baseFareCents *int64, crossZoneSurchargeCents int64) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.NewValidationError("zone name is required")
+	}
+	if geometry.IsZero() {
+		return domain.NewValidationError("zone geometry is required")
+	}
+	if baseFareCents != nil && *baseFareCents <= 0 {
+		return domain.NewValidationError("base fare must be positive")
+	}
+	if crossZoneSurchargeCents < 0 {
+		return domain.NewValidationError("cross-zone surcharge cannot be negative")
+	}
+	z.name = name
+	z.geometry = geometry
+	z.baseFareCents = baseFareCents
+	z.crossZoneSurchargeCents = crossZoneSurchargeCents
+	return nil
+}
+
+// Locate returns the first zone in zones containing the coordinate, or nil.
+func Locate(zones []*Zone, lat, lng float64) *Zone {
+	for _, z := range zones {
+		if z.Contains(lat, lng) {
+			return z
+		}
+	}
+	return nil
+}
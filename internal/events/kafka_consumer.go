@@ -78,7 +78,7 @@ func (c *PaymentEventConsumer) handleEscrowReleased(ctx context.Context, cloudEv
 		zap.String("payment_id", evt.PaymentID.String()),
 	)
 
-	_, err := c.service.CompleteBooking(ctx, evt.BookingID)
+	_, err := c.service.CompleteBookingAfterEscrowRelease(ctx, evt.BookingID)
 	if err != nil {
 		c.logger.Error("failed to complete booking after escrow release",
 			zap.String("booking_id", evt.BookingID.String()),
@@ -0,0 +1,236 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/kafka"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// Runner-service event contract consumed by this service.
+const (
+	// TopicRunnerEvents is the topic service-runner publishes runner lifecycle events to.
+	TopicRunnerEvents = "runner.events"
+
+	// RunnerSuspended is emitted when an admin suspends a runner.
+	RunnerSuspended = "runner.suspended"
+	// RunnerOfflineTimeout is emitted when a runner has missed heartbeats past the
+	// runner service's grace window (prolonged offline, not a brief disconnect).
+	RunnerOfflineTimeout = "runner.offline_timeout"
+	// RunnerCapabilitiesUpdated is emitted whenever a runner's equipment,
+	// certifications or vehicle change, carrying the whole capability profile.
+	RunnerCapabilitiesUpdated = "runner.capabilities_updated"
+	// RunnerAvailabilityUpdated is emitted when a runner goes on or off duty and
+	// periodically while on duty, with their position and rating.
+	RunnerAvailabilityUpdated = "runner.availability_updated"
+)
+
+// System decline reasons recorded in booking_decline_reasons for releases
+// that were not initiated by the runner.
+const (
+	ReleaseReasonRunnerSuspended = "system_runner_suspended"
+	ReleaseReasonRunnerOffline   = "system_runner_offline"
+)
+
+// RunnerStatusEvent is the payload of runner status events.
+type RunnerStatusEvent struct {
+	RunnerID     uuid.UUID  `json:"runner_id"`
+	Reason       string     `json:"reason,omitempty"`
+	OfflineSince *time.Time `json:"offline_since,omitempty"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// RunnerCapabilitiesEvent is the payload of runner.capabilities_updated events.
+type RunnerCapabilitiesEvent struct {
+	RunnerID       uuid.UUID `json:"runner_id"`
+	CrateSizes     []string  `json:"crate_sizes"`
+	CoveredCarrier bool      `json:"covered_carrier"`
+	ClimateControl bool      `json:"climate_control"`
+	Certifications []string  `json:"certifications"`
+	MaxPetWeightKg float64   `json:"max_pet_weight_kg"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// RunnerAvailabilityEvent is the payload of runner.availability_updated events.
+type RunnerAvailabilityEvent struct {
+	RunnerID   uuid.UUID `json:"runner_id"`
+	Online     bool      `json:"online"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Rating     float64   `json:"rating"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RunnerEventConsumer listens to runner events. It releases accepted bookings held
+// by runners who can no longer fulfil them and keeps runner capability profiles and
+// positions up to date.
+type RunnerEventConsumer struct {
+	consumer     *kafka.Consumer
+	service      *application.BookingService
+	capabilities *application.RunnerCapabilityService
+	dispatch     *application.DispatchService
+	logger       *zap.Logger
+}
+
+// NewRunnerEventConsumer creates a new RunnerEventConsumer. A nil capabilities
+// service ignores capability updates; a nil dispatch service ignores availability
+// updates.
+func NewRunnerEventConsumer(
+	brokers []string,
+	groupID string,
+	service *application.BookingService,
+	capabilities *application.RunnerCapabilityService,
+	dispatch *application.DispatchService,
+	logger *zap.Logger,
+) *RunnerEventConsumer {
+	consumer := kafka.NewConsumer(brokers, groupID, TopicRunnerEvents, logger)
+	return &RunnerEventConsumer{
+		consumer:     consumer,
+		service:      service,
+		capabilities: capabilities,
+		dispatch:     dispatch,
+		logger:       logger,
+	}
+}
+
+// Start begins consuming runner events. This blocks until the context is cancelled.
+func (c *RunnerEventConsumer) Start(ctx context.Context) error {
+	return c.consumer.Consume(ctx, c.handleMessage)
+}
+
+// Close closes the underlying Kafka consumer.
+func (c *RunnerEventConsumer) Close() error {
+	return c.consumer.Close()
+}
+
+func (c *RunnerEventConsumer) handleMessage(ctx context.Context, msg kafkago.Message) error {
+	var cloudEvent kafka.CloudEvent
+	if err := json.Unmarshal(msg.Value, &cloudEvent); err != nil {
+		c.logger.Error("failed to parse cloud event from runner topic",
+			zap.Error(err),
+			zap.String("raw", string(msg.Value)),
+		)
+		return nil // Don't retry malformed messages
+	}
+
+	switch cloudEvent.Type {
+	case RunnerSuspended:
+		return c.handleRunnerUnavailable(ctx, cloudEvent, ReleaseReasonRunnerSuspended)
+	case RunnerOfflineTimeout:
+		return c.handleRunnerUnavailable(ctx, cloudEvent, ReleaseReasonRunnerOffline)
+	case RunnerCapabilitiesUpdated:
+		return c.handleCapabilitiesUpdated(ctx, cloudEvent)
+	case RunnerAvailabilityUpdated:
+		return c.handleAvailabilityUpdated(ctx, cloudEvent)
+	default:
+		c.logger.Debug("ignoring unhandled runner event type",
+			zap.String("type", cloudEvent.Type),
+		)
+		return nil
+	}
+}
+
+func (c *RunnerEventConsumer) handleRunnerUnavailable(ctx context.Context, cloudEvent kafka.CloudEvent, reason string) error {
+	var evt RunnerStatusEvent
+	if err := cloudEvent.ParseData(&evt); err != nil {
+		c.logger.Error("failed to parse RunnerStatusEvent data",
+			zap.String("type", cloudEvent.Type),
+			zap.Error(err),
+		)
+		return nil // Don't retry malformed data
+	}
+
+	c.logger.Info("processing runner unavailable event",
+		zap.String("type", cloudEvent.Type),
+		zap.String("runner_id", evt.RunnerID.String()),
+	)
+
+	released, err := c.service.ReleaseRunnerBookings(ctx, evt.RunnerID, reason)
+	if err != nil {
+		c.logger.Error("failed to release bookings from unavailable runner",
+			zap.String("runner_id", evt.RunnerID.String()),
+			zap.Int("released", released),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if released > 0 {
+		c.logger.Info("released bookings from unavailable runner",
+			zap.String("runner_id", evt.RunnerID.String()),
+			zap.Int("released", released),
+		)
+	}
+	return nil
+}
+
+func (c *RunnerEventConsumer) handleCapabilitiesUpdated(ctx context.Context, cloudEvent kafka.CloudEvent) error {
+	if c.capabilities == nil {
+		return nil
+	}
+	var evt RunnerCapabilitiesEvent
+	if err := cloudEvent.ParseData(&evt); err != nil {
+		c.logger.Error("failed to parse RunnerCapabilitiesEvent data", zap.Error(err))
+		return nil // Don't retry malformed data
+	}
+
+	err := c.capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID:       evt.RunnerID,
+		CrateSizes:     evt.CrateSizes,
+		CoveredCarrier: evt.CoveredCarrier,
+		ClimateControl: evt.ClimateControl,
+		Certifications: evt.Certifications,
+		MaxPetWeightKg: evt.MaxPetWeightKg,
+		UpdatedAt:      evt.OccurredAt,
+	})
+	var invalid *application.InvalidCapabilitiesError
+	if errors.As(err, &invalid) {
+		c.logger.Error("rejected invalid runner capabilities",
+			zap.String("runner_id", evt.RunnerID.String()),
+			zap.Error(err),
+		)
+		return nil // Don't retry a profile that can never be stored
+	}
+	if err != nil {
+		c.logger.Error("failed to update runner capabilities",
+			zap.String("runner_id", evt.RunnerID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (c *RunnerEventConsumer) handleAvailabilityUpdated(ctx context.Context, cloudEvent kafka.CloudEvent) error {
+	if c.dispatch == nil {
+		return nil
+	}
+	var evt RunnerAvailabilityEvent
+	if err := cloudEvent.ParseData(&evt); err != nil {
+		c.logger.Error("failed to parse RunnerAvailabilityEvent data", zap.Error(err))
+		return nil // Don't retry malformed data
+	}
+
+	err := c.dispatch.UpdateRunnerAvailability(ctx, application.UpdateRunnerAvailabilityRequest{
+		RunnerID:  evt.RunnerID,
+		Online:    evt.Online,
+		Latitude:  evt.Latitude,
+		Longitude: evt.Longitude,
+		Rating:    evt.Rating,
+		UpdatedAt: evt.OccurredAt,
+	})
+	if err != nil {
+		c.logger.Error("failed to update runner availability",
+			zap.String("runner_id", evt.RunnerID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
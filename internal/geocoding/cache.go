@@ -0,0 +1,92 @@
+package geocoding
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// CachingGeocoder remembers the results of another geocoder, so repeated lookups of
+// the same address (a booking's legs, a series' trips, saved addresses) hit the
+// upstream server once per TTL. "Not found" answers are cached too; other errors are not.
+type CachingGeocoder struct {
+	next Geocoder
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // most recently used first
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key       string
+	addr      dto.AddressDTO
+	err       error
+	expiresAt time.Time
+}
+
+// NewCachingGeocoder wraps next with an LRU cache of up to size addresses kept for ttl.
+func NewCachingGeocoder(next Geocoder, size int, ttl time.Duration) *CachingGeocoder {
+	return &CachingGeocoder{
+		next:    next,
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Geocode returns the cached result for the address, or asks the wrapped geocoder.
+func (g *CachingGeocoder) Geocode(ctx context.Context, addr dto.AddressDTO) (dto.AddressDTO, error) {
+	key := cacheKey(addr)
+	now := g.now()
+
+	g.mu.Lock()
+	if el, ok := g.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		if now.Before(entry.expiresAt) {
+			g.order.MoveToFront(el)
+			g.mu.Unlock()
+			return entry.addr, entry.err
+		}
+		g.order.Remove(el)
+		delete(g.entries, key)
+	}
+	g.mu.Unlock()
+
+	result, err := g.next.Geocode(ctx, addr)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return result, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if el, ok := g.entries[key]; ok {
+		// A concurrent lookup of the same address got there first.
+		g.order.Remove(el)
+		delete(g.entries, key)
+	}
+	g.entries[key] = g.order.PushFront(&cacheEntry{key: key, addr: result, err: err, expiresAt: now.Add(g.ttl)})
+	for g.order.Len() > g.size {
+		oldest := g.order.Back()
+		g.order.Remove(oldest)
+		delete(g.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return result, err
+}
+
+// cacheKey identifies an address by its normalised fields. Coordinates are left out:
+// geocoders replace them with the geocoded position.
+func cacheKey(addr dto.AddressDTO) string {
+	addr = NormalizeAddress(addr)
+	addr.Latitude, addr.Longitude = 0, 0
+	return fmt.Sprintf("%#v", addr)
+}
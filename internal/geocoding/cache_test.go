@@ -0,0 +1,113 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingGeocoder answers from results by normalised, lower-case Line1, or
+// ErrNotFound, counting calls per key.
+type countingGeocoder struct {
+	results map[string]dto.AddressDTO
+	err     error
+	calls   map[string]int
+}
+
+func (g *countingGeocoder) Geocode(_ context.Context, addr dto.AddressDTO) (dto.AddressDTO, error) {
+	key := strings.ToLower(NormalizeAddress(addr).Line1)
+	g.calls[key]++
+	if g.err != nil {
+		return dto.AddressDTO{}, g.err
+	}
+	result, ok := g.results[key]
+	if !ok {
+		return dto.AddressDTO{}, ErrNotFound
+	}
+	return result, nil
+}
+
+func newTestCache(size int) (*CachingGeocoder, *countingGeocoder, *time.Time) {
+	next := &countingGeocoder{
+		results: map[string]dto.AddressDTO{
+			"1 jalan ampang": {Line1: "1 jalan ampang", Latitude: 3.1, Longitude: 101.7},
+			"2 jalan ampang": {Line1: "2 jalan ampang", Latitude: 3.2, Longitude: 101.7},
+			"3 jalan ampang": {Line1: "3 jalan ampang", Latitude: 3.3, Longitude: 101.7},
+		},
+		calls: map[string]int{},
+	}
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewCachingGeocoder(next, size, time.Hour)
+	cache.now = func() time.Time { return clock }
+	return cache, next, &clock
+}
+
+func geocode(t *testing.T, g Geocoder, line1 string) (dto.AddressDTO, error) {
+	t.Helper()
+	return g.Geocode(context.Background(), dto.AddressDTO{Line1: line1})
+}
+
+func TestCachingGeocoder_ReusesResultsUntilTTL(t *testing.T) {
+	cache, next, clock := newTestCache(10)
+
+	first, err := geocode(t, cache, "1 Jln Ampang")
+	require.NoError(t, err)
+	// Same address after normalisation, with client coordinates: served from the cache.
+	second, err := cache.Geocode(context.Background(), dto.AddressDTO{Line1: " 1  Jalan Ampang ", Latitude: 9, Longitude: 9})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, next.calls["1 jalan ampang"])
+
+	*clock = clock.Add(59 * time.Minute)
+	_, err = geocode(t, cache, "1 Jln Ampang")
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls["1 jalan ampang"])
+
+	*clock = clock.Add(time.Minute)
+	_, err = geocode(t, cache, "1 Jln Ampang")
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls["1 jalan ampang"], "expired entries are looked up again")
+}
+
+func TestCachingGeocoder_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, next, _ := newTestCache(2)
+
+	for _, line1 := range []string{"1 jalan ampang", "2 jalan ampang", "1 jalan ampang", "3 jalan ampang"} {
+		_, err := geocode(t, cache, line1)
+		require.NoError(t, err)
+	}
+	// 2 was the least recently used when 3 came in.
+	for _, line1 := range []string{"1 jalan ampang", "3 jalan ampang", "2 jalan ampang"} {
+		_, err := geocode(t, cache, line1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"1 jalan ampang": 1, "2 jalan ampang": 2, "3 jalan ampang": 1}, next.calls)
+}
+
+func TestCachingGeocoder_CachesNotFoundButNotOtherErrors(t *testing.T) {
+	cache, next, _ := newTestCache(10)
+
+	for i := 0; i < 2; i++ {
+		_, err := geocode(t, cache, "99 nowhere")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 1, next.calls["99 nowhere"])
+
+	next.err = errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		_, err := geocode(t, cache, "2 jalan ampang")
+		assert.EqualError(t, err, "connection refused")
+	}
+	assert.Equal(t, 2, next.calls["2 jalan ampang"])
+
+	next.err = nil
+	got, err := geocode(t, cache, "2 jalan ampang")
+	require.NoError(t, err)
+	assert.Equal(t, 3.2, got.Latitude)
+}
@@ -0,0 +1,54 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// GazetteerGeocoder geocodes against a fixed list of known addresses. It needs no
+// network, which makes it suitable for tests and offline development.
+type GazetteerGeocoder struct {
+	entries map[string]dto.AddressDTO
+}
+
+// NewGazetteerGeocoder loads a gazetteer from a JSON file holding an array of
+// addresses in the AddressDTO format, each with its coordinates.
+func NewGazetteerGeocoder(path string) (*GazetteerGeocoder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gazetteer: %w", err)
+	}
+	var entries []dto.AddressDTO
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse gazetteer %s: %w", path, err)
+	}
+	return NewGazetteerGeocoderFromEntries(entries), nil
+}
+
+// NewGazetteerGeocoderFromEntries builds a gazetteer from in-memory addresses.
+// Entries are matched on line 1 and city, ignoring case, spacing and abbreviations.
+func NewGazetteerGeocoderFromEntries(entries []dto.AddressDTO) *GazetteerGeocoder {
+	g := &GazetteerGeocoder{entries: make(map[string]dto.AddressDTO, len(entries))}
+	for _, e := range entries {
+		g.entries[matchKey(e)] = NormalizeAddress(e)
+	}
+	return g
+}
+
+// Geocode returns the gazetteer entry matching the address. Line 2 is kept from the
+// request since gazetteer entries describe buildings, not units.
+func (g *GazetteerGeocoder) Geocode(_ context.Context, addr dto.AddressDTO) (dto.AddressDTO, error) {
+	entry, ok := g.entries[matchKey(addr)]
+	if !ok {
+		return dto.AddressDTO{}, ErrNotFound
+	}
+	result := entry
+	if addr.Line2 != "" {
+		result.Line2 = NormalizeAddress(addr).Line2
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+// Package geocoding resolves postal addresses to normalised addresses and coordinates.
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// ErrNotFound is returned when a geocoder has no match for an address.
+var ErrNotFound = errors.New("geocoding: address not found")
+
+// Geocoder resolves an address to its normalised form with coordinates filled in.
+type Geocoder interface {
+	// Geocode returns the normalised address, with Latitude and Longitude set to the
+	// geocoded position, or ErrNotFound.
+	Geocode(ctx context.Context, addr dto.AddressDTO) (dto.AddressDTO, error)
+}
+
+// abbreviations are expanded when addresses are normalised, so "Jln Ampang" and
+// "Jalan Ampang" geocode alike.
+var abbreviations = map[string]string{
+	"jln":  "jalan",
+	"jl":   "jalan",
+	"lrg":  "lorong",
+	"tmn":  "taman",
+	"psn":  "persiaran",
+	"bkt":  "bukit",
+	"kg":   "kampung",
+	"kpg":  "kampung",
+	"st":   "street",
+	"rd":   "road",
+	"ave":  "avenue",
+	"blvd": "boulevard",
+}
+
+// NormalizeAddress trims and collapses whitespace, expands common street
+// abbreviations and upper-cases the country code. Coordinates are left untouched.
+func NormalizeAddress(addr dto.AddressDTO) dto.AddressDTO {
+	addr.Line1 = expandAbbreviations(collapseSpaces(addr.Line1))
+	addr.Line2 = expandAbbreviations(collapseSpaces(addr.Line2))
+	addr.City = collapseSpaces(addr.City)
+	addr.State = collapseSpaces(addr.State)
+	addr.Country = strings.ToUpper(collapseSpaces(addr.Country))
+	return addr
+}
+
+// matchKey is the case-insensitive form of an address used to compare it with
+// gazetteer entries.
+func matchKey(addr dto.AddressDTO) string {
+	addr = NormalizeAddress(addr)
+	return strings.ToLower(addr.Line1 + "|" + addr.City)
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func expandAbbreviations(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		key := strings.ToLower(strings.TrimSuffix(w, "."))
+		if full, ok := abbreviations[key]; ok {
+			words[i] = matchCase(full, w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// matchCase capitalises the expansion when the abbreviation was capitalised.
+func matchCase(full, abbr string) string {
+	if abbr != "" && abbr[0] >= 'A' && abbr[0] <= 'Z' {
+		return strings.ToUpper(full[:1]) + full[1:]
+	}
+	return full
+}
@@ -0,0 +1,121 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+)
+
+// NominatimGeocoder geocodes with the search API of Nominatim or a compatible server.
+type NominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+// NewNominatimGeocoder creates a geocoder for the server at baseURL. Nominatim's usage
+// policy requires an identifying User-Agent.
+func NewNominatimGeocoder(baseURL, userAgent string, timeout time.Duration) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type nominatimPlace struct {
+	Lat     string `json:"lat"`
+	Lon     string `json:"lon"`
+	Address struct {
+		City        string `json:"city"`
+		Town        string `json:"town"`
+		Village     string `json:"village"`
+		State       string `json:"state"`
+		CountryCode string `json:"country_code"`
+	} `json:"address"`
+}
+
+// Geocode runs a structured search for the address and returns the best match. Lines
+// 1 and 2 are kept as given (normalised); missing city, state and country are filled
+// in from the match.
+func (g *NominatimGeocoder) Geocode(ctx context.Context, addr dto.AddressDTO) (dto.AddressDTO, error) {
+	addr = NormalizeAddress(addr)
+
+	q := url.Values{}
+	q.Set("format", "jsonv2")
+	q.Set("addressdetails", "1")
+	q.Set("limit", "1")
+	q.Set("street", addr.Line1)
+	if addr.City != "" {
+		q.Set("city", addr.City)
+	}
+	if addr.State != "" {
+		q.Set("state", addr.State)
+	}
+	if addr.Country != "" {
+		q.Set("countrycodes", strings.ToLower(addr.Country))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+q.Encode(), nil)
+	if err != nil {
+		return dto.AddressDTO{}, fmt.Errorf("failed to build geocoding request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return dto.AddressDTO{}, fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return dto.AddressDTO{}, fmt.Errorf("geocoding request failed: status %d", resp.StatusCode)
+	}
+
+	var places []nominatimPlace
+	if err := json.NewDecoder(resp.Body).Decode(&places); err != nil {
+		return dto.AddressDTO{}, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+	if len(places) == 0 {
+		return dto.AddressDTO{}, ErrNotFound
+	}
+
+	place := places[0]
+	lat, err := strconv.ParseFloat(place.Lat, 64)
+	if err != nil {
+		return dto.AddressDTO{}, fmt.Errorf("invalid latitude in geocoding response: %w", err)
+	}
+	lng, err := strconv.ParseFloat(place.Lon, 64)
+	if err != nil {
+		return dto.AddressDTO{}, fmt.Errorf("invalid longitude in geocoding response: %w", err)
+	}
+
+	if addr.City == "" {
+		addr.City = firstNonEmpty(place.Address.City, place.Address.Town, place.Address.Village)
+	}
+	if addr.State == "" {
+		addr.State = place.Address.State
+	}
+	if addr.Country == "" {
+		addr.Country = strings.ToUpper(place.Address.CountryCode)
+	}
+	addr.Latitude = lat
+	addr.Longitude = lng
+	return addr, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package geocoding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nominatimServer serves body with the given status for every search request and
+// records the last request.
+func nominatimServer(t *testing.T, status int, body string) (*NominatimGeocoder, *http.Request) {
+	t.Helper()
+	last := &http.Request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = *r.Clone(context.Background())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return NewNominatimGeocoder(srv.URL+"/", "kilat-test/1.0", 5*time.Second), last
+}
+
+func TestNominatimGeocoder_ParsesBestMatch(t *testing.T) {
+	g, last := nominatimServer(t, http.StatusOK, `[{
+		"lat": "3.1579", "lon": "101.7116",
+		"address": {"town": "Kuala Lumpur", "state": "Wilayah Persekutuan", "country_code": "my"}
+	}]`)
+
+	got, err := g.Geocode(context.Background(), dto.AddressDTO{Line1: " 1  Jln Ampang ", Country: "my"})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AddressDTO{
+		Line1: "1 Jalan Ampang", City: "Kuala Lumpur", State: "Wilayah Persekutuan", Country: "MY",
+		Latitude: 3.1579, Longitude: 101.7116,
+	}, got)
+
+	assert.Equal(t, "/search", last.URL.Path)
+	assert.Equal(t, "kilat-test/1.0", last.Header.Get("User-Agent"))
+	q := last.URL.Query()
+	assert.Equal(t, "1 Jalan Ampang", q.Get("street"))
+	assert.Equal(t, "my", q.Get("countrycodes"))
+	assert.Equal(t, "1", q.Get("limit"))
+	assert.False(t, q.Has("city"), "empty fields are not sent")
+}
+
+func TestNominatimGeocoder_KeepsGivenFields(t *testing.T) {
+	g, _ := nominatimServer(t, http.StatusOK, `[{"lat": "3.1", "lon": "101.6", "address": {"city": "Petaling Jaya", "state": "Selangor", "country_code": "my"}}]`)
+
+	got, err := g.Geocode(context.Background(), dto.AddressDTO{Line1: "10 Jalan Rumah", City: "KL", State: "WP", Country: "MY"})
+	require.NoError(t, err)
+	assert.Equal(t, "KL", got.City)
+	assert.Equal(t, "WP", got.State)
+	assert.Equal(t, 3.1, got.Latitude)
+}
+
+func TestNominatimGeocoder_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+		err      string
+	}{
+		{name: "no match", status: http.StatusOK, body: `[]`, notFound: true},
+		{name: "server error", status: http.StatusServiceUnavailable, body: `{}`, err: "status 503"},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{}`, err: "status 429"},
+		{name: "malformed body", status: http.StatusOK, body: `{`, err: "failed to decode"},
+		{name: "bad latitude", status: http.StatusOK, body: `[{"lat": "north", "lon": "101.6"}]`, err: "invalid latitude"},
+		{name: "bad longitude", status: http.StatusOK, body: `[{"lat": "3.1", "lon": ""}]`, err: "invalid longitude"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := nominatimServer(t, tt.status, tt.body)
+			_, err := g.Geocode(context.Background(), dto.AddressDTO{Line1: "10 Jalan Rumah"})
+			require.Error(t, err)
+			assert.Equal(t, tt.notFound, err == ErrNotFound)
+			if tt.err != "" {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
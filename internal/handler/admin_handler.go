@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,7 @@ import (
 	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
 	"github.com/Kilat-Pet-Delivery/lib-common/response"
 	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/google/uuid"
 )
 
 // AdminBookingHandler handles admin HTTP requests for booking management.
@@ -30,12 +32,40 @@ func (h *AdminBookingHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *aut
 	admin.Use(authMW, adminRole)
 	{
 		admin.GET("/bookings", h.ListBookings)
+		admin.GET("/bookings/search", h.SearchBookings)
 		admin.GET("/stats/bookings", h.BookingStats)
+
+		// Overrides for stuck bookings; every action requires a reason and is audited.
+		admin.POST("/bookings/:id/force-cancel", h.ForceCancel)
+		admin.POST("/bookings/:id/reassign", h.Reassign)
+		admin.POST("/bookings/:id/revert-to-accepted", h.RevertToAccepted)
+		admin.POST("/bookings/:id/force-complete", h.ForceComplete)
 	}
 }
 
 // ListBookings handles GET /api/v1/admin/bookings.
+// Supports the same cursor pagination and filters as the user-facing listing.
 func (h *AdminBookingHandler) ListBookings(c *gin.Context) {
+	cursor, err := usesCursorPagination(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if cursor {
+		var q application.BookingListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		result, err := h.service.ListAllBookingsByCursor(c.Request.Context(), q)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		response.Success(c, result)
+		return
+	}
+
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	if page < 1 {
@@ -54,6 +84,23 @@ func (h *AdminBookingHandler) ListBookings(c *gin.Context) {
 	response.Paginated(c, bookings, total, page, limit)
 }
 
+// SearchBookings handles GET /api/v1/admin/bookings/search.
+func (h *AdminBookingHandler) SearchBookings(c *gin.Context) {
+	var q application.BookingSearchQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.SearchBookings(c.Request.Context(), q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // BookingStats handles GET /api/v1/admin/stats/bookings.
 func (h *AdminBookingHandler) BookingStats(c *gin.Context) {
 	stats, err := h.service.GetBookingStats(c.Request.Context())
@@ -64,3 +111,109 @@ func (h *AdminBookingHandler) BookingStats(c *gin.Context) {
 
 	response.Success(c, stats)
 }
+
+// ForceCancel handles POST /api/v1/admin/bookings/:id/force-cancel.
+func (h *AdminBookingHandler) ForceCancel(c *gin.Context) {
+	adminID, bookingID, ok := adminOverrideTarget(c)
+	if !ok {
+		return
+	}
+
+	var req application.AdminOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.AdminForceCancel(c.Request.Context(), adminID, bookingID, req.Reason)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Reassign handles POST /api/v1/admin/bookings/:id/reassign.
+func (h *AdminBookingHandler) Reassign(c *gin.Context) {
+	adminID, bookingID, ok := adminOverrideTarget(c)
+	if !ok {
+		return
+	}
+
+	var req application.AdminReassignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.AdminReassign(c.Request.Context(), adminID, bookingID, req.RunnerID, req.Reason)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RevertToAccepted handles POST /api/v1/admin/bookings/:id/revert-to-accepted.
+func (h *AdminBookingHandler) RevertToAccepted(c *gin.Context) {
+	adminID, bookingID, ok := adminOverrideTarget(c)
+	if !ok {
+		return
+	}
+
+	var req application.AdminOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.AdminRevertToAccepted(c.Request.Context(), adminID, bookingID, req.Reason)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ForceComplete handles POST /api/v1/admin/bookings/:id/force-complete.
+func (h *AdminBookingHandler) ForceComplete(c *gin.Context) {
+	adminID, bookingID, ok := adminOverrideTarget(c)
+	if !ok {
+		return
+	}
+
+	var req application.AdminForceCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.AdminForceComplete(c.Request.Context(), adminID, bookingID, *req.FinalPriceCents, req.Reason)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// adminOverrideTarget extracts the acting admin and the booking ID, writing the error
+// response itself when either is missing.
+func adminOverrideTarget(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return adminID, bookingID, true
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// AdminAnalyticsHandler handles admin HTTP requests for booking analytics.
+type AdminAnalyticsHandler struct {
+	service *application.AnalyticsService
+}
+
+// NewAdminAnalyticsHandler creates a new AdminAnalyticsHandler.
+func NewAdminAnalyticsHandler(service *application.AnalyticsService) *AdminAnalyticsHandler {
+	return &AdminAnalyticsHandler{service: service}
+}
+
+// RegisterRoutes registers admin analytics routes.
+func (h *AdminAnalyticsHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	adminRole := middleware.RequireRole(auth.RoleAdmin)
+
+	analytics := r.Group("/api/v1/admin/analytics")
+	analytics.Use(authMW, adminRole)
+	{
+		analytics.GET("/bookings", h.BookingSeries)
+		analytics.GET("/declines", h.Declines)
+		analytics.GET("/runners", h.RunnerReliability)
+		analytics.GET("/runners/:id", h.GetRunnerReliability)
+	}
+}
+
+// BookingSeries handles GET /api/v1/admin/analytics/bookings.
+func (h *AdminAnalyticsHandler) BookingSeries(c *gin.Context) {
+	var q application.BookingSeriesQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetBookingSeries(c.Request.Context(), q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Declines handles GET /api/v1/admin/analytics/declines.
+func (h *AdminAnalyticsHandler) Declines(c *gin.Context) {
+	var q application.DeclineAnalyticsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetDeclineAnalytics(c.Request.Context(), q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RunnerReliability handles GET /api/v1/admin/analytics/runners.
+func (h *AdminAnalyticsHandler) RunnerReliability(c *gin.Context) {
+	var q application.DeclineAnalyticsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.ListRunnerReliability(c.Request.Context(), q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetRunnerReliability handles GET /api/v1/admin/analytics/runners/:id.
+func (h *AdminAnalyticsHandler) GetRunnerReliability(c *gin.Context) {
+	runnerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid runner ID")
+		return
+	}
+
+	var q application.DeclineAnalyticsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetRunnerReliability(c.Request.Context(), runnerID, q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -14,30 +16,43 @@ import (
 
 // BookingHandler handles HTTP requests for booking operations.
 type BookingHandler struct {
-	service *application.BookingService
+	service     *application.BookingService
+	idempotency *application.IdempotencyService
 }
 
-// NewBookingHandler creates a new BookingHandler.
-func NewBookingHandler(service *application.BookingService) *BookingHandler {
-	return &BookingHandler{service: service}
+// NewBookingHandler creates a new BookingHandler. A nil idempotency ignores
+// Idempotency-Key headers.
+func NewBookingHandler(service *application.BookingService, idempotency *application.IdempotencyService) *BookingHandler {
+	return &BookingHandler{service: service, idempotency: idempotency}
 }
 
 // RegisterRoutes registers all booking routes on the given router group.
 func (h *BookingHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
 	authMW := middleware.AuthMiddleware(jwtManager)
 
+	// Only creating, accepting and cancelling are retried by clients with an
+	// Idempotency-Key. It runs after the role check, so a 403 is not stored.
+	idempotent := func(c *gin.Context) { c.Next() }
+	if h.idempotency != nil {
+		idempotent = Idempotency(h.idempotency)
+	}
+
 	bookings := r.Group("/api/v1/bookings")
 	bookings.Use(authMW)
 	{
-		bookings.POST("", middleware.RequireRole(auth.RoleOwner), h.CreateBooking)
+		bookings.POST("", middleware.RequireRole(auth.RoleOwner), idempotent, h.CreateBooking)
 		bookings.GET("", h.ListBookings)
+		bookings.GET("/stream", h.StreamBookings)
+		bookings.GET("/available", middleware.RequireRole(auth.RoleRunner), h.ListAvailableBookings)
 		bookings.GET("/:id", h.GetBooking)
-		bookings.POST("/:id/accept", middleware.RequireRole(auth.RoleRunner), h.AcceptBooking)
+		bookings.GET("/:id/stream", h.StreamBooking)
+		bookings.POST("/:id/accept", middleware.RequireRole(auth.RoleRunner), idempotent, h.AcceptBooking)
 		bookings.POST("/:id/decline", middleware.RequireRole(auth.RoleRunner), h.DeclineBooking)
 		bookings.POST("/:id/pickup", middleware.RequireRole(auth.RoleRunner), h.StartDelivery)
+		bookings.POST("/:id/stops/:seq/confirm", middleware.RequireRole(auth.RoleRunner), h.ConfirmStop)
 		bookings.POST("/:id/deliver", middleware.RequireRole(auth.RoleRunner), h.ConfirmDelivery)
 		bookings.POST("/:id/confirm", middleware.RequireRole(auth.RoleOwner), h.ConfirmDeliveryByOwner)
-		bookings.POST("/:id/cancel", h.CancelBooking)
+		bookings.POST("/:id/cancel", idempotent, h.CancelBooking)
 		bookings.POST("/:id/rebook", middleware.RequireRole(auth.RoleOwner), h.RebookBooking)
 	}
 }
@@ -58,7 +73,7 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 
 	result, err := h.service.CreateBooking(c.Request.Context(), userID, req)
 	if err != nil {
-		response.Error(c, err)
+		respondCreateError(c, err)
 		return
 	}
 
@@ -79,6 +94,16 @@ func (h *BookingHandler) ListBookings(c *gin.Context) {
 		return
 	}
 
+	cursor, err := usesCursorPagination(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if cursor {
+		h.listBookingsByCursor(c, userID, role == auth.RoleRunner)
+		return
+	}
+
 	page, limit := parsePagination(c)
 
 	switch role {
@@ -109,6 +134,30 @@ func (h *BookingHandler) ListBookings(c *gin.Context) {
 	}
 }
 
+// ListAvailableBookings handles GET /api/v1/bookings/available: open bookings near
+// the runner that they can take.
+func (h *BookingHandler) ListAvailableBookings(c *gin.Context) {
+	runnerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var q application.AvailableBookingsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.ListAvailableBookings(c.Request.Context(), runnerID, q)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // GetBooking handles GET /api/v1/bookings/:id.
 func (h *BookingHandler) GetBooking(c *gin.Context) {
 	bookingID, err := uuid.Parse(c.Param("id"))
@@ -142,13 +191,35 @@ func (h *BookingHandler) AcceptBooking(c *gin.Context) {
 
 	result, err := h.service.AcceptBooking(c.Request.Context(), bookingID, runnerID)
 	if err != nil {
-		response.Error(c, err)
+		respondAcceptError(c, err)
 		return
 	}
 
 	response.Success(c, result)
 }
 
+// respondCreateError writes a failed booking creation. A pet the eligibility policy
+// turns away is a 400 carrying the blocking findings, so the client gets their codes.
+func respondCreateError(c *gin.Context, err error) {
+	var ineligible *application.IneligiblePetError
+	if errors.As(err, &ineligible) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": ineligible.Error(), "data": gin.H{"findings": ineligible.Findings}})
+		return
+	}
+	response.Error(c, err)
+}
+
+// respondAcceptError writes a failed accept. A booking another runner took first is a
+// 409 carrying the booking as it now stands, so the client can show who won.
+func respondAcceptError(c *gin.Context, err error) {
+	var taken *application.BookingTakenError
+	if errors.As(err, &taken) {
+		c.JSON(http.StatusConflict, gin.H{"success": false, "error": taken.Error(), "data": taken.Booking})
+		return
+	}
+	response.Error(c, err)
+}
+
 // StartDelivery handles POST /api/v1/bookings/:id/pickup.
 func (h *BookingHandler) StartDelivery(c *gin.Context) {
 	bookingID, err := uuid.Parse(c.Param("id"))
@@ -166,6 +237,35 @@ func (h *BookingHandler) StartDelivery(c *gin.Context) {
 	response.Success(c, result)
 }
 
+// ConfirmStop handles POST /api/v1/bookings/:id/stops/:seq/confirm (runner reached a stop).
+func (h *BookingHandler) ConfirmStop(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	sequence, err := strconv.Atoi(c.Param("seq"))
+	if err != nil {
+		response.BadRequest(c, "invalid stop sequence")
+		return
+	}
+
+	runnerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.service.ConfirmStop(c.Request.Context(), bookingID, runnerID, sequence)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // ConfirmDelivery handles POST /api/v1/bookings/:id/deliver (runner marks delivered).
 func (h *BookingHandler) ConfirmDelivery(c *gin.Context) {
 	bookingID, err := uuid.Parse(c.Param("id"))
@@ -244,13 +344,62 @@ func (h *BookingHandler) RebookBooking(c *gin.Context) {
 
 	result, err := h.service.RebookBooking(c.Request.Context(), userID, bookingID)
 	if err != nil {
-		response.Error(c, err)
+		respondCreateError(c, err)
 		return
 	}
 
 	response.Created(c, result)
 }
 
+// listBookingsByCursor serves the keyset-paginated, filterable form of ListBookings.
+func (h *BookingHandler) listBookingsByCursor(c *gin.Context, userID uuid.UUID, asRunner bool) {
+	var q application.BookingListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var (
+		result *application.BookingCursorPage
+		err    error
+	)
+	if asRunner {
+		result, err = h.service.ListRunnerBookings(c.Request.Context(), userID, q)
+	} else {
+		result, err = h.service.ListOwnerBookings(c.Request.Context(), userID, q)
+	}
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// cursorQueryParams are the query parameters that opt a list request into
+// cursor pagination.
+var cursorQueryParams = []string{
+	"cursor", "status", "from", "to", "pet_type", "scheduled", "series_id", "parent_id", "min_price_cents", "max_price_cents",
+}
+
+// usesCursorPagination reports whether a list request should be served with
+// keyset pagination. Requests without list parameters keep the offset-based
+// response so existing clients are unaffected. Offset pagination cannot filter, so a
+// request combining "page" with a cursor parameter is an error rather than being
+// answered with unfiltered data.
+func usesCursorPagination(c *gin.Context) (bool, error) {
+	_, paged := c.GetQuery("page")
+	for _, key := range cursorQueryParams {
+		if _, ok := c.GetQuery(key); ok {
+			if paged {
+				return false, fmt.Errorf("%s cannot be combined with page; drop page to use cursor pagination", key)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // parsePagination extracts page and limit query parameters with defaults.
 func parsePagination(c *gin.Context) (int, int) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StreamBookings handles GET /api/v1/bookings/stream.
+// Pushes status changes, runner assignments and photo uploads for every booking the
+// caller owns or runs as Server-Sent Events.
+func (h *BookingHandler) StreamBookings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.streamUpdates(c, userID, nil)
+}
+
+// StreamBooking handles GET /api/v1/bookings/:id/stream.
+// Only the booking's owner and assigned runner may subscribe.
+func (h *BookingHandler) StreamBooking(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.streamUpdates(c, userID, &bookingID)
+}
+
+func (h *BookingHandler) streamUpdates(c *gin.Context, userID uuid.UUID, bookingID *uuid.UUID) {
+	updates, unsubscribe, err := h.service.SubscribeUpdates(c.Request.Context(), userID, bookingID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	prepareEventStream(c)
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case u, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(u.Type), u)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().UTC())
+			return true
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// exportWriteWindow is how far the write deadline is pushed ahead after each flush.
+// Large exports outlive the server's WriteTimeout; a stalled client still times out.
+const exportWriteWindow = 30 * time.Second
+
+// exportFlushEvery is how many rows are buffered between flushes.
+const exportFlushEvery = 500
+
+// bookingExportHeader is the CSV header row, in BookingExportDTO field order.
+var bookingExportHeader = []string{
+	"id", "booking_number", "status", "owner_id", "runner_id", "pet_type",
+	"estimated_price_cents", "final_price_cents", "currency",
+	"created_at", "scheduled_at", "picked_up_at", "delivered_at", "completed_at", "cancelled_at", "updated_at",
+}
+
+// AdminExportHandler handles admin bulk export requests.
+type AdminExportHandler struct {
+	service *application.ExportService
+	logger  *zap.Logger
+}
+
+// NewAdminExportHandler creates a new AdminExportHandler.
+func NewAdminExportHandler(service *application.ExportService, logger *zap.Logger) *AdminExportHandler {
+	return &AdminExportHandler{service: service, logger: logger}
+}
+
+// RegisterRoutes registers admin export routes.
+func (h *AdminExportHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	adminRole := middleware.RequireRole(auth.RoleAdmin)
+
+	admin := r.Group("/api/v1/admin")
+	admin.Use(authMW, adminRole)
+	{
+		admin.GET("/bookings/export", h.ExportBookings)
+	}
+}
+
+// ExportBookings handles GET /api/v1/admin/bookings/export.
+// Streams CSV (default) or NDJSON; failures after the first byte end the stream early.
+func (h *AdminExportHandler) ExportBookings(c *gin.Context) {
+	var q application.BookingExportQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if err := h.service.ValidateExport(q); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	format := q.Format
+	if format == "" {
+		format = application.ExportFormatCSV
+	}
+
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		_ = rc.SetWriteDeadline(time.Now().Add(exportWriteWindow))
+	}
+	extendDeadline()
+
+	filename := fmt.Sprintf("bookings-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	var write func(application.BookingExportDTO) error
+	var flush func() error
+	switch format {
+	case application.ExportFormatNDJSON:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		enc := json.NewEncoder(c.Writer)
+		write = func(row application.BookingExportDTO) error { return enc.Encode(row) }
+		flush = func() error { return nil }
+	default:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		cw := csv.NewWriter(c.Writer)
+		if err := cw.Write(bookingExportHeader); err != nil {
+			return
+		}
+		write = func(row application.BookingExportDTO) error { return cw.Write(bookingExportRecord(row)) }
+		flush = func() error { cw.Flush(); return cw.Error() }
+	}
+
+	rows := 0
+	err := h.service.StreamBookings(c.Request.Context(), q, func(row application.BookingExportDTO) error {
+		if err := write(row); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+			extendDeadline()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	c.Writer.Flush()
+
+	if err != nil {
+		h.logger.Error("booking export aborted",
+			zap.String("format", format),
+			zap.Int("rows_written", rows),
+			zap.Error(err),
+		)
+	}
+}
+
+// bookingExportRecord renders a booking as a CSV record matching bookingExportHeader.
+func bookingExportRecord(row application.BookingExportDTO) []string {
+	runnerID := ""
+	if row.RunnerID != nil {
+		runnerID = row.RunnerID.String()
+	}
+	finalPrice := ""
+	if row.FinalPriceCents != nil {
+		finalPrice = strconv.FormatInt(*row.FinalPriceCents, 10)
+	}
+	return []string{
+		row.ID.String(),
+		row.BookingNumber,
+		row.Status,
+		row.OwnerID.String(),
+		runnerID,
+		row.PetType,
+		strconv.FormatInt(row.EstimatedPriceCents, 10),
+		finalPrice,
+		row.Currency,
+		formatExportTime(&row.CreatedAt),
+		formatExportTime(row.ScheduledAt),
+		formatExportTime(row.PickedUpAt),
+		formatExportTime(row.DeliveredAt),
+		formatExportTime(row.CompletedAt),
+		formatExportTime(row.CancelledAt),
+		formatExportTime(&row.UpdatedAt),
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
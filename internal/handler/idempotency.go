@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client's key for a retryable request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodyBytes caps the request body read into memory for hashing.
+	maxIdempotentBodyBytes = 1 << 20
+)
+
+// Idempotency makes mutating requests that carry an Idempotency-Key safe to retry.
+// The first request with a key runs as usual and its response is stored; a retry with
+// the same method, path and body gets the stored response back, and a retry with
+// anything else is rejected with 422. Server errors and panics are not stored, so they
+// can be retried. Bodies over 1 MiB are rejected with 413. Keys are per user, so the
+// middleware must run after authentication, and after role checks so their
+// rejections are not stored.
+func Idempotency(svc *application.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.BadRequest(c, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+		userID, ok := middleware.GetUserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"success": false, "error": "request body too large"})
+			return
+		}
+		if err != nil {
+			response.BadRequest(c, "failed to read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		reservation, replay, err := svc.Begin(ctx, userID, key, requestHash(c.Request, body))
+		switch {
+		case errors.Is(err, application.ErrIdempotencyKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"success": false, "error": err.Error()})
+			return
+		case errors.Is(err, application.ErrIdempotencyKeyInFlight):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"success": false, "error": err.Error()})
+			return
+		case err != nil:
+			response.Error(c, err)
+			c.Abort()
+			return
+		case replay != nil:
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(replay.StatusCode, replay.ContentType, replay.Body)
+			c.Abort()
+			return
+		}
+
+		// Store the outcome even if the client has already gone away.
+		ctx = context.WithoutCancel(ctx)
+		finished := false
+		defer func() {
+			// The handler panicked: release the key so the client can retry.
+			if !finished {
+				svc.Abandon(ctx, reservation)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		finished = true
+		if recorder.Status() >= http.StatusInternalServerError {
+			svc.Abandon(ctx, reservation)
+			return
+		}
+		svc.Finish(ctx, reservation, application.IdempotentResponse{
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestHash identifies a request by its method, path, query and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies everything written to the response so it can be stored.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// OfferHandler handles HTTP requests for dispatcher offers.
+type OfferHandler struct {
+	service *application.DispatchService
+}
+
+// NewOfferHandler creates a new OfferHandler.
+func NewOfferHandler(service *application.DispatchService) *OfferHandler {
+	return &OfferHandler{service: service}
+}
+
+// RegisterRoutes registers the runner offer routes and the admin offer history.
+func (h *OfferHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+
+	offers := r.Group("/api/v1/offers")
+	offers.Use(authMW, middleware.RequireRole(auth.RoleRunner))
+	{
+		offers.GET("", h.ListOffers)
+		offers.POST("/:id/accept", h.AcceptOffer)
+		offers.POST("/:id/reject", h.RejectOffer)
+	}
+
+	admin := r.Group("/api/v1/admin/bookings")
+	admin.Use(authMW, middleware.RequireRole(auth.RoleAdmin))
+	{
+		admin.GET("/:id/offers", h.ListBookingOffers)
+	}
+}
+
+// ListOffers handles GET /api/v1/offers: the current runner's open offers.
+func (h *OfferHandler) ListOffers(c *gin.Context) {
+	runnerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.service.ListRunnerOffers(c.Request.Context(), runnerID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AcceptOffer handles POST /api/v1/offers/:id/accept.
+func (h *OfferHandler) AcceptOffer(c *gin.Context) {
+	runnerID, offerID, ok := offerTarget(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.AcceptOffer(c.Request.Context(), runnerID, offerID)
+	if err != nil {
+		respondAcceptError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RejectOffer handles POST /api/v1/offers/:id/reject.
+func (h *OfferHandler) RejectOffer(c *gin.Context) {
+	runnerID, offerID, ok := offerTarget(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.RejectOffer(c.Request.Context(), runnerID, offerID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListBookingOffers handles GET /api/v1/admin/bookings/:id/offers.
+func (h *OfferHandler) ListBookingOffers(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	result, err := h.service.ListBookingOffers(c.Request.Context(), bookingID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// offerTarget reads the calling runner and the offer ID, writing the error response
+// itself when either is missing.
+func offerTarget(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	runnerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	offerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid offer ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return runnerID, offerID, true
+}
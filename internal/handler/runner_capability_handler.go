@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// RunnerCapabilityHandler handles admin HTTP requests for runner capability profiles.
+type RunnerCapabilityHandler struct {
+	service *application.RunnerCapabilityService
+}
+
+// NewRunnerCapabilityHandler creates a new RunnerCapabilityHandler.
+func NewRunnerCapabilityHandler(service *application.RunnerCapabilityService) *RunnerCapabilityHandler {
+	return &RunnerCapabilityHandler{service: service}
+}
+
+// RegisterRoutes registers the runner capability admin routes.
+func (h *RunnerCapabilityHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	adminRole := middleware.RequireRole(auth.RoleAdmin)
+
+	runners := r.Group("/api/v1/admin/runners")
+	runners.Use(authMW, adminRole)
+	{
+		runners.GET("/:id/capabilities", h.GetCapabilities)
+	}
+}
+
+// GetCapabilities handles GET /api/v1/admin/runners/:id/capabilities.
+func (h *RunnerCapabilityHandler) GetCapabilities(c *gin.Context) {
+	runnerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid runner ID")
+		return
+	}
+
+	result, err := h.service.GetCapabilities(c.Request.Context(), runnerID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
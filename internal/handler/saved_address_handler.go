@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// SavedAddressHandler handles HTTP requests for owners' saved addresses.
+type SavedAddressHandler struct {
+	service *application.SavedAddressService
+}
+
+// NewSavedAddressHandler creates a new SavedAddressHandler.
+func NewSavedAddressHandler(service *application.SavedAddressService) *SavedAddressHandler {
+	return &SavedAddressHandler{service: service}
+}
+
+// RegisterRoutes registers all saved address routes.
+func (h *SavedAddressHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	ownerRole := middleware.RequireRole(auth.RoleOwner)
+
+	addresses := r.Group("/api/v1/addresses")
+	addresses.Use(authMW, ownerRole)
+	{
+		addresses.POST("", h.CreateAddress)
+		addresses.GET("", h.ListAddresses)
+		addresses.GET("/:id", h.GetAddress)
+		addresses.PUT("/:id", h.UpdateAddress)
+		addresses.DELETE("/:id", h.DeleteAddress)
+	}
+}
+
+// CreateAddress adds an address to the current owner's address book.
+func (h *SavedAddressHandler) CreateAddress(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req application.SavedAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.CreateAddress(c.Request.Context(), ownerID, req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": result})
+}
+
+// ListAddresses returns the current owner's saved addresses.
+func (h *SavedAddressHandler) ListAddresses(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.service.ListAddresses(c.Request.Context(), ownerID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAddress returns a single saved address by ID.
+func (h *SavedAddressHandler) GetAddress(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	addressID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid address ID")
+		return
+	}
+
+	result, err := h.service.GetAddress(c.Request.Context(), ownerID, addressID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// UpdateAddress replaces a saved address.
+func (h *SavedAddressHandler) UpdateAddress(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	addressID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid address ID")
+		return
+	}
+
+	var req application.SavedAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateAddress(c.Request.Context(), ownerID, addressID, req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DeleteAddress removes a saved address.
+func (h *SavedAddressHandler) DeleteAddress(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	addressID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid address ID")
+		return
+	}
+
+	if err := h.service.DeleteAddress(c.Request.Context(), ownerID, addressID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "saved address deleted"})
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// SeriesHandler handles HTTP requests for recurring booking series.
+type SeriesHandler struct {
+	service *application.SeriesService
+}
+
+// NewSeriesHandler creates a new SeriesHandler.
+func NewSeriesHandler(service *application.SeriesService) *SeriesHandler {
+	return &SeriesHandler{service: service}
+}
+
+// RegisterRoutes registers all booking series routes.
+func (h *SeriesHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	ownerRole := middleware.RequireRole(auth.RoleOwner)
+
+	series := r.Group("/api/v1/booking-series")
+	series.Use(authMW, ownerRole)
+	{
+		series.POST("", h.CreateSeries)
+		series.GET("", h.ListSeries)
+		series.GET("/:id", h.GetSeries)
+		series.POST("/:id/pause", h.PauseSeries)
+		series.POST("/:id/resume", h.ResumeSeries)
+		series.POST("/:id/skip", h.SkipSeriesDate)
+		series.POST("/:id/end", h.EndSeries)
+	}
+}
+
+// CreateSeries creates a recurring booking series and its first bookings.
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req application.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.CreateSeries(c.Request.Context(), ownerID, req)
+	if err != nil {
+		respondCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": result})
+}
+
+// ListSeries returns every series of the current owner.
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.service.ListSeries(c.Request.Context(), ownerID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetSeries returns a single series by ID.
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	h.seriesAction(c, h.service.GetSeries)
+}
+
+// PauseSeries stops generating bookings for a series.
+func (h *SeriesHandler) PauseSeries(c *gin.Context) {
+	h.seriesAction(c, h.service.PauseSeries)
+}
+
+// ResumeSeries restarts a paused series.
+func (h *SeriesHandler) ResumeSeries(c *gin.Context) {
+	h.seriesAction(c, h.service.ResumeSeries)
+}
+
+// EndSeries permanently stops a series.
+func (h *SeriesHandler) EndSeries(c *gin.Context) {
+	h.seriesAction(c, h.service.EndSeries)
+}
+
+// SkipSeriesDate leaves a single date out of a series.
+func (h *SeriesHandler) SkipSeriesDate(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid series ID")
+		return
+	}
+
+	var req application.SkipSeriesDateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.SkipSeriesDate(c.Request.Context(), ownerID, seriesID, req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// seriesAction runs a service call that takes only the owner and the series ID from the path.
+func (h *SeriesHandler) seriesAction(c *gin.Context, fn func(ctx context.Context, ownerID, seriesID uuid.UUID) (*application.SeriesDTO, error)) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid series ID")
+		return
+	}
+
+	result, err := fn(c.Request.Context(), ownerID, seriesID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// streamHeartbeatInterval keeps idle SSE connections alive through proxies.
+const streamHeartbeatInterval = 20 * time.Second
+
+// TrackingHandler handles HTTP requests for live runner location tracking.
+type TrackingHandler struct {
+	service *application.TrackingService
+}
+
+// NewTrackingHandler creates a new TrackingHandler.
+func NewTrackingHandler(service *application.TrackingService) *TrackingHandler {
+	return &TrackingHandler{service: service}
+}
+
+// RegisterRoutes registers all location tracking routes.
+func (h *TrackingHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+
+	tracking := r.Group("/api/v1/bookings")
+	tracking.Use(authMW)
+	{
+		tracking.POST("/:id/location", middleware.RequireRole(auth.RoleRunner), h.RecordLocation)
+		tracking.GET("/:id/location", h.GetLocation)
+		tracking.GET("/:id/location/history", h.GetLocationHistory)
+		tracking.GET("/:id/location/stream", h.StreamLocation)
+	}
+}
+
+// RecordLocation handles POST /api/v1/bookings/:id/location.
+func (h *TrackingHandler) RecordLocation(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	runnerID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req application.RecordLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.RecordLocation(c.Request.Context(), bookingID, runnerID, req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetLocation handles GET /api/v1/bookings/:id/location.
+func (h *TrackingHandler) GetLocation(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.service.GetLatestLocation(c.Request.Context(), bookingID, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetLocationHistory handles GET /api/v1/bookings/:id/location/history.
+func (h *TrackingHandler) GetLocationHistory(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	result, err := h.service.GetLocationHistory(c.Request.Context(), bookingID, userID, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// StreamLocation handles GET /api/v1/bookings/:id/location/stream as Server-Sent Events.
+// Each update is sent as a "location" event; a "ping" event is sent while idle.
+func (h *TrackingHandler) StreamLocation(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid booking ID")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	updates, unsubscribe, err := h.service.SubscribeLocation(c.Request.Context(), bookingID, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	prepareEventStream(c)
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case loc, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("location", loc)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().UTC())
+			return true
+		}
+	})
+}
+
+// prepareEventStream sets SSE headers and lifts the server-wide write deadline,
+// which would otherwise cut long-lived streams off after WriteTimeout.
+func prepareEventStream(c *gin.Context) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
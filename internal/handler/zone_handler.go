@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-common/middleware"
+	"github.com/Kilat-Pet-Delivery/lib-common/response"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+)
+
+// ZoneHandler handles admin HTTP requests for service zones.
+type ZoneHandler struct {
+	service *application.ServiceAreaService
+}
+
+// NewZoneHandler creates a new ZoneHandler.
+func NewZoneHandler(service *application.ServiceAreaService) *ZoneHandler {
+	return &ZoneHandler{service: service}
+}
+
+// RegisterRoutes registers the service zone admin routes.
+func (h *ZoneHandler) RegisterRoutes(r *gin.RouterGroup, jwtManager *auth.JWTManager) {
+	authMW := middleware.AuthMiddleware(jwtManager)
+	adminRole := middleware.RequireRole(auth.RoleAdmin)
+
+	zones := r.Group("/api/v1/admin/service-zones")
+	zones.Use(authMW, adminRole)
+	{
+		zones.POST("", h.CreateZone)
+		zones.GET("", h.ListZones)
+		zones.GET("/:id", h.GetZone)
+		zones.PUT("/:id", h.UpdateZone)
+		zones.DELETE("/:id", h.DeleteZone)
+	}
+}
+
+// CreateZone handles POST /api/v1/admin/service-zones.
+func (h *ZoneHandler) CreateZone(c *gin.Context) {
+	var req application.ZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.CreateZone(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": result})
+}
+
+// ListZones handles GET /api/v1/admin/service-zones.
+func (h *ZoneHandler) ListZones(c *gin.Context) {
+	result, err := h.service.ListZones(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetZone handles GET /api/v1/admin/service-zones/:id.
+func (h *ZoneHandler) GetZone(c *gin.Context) {
+	zoneID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid zone ID")
+		return
+	}
+
+	result, err := h.service.GetZone(c.Request.Context(), zoneID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// UpdateZone handles PUT /api/v1/admin/service-zones/:id.
+func (h *ZoneHandler) UpdateZone(c *gin.Context) {
+	zoneID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid zone ID")
+		return
+	}
+
+	var req application.ZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateZone(c.Request.Context(), zoneID, req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DeleteZone handles DELETE /api/v1/admin/service-zones/:id.
+func (h *ZoneHandler) DeleteZone(c *gin.Context) {
+	zoneID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid zone ID")
+		return
+	}
+
+	if err := h.service.DeleteZone(c.Request.Context(), zoneID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "service zone deleted"})
+}
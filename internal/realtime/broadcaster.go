@@ -0,0 +1,33 @@
+package realtime
+
+import "context"
+
+// Broadcaster relays booking updates between service replicas.
+type Broadcaster interface {
+	// Publish sends an update to every replica (including, possibly, this one).
+	Publish(ctx context.Context, u Update) error
+	// Listen calls handle for every update received until the context is cancelled.
+	Listen(ctx context.Context, handle func(Update)) error
+	// Close releases any resources held by the broadcaster.
+	Close() error
+}
+
+// LocalBroadcaster is a no-op Broadcaster for single-replica deployments and tests.
+type LocalBroadcaster struct{}
+
+// NewLocalBroadcaster creates a new LocalBroadcaster.
+func NewLocalBroadcaster() *LocalBroadcaster {
+	return &LocalBroadcaster{}
+}
+
+// Publish does nothing: the hub has already delivered the update locally.
+func (b *LocalBroadcaster) Publish(ctx context.Context, u Update) error { return nil }
+
+// Listen blocks until the context is cancelled.
+func (b *LocalBroadcaster) Listen(ctx context.Context, handle func(Update)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// Close does nothing.
+func (b *LocalBroadcaster) Close() error { return nil }
@@ -0,0 +1,123 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// subscriberBuffer is the per-subscriber channel size; slow readers drop updates.
+const subscriberBuffer = 16
+
+// Subscription filters which updates a subscriber receives.
+type Subscription struct {
+	UserID uuid.UUID
+	// BookingID restricts the stream to one booking; nil streams every booking the
+	// user owns or runs.
+	BookingID *uuid.UUID
+	// Locations streams runner location pings instead of booking changes.
+	Locations bool
+}
+
+func (s Subscription) matches(u Update) bool {
+	if (u.Type == UpdateLocation) != s.Locations {
+		return false
+	}
+	if s.BookingID != nil && u.BookingID != *s.BookingID {
+		return false
+	}
+	// Checked on every update, not just at subscribe time: a runner who loses the
+	// booking stops receiving it.
+	return u.VisibleTo(s.UserID)
+}
+
+// Hub is the in-process pub/sub for booking updates. Updates published on one
+// replica are delivered locally and relayed to the others via the Broadcaster.
+type Hub struct {
+	instanceID  string
+	broadcaster Broadcaster
+	logger      *zap.Logger
+
+	mu          sync.RWMutex
+	subscribers map[chan Update]Subscription
+}
+
+// NewHub creates a new Hub relaying through the given broadcaster.
+func NewHub(broadcaster Broadcaster, logger *zap.Logger) *Hub {
+	return &Hub{
+		instanceID:  uuid.New().String(),
+		broadcaster: broadcaster,
+		logger:      logger,
+		subscribers: make(map[chan Update]Subscription),
+	}
+}
+
+// Publish delivers an update to local subscribers and relays it to other replicas.
+// It never fails the caller: the state change has already been committed.
+func (h *Hub) Publish(ctx context.Context, u Update) {
+	u.Origin = h.instanceID
+	h.deliver(u)
+
+	if err := h.broadcaster.Publish(ctx, u); err != nil {
+		h.logger.Error("failed to broadcast booking update",
+			zap.String("booking_id", u.BookingID.String()),
+			zap.String("type", string(u.Type)),
+			zap.Error(err),
+		)
+	}
+}
+
+// Subscribe registers a subscriber. The returned cancel func must be called when
+// the subscriber goes away.
+func (h *Hub) Subscribe(sub Subscription) (<-chan Update, func()) {
+	ch := make(chan Update, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = sub
+	h.mu.Unlock()
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// Run relays updates from other replicas to local subscribers until the context is cancelled.
+func (h *Hub) Run(ctx context.Context) error {
+	return h.broadcaster.Listen(ctx, func(u Update) {
+		if u.Origin == h.instanceID {
+			return // already delivered locally
+		}
+		h.deliver(u)
+	})
+}
+
+// Close releases the broadcaster.
+func (h *Hub) Close() error {
+	return h.broadcaster.Close()
+}
+
+func (h *Hub) deliver(u Update) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch, sub := range h.subscribers {
+		if !sub.matches(u) {
+			continue
+		}
+		select {
+		case ch <- u:
+		default:
+			h.logger.Warn("dropping booking update for slow subscriber",
+				zap.String("booking_id", u.BookingID.String()),
+			)
+		}
+	}
+}
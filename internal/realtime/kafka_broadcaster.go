@@ -0,0 +1,88 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/kafka"
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// updateEventType is the CloudEvent type used for relayed booking updates.
+const updateEventType = "booking.realtime_update"
+
+// KafkaBroadcaster relays updates between replicas over a Kafka topic. Every replica
+// reads the topic with its own consumer group starting at the latest offset, so each
+// one sees every update published while it is running and nothing from before. The
+// group is named after the instance ID so a restarted replica reuses its group instead
+// of leaving one behind per start; groups of retired instances expire with the
+// broker's offsets.retention.minutes.
+type KafkaBroadcaster struct {
+	producer *kafka.Producer
+	reader   *kafkago.Reader
+	topic    string
+	logger   *zap.Logger
+}
+
+// NewKafkaBroadcaster creates a new KafkaBroadcaster on the given topic.
+func NewKafkaBroadcaster(brokers []string, groupPrefix, instanceID, topic string, producer *kafka.Producer, logger *zap.Logger) *KafkaBroadcaster {
+	if instanceID == "" {
+		instanceID = uuid.New().String()[:8]
+		logger.Warn("no instance ID set, using a one-off realtime consumer group", zap.String("instance_id", instanceID))
+	}
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     fmt.Sprintf("%sbooking-realtime-%s", groupPrefix, instanceID),
+		Topic:       topic,
+		MinBytes:    1,
+		MaxBytes:    1e6,
+		StartOffset: kafkago.LastOffset,
+	})
+	return &KafkaBroadcaster{
+		producer: producer,
+		reader:   reader,
+		topic:    topic,
+		logger:   logger,
+	}
+}
+
+// Publish sends the update as a CloudEvent on the relay topic.
+func (b *KafkaBroadcaster) Publish(ctx context.Context, u Update) error {
+	cloudEvent, err := kafka.NewCloudEvent("service-booking", updateEventType, u)
+	if err != nil {
+		return fmt.Errorf("failed to create booking update event: %w", err)
+	}
+	return b.producer.PublishEvent(ctx, b.topic, cloudEvent)
+}
+
+// Listen reads relayed updates until the context is cancelled.
+func (b *KafkaBroadcaster) Listen(ctx context.Context, handle func(Update)) error {
+	for {
+		msg, err := b.reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			b.logger.Error("failed to read booking update", zap.Error(err))
+			continue
+		}
+
+		cloudEvent, err := kafka.ParseCloudEvent(msg.Value)
+		if err != nil || cloudEvent.Type != updateEventType {
+			continue
+		}
+		var u Update
+		if err := cloudEvent.ParseData(&u); err != nil {
+			b.logger.Error("failed to parse booking update", zap.Error(err))
+			continue
+		}
+		handle(u)
+	}
+}
+
+// Close closes the relay reader.
+func (b *KafkaBroadcaster) Close() error {
+	return b.reader.Close()
+}
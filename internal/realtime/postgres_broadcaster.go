@@ -0,0 +1,98 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// postgresReconnectDelay is how long Listen waits before re-establishing a dropped connection.
+	postgresReconnectDelay = 2 * time.Second
+	// maxNotifyPayload stays under Postgres' 8000-byte NOTIFY limit.
+	maxNotifyPayload = 7900
+)
+
+// PostgresBroadcaster relays updates between replicas with Postgres LISTEN/NOTIFY.
+// NOTIFY goes through the shared GORM pool; LISTEN needs its own dedicated connection.
+type PostgresBroadcaster struct {
+	db      *gorm.DB
+	dsn     string
+	channel string
+	logger  *zap.Logger
+}
+
+// NewPostgresBroadcaster creates a new PostgresBroadcaster on the given channel.
+func NewPostgresBroadcaster(db *gorm.DB, dsn, channel string, logger *zap.Logger) *PostgresBroadcaster {
+	return &PostgresBroadcaster{db: db, dsn: dsn, channel: channel, logger: logger}
+}
+
+// Publish sends the update as a NOTIFY payload. Oversized updates are relayed
+// without their payload; clients refetch the booking.
+func (b *PostgresBroadcaster) Publish(ctx context.Context, u Update) error {
+	payload, err := json.Marshal(u)
+	if err != nil {
+		return fmt.Errorf("failed to marshal booking update: %w", err)
+	}
+	if len(payload) > maxNotifyPayload {
+		u.Payload = nil
+		if payload, err = json.Marshal(u); err != nil {
+			return fmt.Errorf("failed to marshal booking update: %w", err)
+		}
+	}
+	if err := b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel, string(payload)).Error; err != nil {
+		return fmt.Errorf("failed to notify booking update: %w", err)
+	}
+	return nil
+}
+
+// Listen receives notifications until the context is cancelled, reconnecting on failure.
+func (b *PostgresBroadcaster) Listen(ctx context.Context, handle func(Update)) error {
+	for {
+		err := b.listenOnce(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b.logger.Warn("postgres update listener disconnected, reconnecting", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(postgresReconnectDelay):
+		}
+	}
+}
+
+func (b *PostgresBroadcaster) listenOnce(ctx context.Context, handle func(Update)) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect listener: %w", err)
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", b.channel, err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var u Update
+		if err := json.Unmarshal([]byte(notification.Payload), &u); err != nil {
+			b.logger.Error("failed to parse booking update notification", zap.Error(err))
+			continue
+		}
+		handle(u)
+	}
+}
+
+// Close does nothing: the listener connection is closed when Listen returns.
+func (b *PostgresBroadcaster) Close() error { return nil }
@@ -0,0 +1,62 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateType identifies what changed on a booking.
+type UpdateType string
+
+const (
+	UpdateStatusChanged  UpdateType = "status_changed"
+	UpdateRunnerAssigned UpdateType = "runner_assigned"
+	UpdatePhotoUploaded  UpdateType = "photo_uploaded"
+	UpdateStopConfirmed  UpdateType = "stop_confirmed"
+	// UpdateLocation carries a runner GPS ping; only location subscriptions receive it.
+	UpdateLocation UpdateType = "location"
+)
+
+// Update is a committed booking change pushed to subscribed clients.
+type Update struct {
+	ID         uuid.UUID       `json:"id"`
+	Origin     string          `json:"origin"`
+	Type       UpdateType      `json:"type"`
+	BookingID  uuid.UUID       `json:"booking_id"`
+	OwnerID    uuid.UUID       `json:"owner_id"`
+	RunnerID   *uuid.UUID      `json:"runner_id,omitempty"`
+	Status     string          `json:"status"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// NewUpdate creates an update for a booking with an optional JSON payload.
+func NewUpdate(updateType UpdateType, bookingID, ownerID uuid.UUID, runnerID *uuid.UUID, status string, payload interface{}) (Update, error) {
+	u := Update{
+		ID:         uuid.New(),
+		Type:       updateType,
+		BookingID:  bookingID,
+		OwnerID:    ownerID,
+		RunnerID:   runnerID,
+		Status:     status,
+		OccurredAt: time.Now().UTC(),
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return Update{}, err
+		}
+		u.Payload = data
+	}
+	return u, nil
+}
+
+// VisibleTo returns true if the user is the booking's owner or assigned runner.
+func (u Update) VisibleTo(userID uuid.UUID) bool {
+	if u.OwnerID == userID {
+		return true
+	}
+	return u.RunnerID != nil && *u.RunnerID == userID
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DailyRollupModel is the GORM model for the booking_daily_rollups table.
+type DailyRollupModel struct {
+	Day             time.Time `gorm:"type:date;primaryKey"`
+	PetType         string    `gorm:"size:20;primaryKey"`
+	Created         int64     `gorm:"not null;default:0"`
+	Completed       int64     `gorm:"not null;default:0"`
+	Cancelled       int64     `gorm:"not null;default:0"`
+	GMVCents        int64     `gorm:"column:gmv_cents;not null;default:0"`
+	AcceptSeconds   float64   `gorm:"not null;default:0"`
+	AcceptCount     int64     `gorm:"not null;default:0"`
+	DeliverySeconds float64   `gorm:"not null;default:0"`
+	DeliveryCount   int64     `gorm:"not null;default:0"`
+	RefreshedAt     time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName returns the table name for the GORM model.
+func (DailyRollupModel) TableName() string {
+	return "booking_daily_rollups"
+}
+
+// SeriesFilter selects the bookings and bucket size of an analytics series.
+// Granularity is a Postgres date_trunc field: "day" or "week".
+type SeriesFilter struct {
+	From        time.Time
+	To          time.Time
+	PetType     string
+	Granularity string
+}
+
+// SeriesRow is one (bucket, pet type) cell of booking activity. Averages are carried
+// as sums and counts so rows can be merged across pet types and re-bucketed.
+type SeriesRow struct {
+	Bucket          time.Time
+	PetType         string
+	Created         int64
+	Completed       int64
+	Cancelled       int64
+	GMVCents        int64
+	AcceptSeconds   float64
+	AcceptCount     int64
+	DeliverySeconds float64
+	DeliveryCount   int64
+}
+
+// Add accumulates the metrics of o into r, leaving the bucket and pet type unchanged.
+func (r *SeriesRow) Add(o SeriesRow) {
+	r.Created += o.Created
+	r.Completed += o.Completed
+	r.Cancelled += o.Cancelled
+	r.GMVCents += o.GMVCents
+	r.AcceptSeconds += o.AcceptSeconds
+	r.AcceptCount += o.AcceptCount
+	r.DeliverySeconds += o.DeliverySeconds
+	r.DeliveryCount += o.DeliveryCount
+}
+
+// GormAnalyticsRepository runs the admin analytics queries over bookings and
+// booking_status_history, and maintains the optional daily rollup table.
+type GormAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAnalyticsRepository creates a new GormAnalyticsRepository.
+func NewGormAnalyticsRepository(db *gorm.DB) *GormAnalyticsRepository {
+	return &GormAnalyticsRepository{db: db}
+}
+
+// Live booking metric queries. Each returns (bucket, pet_type, metric...) rows for
+// events whose own timestamp falls in [from, to). Buckets are UTC.
+const (
+	petTypeExpr = `coalesce(b.pet_spec->>'pet_type', '')`
+
+	createdSeriesSQL = `
+SELECT date_trunc(@g, b.created_at AT TIME ZONE 'UTC') AS bucket, ` + petTypeExpr + ` AS pet_type,
+       count(*) AS created
+FROM bookings b
+WHERE b.created_at >= @from AND b.created_at < @to
+  AND (@pet = '' OR b.pet_spec->>'pet_type' = @pet)
+GROUP BY 1, 2`
+
+	cancelledSeriesSQL = `
+SELECT date_trunc(@g, b.cancelled_at AT TIME ZONE 'UTC') AS bucket, ` + petTypeExpr + ` AS pet_type,
+       count(*) AS cancelled
+FROM bookings b
+WHERE b.status = 'cancelled' AND b.cancelled_at >= @from AND b.cancelled_at < @to
+  AND (@pet = '' OR b.pet_spec->>'pet_type' = @pet)
+GROUP BY 1, 2`
+
+	// Completion time comes from status history; bookings completed before history
+	// was recorded fall back to updated_at.
+	completedSeriesSQL = `
+WITH c AS (
+    SELECT ` + petTypeExpr + ` AS pet_type, b.final_price_cents, b.picked_up_at, b.delivered_at,
+           coalesce((SELECT min(h.created_at) FROM booking_status_history h
+                     WHERE h.booking_id = b.id AND h.to_status = 'completed'), b.updated_at) AS completed_at
+    FROM bookings b
+    WHERE b.status = 'completed'
+      AND (@pet = '' OR b.pet_spec->>'pet_type' = @pet)
+)
+SELECT date_trunc(@g, completed_at AT TIME ZONE 'UTC') AS bucket, pet_type,
+       count(*) AS completed,
+       coalesce(sum(final_price_cents), 0) AS gmv_cents,
+       coalesce(sum(extract(epoch FROM delivered_at - picked_up_at)), 0) AS delivery_seconds,
+       count(delivered_at - picked_up_at) AS delivery_count
+FROM c
+WHERE completed_at >= @from AND completed_at < @to
+GROUP BY 1, 2`
+
+	// Time to accept is measured to the first time the booking was accepted.
+	acceptSeriesSQL = `
+WITH a AS (
+    SELECT ` + petTypeExpr + ` AS pet_type, b.created_at, min(h.created_at) AS accepted_at
+    FROM bookings b
+    JOIN booking_status_history h ON h.booking_id = b.id AND h.to_status = 'accepted'
+    WHERE (@pet = '' OR b.pet_spec->>'pet_type' = @pet)
+    GROUP BY b.id
+)
+SELECT date_trunc(@g, accepted_at AT TIME ZONE 'UTC') AS bucket, pet_type,
+       sum(extract(epoch FROM accepted_at - created_at)) AS accept_seconds,
+       count(*) AS accept_count
+FROM a
+WHERE accepted_at >= @from AND accepted_at < @to
+GROUP BY 1, 2`
+
+	rollupSeriesSQL = `
+SELECT date_trunc(@g, day::timestamp) AS bucket, pet_type,
+       sum(created) AS created, sum(completed) AS completed, sum(cancelled) AS cancelled,
+       sum(gmv_cents) AS gmv_cents,
+       sum(accept_seconds) AS accept_seconds, sum(accept_count) AS accept_count,
+       sum(delivery_seconds) AS delivery_seconds, sum(delivery_count) AS delivery_count
+FROM booking_daily_rollups
+WHERE day >= @from::date AND day < @to::date
+  AND (@pet = '' OR pet_type = @pet)
+GROUP BY 1, 2`
+)
+
+// LiveSeries computes the booking series directly from bookings and status history.
+func (r *GormAnalyticsRepository) LiveSeries(ctx context.Context, f SeriesFilter) ([]SeriesRow, error) {
+	return r.liveSeries(ctx, r.db, f)
+}
+
+func (r *GormAnalyticsRepository) liveSeries(ctx context.Context, db *gorm.DB, f SeriesFilter) ([]SeriesRow, error) {
+	args := seriesArgs(f)
+	cells := make(map[seriesKey]*SeriesRow)
+
+	for _, query := range []string{createdSeriesSQL, cancelledSeriesSQL, completedSeriesSQL, acceptSeriesSQL} {
+		var rows []SeriesRow
+		if err := db.WithContext(ctx).Raw(query, args).Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to query booking series: %w", err)
+		}
+		for _, row := range rows {
+			mergeSeriesRow(cells, row)
+		}
+	}
+
+	result := make([]SeriesRow, 0, len(cells))
+	for _, row := range cells {
+		result = append(result, *row)
+	}
+	return result, nil
+}
+
+// RollupSeries reads the booking series from the daily rollup table. From and To are
+// truncated to whole UTC days.
+func (r *GormAnalyticsRepository) RollupSeries(ctx context.Context, f SeriesFilter) ([]SeriesRow, error) {
+	var rows []SeriesRow
+	if err := r.db.WithContext(ctx).Raw(rollupSeriesSQL, seriesArgs(f)).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query booking rollups: %w", err)
+	}
+	return rows, nil
+}
+
+// rollupLockClass namespaces the rollup refresh advisory lock from any other advisory locks.
+const rollupLockClass = 4702
+
+// RefreshRollups recomputes the daily rollups for the UTC days in [from, to).
+// Days are replaced wholesale so late status changes are picked up. Only one refresh
+// runs at a time across replicas: if another holds the lock, nothing is done and
+// false is returned.
+func (r *GormAnalyticsRepository) RefreshRollups(ctx context.Context, from, to time.Time) (bool, error) {
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+
+	refreshed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?::int, 0)", rollupLockClass).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to lock booking rollups: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+		refreshed = true
+
+		rows, err := r.liveSeries(ctx, tx, SeriesFilter{From: from, To: to, Granularity: "day"})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("day >= ? AND day < ?", from, to).Delete(&DailyRollupModel{}).Error; err != nil {
+			return fmt.Errorf("failed to clear booking rollups: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		models := make([]DailyRollupModel, len(rows))
+		for i, row := range rows {
+			models[i] = DailyRollupModel{
+				Day:             row.Bucket,
+				PetType:         row.PetType,
+				Created:         row.Created,
+				Completed:       row.Completed,
+				Cancelled:       row.Cancelled,
+				GMVCents:        row.GMVCents,
+				AcceptSeconds:   row.AcceptSeconds,
+				AcceptCount:     row.AcceptCount,
+				DeliverySeconds: row.DeliverySeconds,
+				DeliveryCount:   row.DeliveryCount,
+				RefreshedAt:     now,
+			}
+		}
+		if err := tx.Create(&models).Error; err != nil {
+			return fmt.Errorf("failed to write booking rollups: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return refreshed, nil
+}
+
+// LatestRollupDay returns the most recent day in the rollup table, or nil if it is empty.
+func (r *GormAnalyticsRepository) LatestRollupDay(ctx context.Context) (*time.Time, error) {
+	var latest *time.Time
+	if err := r.db.WithContext(ctx).Model(&DailyRollupModel{}).
+		Select("max(day)").
+		Scan(&latest).Error; err != nil {
+		return nil, fmt.Errorf("failed to find latest booking rollup: %w", err)
+	}
+	return latest, nil
+}
+
+// EarliestBookingAt returns the creation time of the oldest booking, or nil if there are none.
+func (r *GormAnalyticsRepository) EarliestBookingAt(ctx context.Context) (*time.Time, error) {
+	var earliest *time.Time
+	if err := r.db.WithContext(ctx).Model(&BookingModel{}).
+		Select("min(created_at)").
+		Scan(&earliest).Error; err != nil {
+		return nil, fmt.Errorf("failed to find earliest booking: %w", err)
+	}
+	return earliest, nil
+}
+
+type seriesKey struct {
+	bucket  time.Time
+	petType string
+}
+
+func seriesArgs(f SeriesFilter) map[string]interface{} {
+	return map[string]interface{}{
+		"g":    f.Granularity,
+		"from": f.From,
+		"to":   f.To,
+		"pet":  f.PetType,
+	}
+}
+
+func mergeSeriesRow(cells map[seriesKey]*SeriesRow, row SeriesRow) {
+	key := seriesKey{bucket: row.Bucket.UTC(), petType: row.PetType}
+	cell, ok := cells[key]
+	if !ok {
+		cell = &SeriesRow{Bucket: key.bucket, PetType: key.petType}
+		cells[key] = cell
+	}
+	cell.Add(row)
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingExportRow is one booking as exported for finance reconciliation.
+type BookingExportRow struct {
+	ID                  uuid.UUID
+	BookingNumber       string
+	Status              string
+	OwnerID             uuid.UUID
+	RunnerID            *uuid.UUID
+	PetType             string
+	EstimatedPriceCents int64
+	FinalPriceCents     *int64
+	Currency            string
+	CreatedAt           time.Time
+	ScheduledAt         *time.Time
+	PickedUpAt          *time.Time
+	DeliveredAt         *time.Time
+	CompletedAt         *time.Time
+	CancelledAt         *time.Time
+	UpdatedAt           time.Time
+}
+
+// GormBookingExportRepository streams bookings for bulk export.
+type GormBookingExportRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBookingExportRepository creates a new GormBookingExportRepository.
+func NewGormBookingExportRepository(db *gorm.DB) *GormBookingExportRepository {
+	return &GormBookingExportRepository{db: db}
+}
+
+const bookingExportSelect = `
+SELECT b.id, b.booking_number, b.status, b.owner_id, b.runner_id,
+       coalesce(b.pet_spec->>'pet_type', '') AS pet_type,
+       b.estimated_price_cents, b.final_price_cents, b.currency,
+       b.created_at, b.scheduled_at, b.picked_up_at, b.delivered_at,
+       CASE WHEN b.status = 'completed' THEN
+           coalesce((SELECT min(h.created_at) FROM booking_status_history h
+                     WHERE h.booking_id = b.id AND h.to_status = 'completed'), b.updated_at)
+       END AS completed_at,
+       b.cancelled_at, b.updated_at
+FROM bookings b`
+
+// Stream reads the bookings matching the filter, oldest first, through a server-side
+// cursor in batches of batchSize and calls fn for each row. Memory use is bounded by
+// the batch size regardless of how many rows match. Returning an error from fn stops
+// the export.
+func (r *GormBookingExportRepository) Stream(ctx context.Context, f bookingDomain.ListFilter, batchSize int, fn func(BookingExportRow) error) error {
+	where, args := exportWhere(f)
+	query := bookingExportSelect + where + " ORDER BY b.created_at, b.id"
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DECLARE booking_export NO SCROLL CURSOR FOR "+query, args...).Error; err != nil {
+			return fmt.Errorf("failed to open export cursor: %w", err)
+		}
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM booking_export", batchSize)
+		for {
+			var batch []BookingExportRow
+			if err := tx.Raw(fetch).Scan(&batch).Error; err != nil {
+				return fmt.Errorf("failed to fetch export rows: %w", err)
+			}
+			for _, row := range batch {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			if len(batch) < batchSize {
+				return nil
+			}
+		}
+	}, &sql.TxOptions{ReadOnly: true})
+}
+
+// exportWhere builds the WHERE clause for the export filter. Only the status and
+// created-at range apply to exports.
+func exportWhere(f bookingDomain.ListFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, st := range f.Statuses {
+			statuses[i] = string(st)
+		}
+		conds = append(conds, "b.status IN ?")
+		args = append(args, statuses)
+	}
+	if f.CreatedFrom != nil {
+		conds = append(conds, "b.created_at >= ?")
+		args = append(args, *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		conds = append(conds, "b.created_at < ?")
+		args = append(args, *f.CreatedTo)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
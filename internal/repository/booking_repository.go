@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Kilat-Pet-Delivery/lib-common/domain"
@@ -12,6 +13,7 @@ import (
 	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BookingModel is the GORM model for the bookings table.
@@ -26,6 +28,7 @@ type BookingModel struct {
 	PickupAddress       json.RawMessage `gorm:"type:jsonb;not null"`
 	DropoffAddress      json.RawMessage `gorm:"type:jsonb;not null"`
 	RouteSpec           json.RawMessage `gorm:"type:jsonb"`
+	DeliveryProgress    json.RawMessage `gorm:"type:jsonb"`
 	EstimatedPriceCents int64           `gorm:"not null"`
 	FinalPriceCents     *int64          `gorm:""`
 	Currency            string          `gorm:"not null;size:3;default:'MYR'"`
@@ -35,6 +38,13 @@ type BookingModel struct {
 	CancelledAt         *time.Time      `gorm:""`
 	CancelNote          string          `gorm:"size:500"`
 	Notes               string          `gorm:"size:1000"`
+	SeriesID            *uuid.UUID      `gorm:"type:uuid;index"`
+	ParentID            *uuid.UUID      `gorm:"type:uuid;index"`
+	Leg                 string          `gorm:"size:10"`
+	Stops               json.RawMessage `gorm:"type:jsonb"`
+	PickupDetails       json.RawMessage `gorm:"type:jsonb"`
+	DropoffDetails      json.RawMessage `gorm:"type:jsonb"`
+	Eligibility         json.RawMessage `gorm:"type:jsonb"`
 	Version             int64           `gorm:"not null;default:1"`
 	CreatedAt           time.Time       `gorm:"not null"`
 	UpdatedAt           time.Time       `gorm:"not null"`
@@ -67,6 +77,22 @@ func (r *GormBookingRepository) FindByID(ctx context.Context, id uuid.UUID) (*bo
 	return toDomainBooking(&model)
 }
 
+// FindByIDForUpdate retrieves a booking and locks its row until the transaction ends.
+// The repository must be bound to a transaction.
+func (r *GormBookingRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*bookingDomain.Booking, error) {
+	var model BookingModel
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("Booking", id.String())
+		}
+		return nil, fmt.Errorf("failed to lock booking: %w", err)
+	}
+	return toDomainBooking(&model)
+}
+
 // FindByNumber retrieves a booking by its booking number.
 func (r *GormBookingRepository) FindByNumber(ctx context.Context, number string) (*bookingDomain.Booking, error) {
 	var model BookingModel
@@ -139,6 +165,111 @@ func (r *GormBookingRepository) FindByRunnerID(ctx context.Context, runnerID uui
 	return bookings, total, nil
 }
 
+// FindByRunnerIDAndStatus retrieves every booking assigned to a runner in the given status.
+func (r *GormBookingRepository) FindByRunnerIDAndStatus(ctx context.Context, runnerID uuid.UUID, status bookingDomain.BookingStatus) ([]*bookingDomain.Booking, error) {
+	var models []BookingModel
+	if err := r.db.WithContext(ctx).
+		Where("runner_id = ? AND status = ?", runnerID, string(status)).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find runner bookings by status: %w", err)
+	}
+
+	bookings := make([]*bookingDomain.Booking, len(models))
+	for i, m := range models {
+		bk, err := toDomainBooking(&m)
+		if err != nil {
+			return nil, err
+		}
+		bookings[i] = bk
+	}
+
+	return bookings, nil
+}
+
+// FindActiveByRunnerID retrieves the runner's accepted and in-progress bookings.
+func (r *GormBookingRepository) FindActiveByRunnerID(ctx context.Context, runnerID uuid.UUID) ([]*bookingDomain.Booking, error) {
+	var models []BookingModel
+	if err := r.db.WithContext(ctx).
+		Where("runner_id = ? AND status IN ?", runnerID,
+			[]string{string(bookingDomain.StatusAccepted), string(bookingDomain.StatusInProgress)}).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active runner bookings: %w", err)
+	}
+
+	bookings := make([]*bookingDomain.Booking, len(models))
+	for i, m := range models {
+		bk, err := toDomainBooking(&m)
+		if err != nil {
+			return nil, err
+		}
+		bookings[i] = bk
+	}
+
+	return bookings, nil
+}
+
+// runnerLockClass namespaces runner advisory locks from any other advisory locks.
+const runnerLockClass = 4701
+
+// LockRunner takes a transaction-scoped advisory lock on the runner, so concurrent
+// accepts by the same runner are checked one at a time. The repository must be bound
+// to a transaction; the lock is released when it ends.
+func (r *GormBookingRepository) LockRunner(ctx context.Context, runnerID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).
+		Exec("SELECT pg_advisory_xact_lock(?::int, hashtext(?))", runnerLockClass, runnerID.String()).Error; err != nil {
+		return fmt.Errorf("failed to lock runner: %w", err)
+	}
+	return nil
+}
+
+// FindScheduledBySeries retrieves the non-cancelled bookings of a recurring series
+// scheduled in [from, to), earliest first.
+func (r *GormBookingRepository) FindScheduledBySeries(ctx context.Context, seriesID uuid.UUID, from, to time.Time) ([]*bookingDomain.Booking, error) {
+	var models []BookingModel
+	if err := r.db.WithContext(ctx).
+		Where("series_id = ? AND status <> ? AND scheduled_at >= ? AND scheduled_at < ?",
+			seriesID, string(bookingDomain.StatusCancelled), from, to).
+		Order("scheduled_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find series bookings: %w", err)
+	}
+
+	bookings := make([]*bookingDomain.Booking, len(models))
+	for i, m := range models {
+		bk, err := toDomainBooking(&m)
+		if err != nil {
+			return nil, err
+		}
+		bookings[i] = bk
+	}
+
+	return bookings, nil
+}
+
+// FindByParentID retrieves both legs of a round trip, outbound first.
+func (r *GormBookingRepository) FindByParentID(ctx context.Context, parentID uuid.UUID) ([]*bookingDomain.Booking, error) {
+	var models []BookingModel
+	if err := r.db.WithContext(ctx).
+		Where("parent_id = ?", parentID).
+		Order("created_at ASC, leg ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find round-trip bookings: %w", err)
+	}
+
+	bookings := make([]*bookingDomain.Booking, len(models))
+	for i, m := range models {
+		bk, err := toDomainBooking(&m)
+		if err != nil {
+			return nil, err
+		}
+		bookings[i] = bk
+	}
+
+	return bookings, nil
+}
+
 // Save persists a new booking.
 func (r *GormBookingRepository) Save(ctx context.Context, bk *bookingDomain.Booking) error {
 	model, err := toBookingModel(bk)
@@ -172,6 +303,7 @@ func (r *GormBookingRepository) Update(ctx context.Context, bk *bookingDomain.Bo
 			"pickup_address":       model.PickupAddress,
 			"dropoff_address":      model.DropoffAddress,
 			"route_spec":           model.RouteSpec,
+			"delivery_progress":    model.DeliveryProgress,
 			"estimated_price_cents": model.EstimatedPriceCents,
 			"final_price_cents":    model.FinalPriceCents,
 			"currency":             model.Currency,
@@ -181,6 +313,10 @@ func (r *GormBookingRepository) Update(ctx context.Context, bk *bookingDomain.Bo
 			"cancelled_at":         model.CancelledAt,
 			"cancel_note":          model.CancelNote,
 			"notes":                model.Notes,
+			"stops":                model.Stops,
+			"pickup_details":       model.PickupDetails,
+			"dropoff_details":      model.DropoffDetails,
+			"eligibility":          model.Eligibility,
 			"version":              model.Version,
 			"updated_at":           model.UpdatedAt,
 		})
@@ -196,6 +332,27 @@ func (r *GormBookingRepository) Update(ctx context.Context, bk *bookingDomain.Bo
 	return nil
 }
 
+// UpdateProgress persists only the live delivery progress of an in-progress booking.
+// It deliberately skips optimistic locking: progress is telemetry written on every
+// location ping and must not conflict with concurrent status transitions.
+func (r *GormBookingRepository) UpdateProgress(ctx context.Context, bk *bookingDomain.Booking) error {
+	if bk.Progress() == nil {
+		return nil
+	}
+	progressJSON, err := json.Marshal(bk.Progress())
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery progress: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&BookingModel{}).
+		Where("id = ? AND status = ?", bk.ID(), string(bookingDomain.StatusInProgress)).
+		Update("delivery_progress", json.RawMessage(progressJSON)).Error; err != nil {
+		return fmt.Errorf("failed to update delivery progress: %w", err)
+	}
+	return nil
+}
+
 // ListAll retrieves all bookings with pagination (admin).
 func (r *GormBookingRepository) ListAll(ctx context.Context, page, limit int) ([]*bookingDomain.Booking, int64, error) {
 	var total int64
@@ -225,6 +382,161 @@ func (r *GormBookingRepository) ListAll(ctx context.Context, page, limit int) ([
 	return bookings, total, nil
 }
 
+// List retrieves bookings matching the query using keyset pagination on
+// (created_at, id), newest first. Unlike the offset-based finders it does not
+// count rows and is stable while new bookings are being inserted.
+func (r *GormBookingRepository) List(ctx context.Context, query bookingDomain.ListQuery) ([]*bookingDomain.Booking, error) {
+	tx := r.db.WithContext(ctx).Model(&BookingModel{})
+	if query.OwnerID != nil {
+		tx = tx.Where("owner_id = ?", *query.OwnerID)
+	}
+	if query.RunnerID != nil {
+		tx = tx.Where("runner_id = ?", *query.RunnerID)
+	}
+	tx = applyListFilter(tx, query.Filter)
+	if query.Search != "" {
+		tx = tx.Where(bookingSearchExpr+" LIKE ?", "%"+escapeLike(strings.ToLower(query.Search))+"%")
+	}
+	if query.After != nil {
+		tx = tx.Where("(created_at, id) < (?, ?)", query.After.CreatedAt, query.After.ID)
+	}
+
+	var models []BookingModel
+	if err := tx.
+		Order("created_at DESC, id DESC").
+		Limit(query.Limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bookings: %w", err)
+	}
+
+	bookings := make([]*bookingDomain.Booking, len(models))
+	for i, m := range models {
+		bk, err := toDomainBooking(&m)
+		if err != nil {
+			return nil, err
+		}
+		bookings[i] = bk
+	}
+
+	return bookings, nil
+}
+
+// Pickup coordinate expressions. They must match idx_bookings_requested_pickup
+// (migration 019) exactly for the planner to use the index.
+const (
+	pickupLatExpr = "((pickup_address->>'latitude')::double precision)"
+	pickupLngExpr = "((pickup_address->>'longitude')::double precision)"
+)
+
+// pickupDistanceExpr is the haversine distance in km from the pickup to the point
+// bound to its three parameters (latitude, latitude, longitude).
+const pickupDistanceExpr = `(6371.0 * 2 * asin(sqrt(
+        power(sin(radians(` + pickupLatExpr + ` - ?) / 2), 2) +
+        cos(radians(?)) * cos(radians(` + pickupLatExpr + `)) *
+        power(sin(radians(` + pickupLngExpr + ` - ?) / 2), 2)
+    )))`
+
+// FindRequestedNear retrieves requested bookings whose pickup is within the query
+// radius, nearest first. A bounding box on the indexed pickup coordinates narrows the
+// candidates before the exact distance is computed.
+func (r *GormBookingRepository) FindRequestedNear(ctx context.Context, query bookingDomain.NearbyQuery) ([]bookingDomain.NearbyBooking, error) {
+	minLat, maxLat, minLng, maxLng := query.BoundingBox()
+	distance := []interface{}{query.Latitude, query.Latitude, query.Longitude}
+
+	tx := r.db.WithContext(ctx).
+		Table("bookings").
+		Select("bookings.*, "+pickupDistanceExpr+" AS pickup_distance_km", distance...).
+		Where("status = ?", string(bookingDomain.StatusRequested)).
+		Where(pickupLatExpr+" BETWEEN ? AND ?", minLat, maxLat).
+		Where(pickupLngExpr+" BETWEEN ? AND ?", minLng, maxLng).
+		Where(pickupDistanceExpr+" <= ?", append(distance, query.RadiusKm)...)
+	if query.ExcludeDeclinedBy != nil {
+		tx = tx.Where(
+			"NOT EXISTS (SELECT 1 FROM booking_decline_reasons d WHERE d.booking_id = bookings.id AND d.runner_id = ?)",
+			*query.ExcludeDeclinedBy,
+		)
+	}
+
+	var rows []struct {
+		BookingModel
+		PickupDistanceKm float64
+	}
+	if err := tx.
+		Order("pickup_distance_km ASC, id ASC").
+		Limit(query.Limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find nearby bookings: %w", err)
+	}
+
+	nearby := make([]bookingDomain.NearbyBooking, len(rows))
+	for i := range rows {
+		bk, err := toDomainBooking(&rows[i].BookingModel)
+		if err != nil {
+			return nil, err
+		}
+		nearby[i] = bookingDomain.NearbyBooking{Booking: bk, PickupDistanceKm: rows[i].PickupDistanceKm}
+	}
+	return nearby, nil
+}
+
+// bookingSearchExpr is the text searched by ListQuery.Search. It must match the
+// expression of idx_bookings_search_trgm (migration 007) exactly.
+const bookingSearchExpr = `lower(
+        booking_number || ' ' ||
+        owner_id::text || ' ' ||
+        coalesce(runner_id::text, '') || ' ' ||
+        coalesce(pet_spec->>'name', '') || ' ' ||
+        coalesce(pickup_address->>'line1', '') || ' ' ||
+        coalesce(pickup_address->>'line2', '') || ' ' ||
+        coalesce(dropoff_address->>'line1', '') || ' ' ||
+        coalesce(dropoff_address->>'line2', '')
+    )`
+
+// escapeLike escapes LIKE wildcards so user input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// applyListFilter adds the WHERE clauses for a booking list filter.
+func applyListFilter(tx *gorm.DB, f bookingDomain.ListFilter) *gorm.DB {
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, st := range f.Statuses {
+			statuses[i] = string(st)
+		}
+		tx = tx.Where("status IN ?", statuses)
+	}
+	if f.CreatedFrom != nil {
+		tx = tx.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		tx = tx.Where("created_at < ?", *f.CreatedTo)
+	}
+	if f.PetType != "" {
+		tx = tx.Where("pet_spec->>'pet_type' = ?", string(f.PetType))
+	}
+	if f.Scheduled != nil {
+		if *f.Scheduled {
+			tx = tx.Where("scheduled_at IS NOT NULL")
+		} else {
+			tx = tx.Where("scheduled_at IS NULL")
+		}
+	}
+	if f.SeriesID != nil {
+		tx = tx.Where("series_id = ?", *f.SeriesID)
+	}
+	if f.ParentID != nil {
+		tx = tx.Where("parent_id = ?", *f.ParentID)
+	}
+	if f.MinPriceCents != nil {
+		tx = tx.Where("estimated_price_cents >= ?", *f.MinPriceCents)
+	}
+	if f.MaxPriceCents != nil {
+		tx = tx.Where("estimated_price_cents <= ?", *f.MaxPriceCents)
+	}
+	return tx
+}
+
 // CountByStatus returns booking counts grouped by status (admin).
 func (r *GormBookingRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
 	type statusCount struct {
@@ -278,6 +590,51 @@ func toBookingModel(bk *bookingDomain.Booking) (*BookingModel, error) {
 		routeSpecJSON = data
 	}
 
+	var progressJSON json.RawMessage
+	if bk.Progress() != nil {
+		data, err := json.Marshal(bk.Progress())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal delivery progress: %w", err)
+		}
+		progressJSON = data
+	}
+
+	var stopsJSON json.RawMessage
+	if len(bk.Stops()) > 0 {
+		data, err := json.Marshal(bk.Stops())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal stops: %w", err)
+		}
+		stopsJSON = data
+	}
+
+	var pickupDetailsJSON json.RawMessage
+	if bk.PickupDetails() != nil {
+		data, err := json.Marshal(bk.PickupDetails())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pickup details: %w", err)
+		}
+		pickupDetailsJSON = data
+	}
+
+	var dropoffDetailsJSON json.RawMessage
+	if bk.DropoffDetails() != nil {
+		data, err := json.Marshal(bk.DropoffDetails())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal dropoff details: %w", err)
+		}
+		dropoffDetailsJSON = data
+	}
+
+	var eligibilityJSON json.RawMessage
+	if bk.Eligibility() != nil {
+		data, err := json.Marshal(bk.Eligibility())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal eligibility: %w", err)
+		}
+		eligibilityJSON = data
+	}
+
 	return &BookingModel{
 		ID:                  bk.ID(),
 		BookingNumber:       bk.BookingNumber(),
@@ -289,6 +646,7 @@ func toBookingModel(bk *bookingDomain.Booking) (*BookingModel, error) {
 		PickupAddress:       pickupJSON,
 		DropoffAddress:      dropoffJSON,
 		RouteSpec:           routeSpecJSON,
+		DeliveryProgress:    progressJSON,
 		EstimatedPriceCents: bk.EstimatedPriceCents(),
 		FinalPriceCents:     bk.FinalPriceCents(),
 		Currency:            bk.Currency(),
@@ -298,6 +656,13 @@ func toBookingModel(bk *bookingDomain.Booking) (*BookingModel, error) {
 		CancelledAt:         bk.CancelledAt(),
 		CancelNote:          bk.CancelNote(),
 		Notes:               bk.Notes(),
+		SeriesID:            bk.SeriesID(),
+		ParentID:            bk.ParentID(),
+		Leg:                 string(bk.Leg()),
+		Stops:               stopsJSON,
+		PickupDetails:       pickupDetailsJSON,
+		DropoffDetails:      dropoffDetailsJSON,
+		Eligibility:         eligibilityJSON,
 		Version:             bk.Version(),
 		CreatedAt:           bk.CreatedAt(),
 		UpdatedAt:           bk.UpdatedAt(),
@@ -334,6 +699,49 @@ func toDomainBooking(m *BookingModel) (*bookingDomain.Booking, error) {
 		routeSpec = &rs
 	}
 
+	var progress *bookingDomain.DeliveryProgress
+	if len(m.DeliveryProgress) > 0 {
+		var dp bookingDomain.DeliveryProgress
+		if err := json.Unmarshal(m.DeliveryProgress, &dp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delivery progress: %w", err)
+		}
+		progress = &dp
+	}
+
+	var stops []bookingDomain.Stop
+	if len(m.Stops) > 0 {
+		if err := json.Unmarshal(m.Stops, &stops); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal stops: %w", err)
+		}
+	}
+
+	var pickupDetails *bookingDomain.AddressDetails
+	if len(m.PickupDetails) > 0 {
+		var d bookingDomain.AddressDetails
+		if err := json.Unmarshal(m.PickupDetails, &d); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pickup details: %w", err)
+		}
+		pickupDetails = &d
+	}
+
+	var dropoffDetails *bookingDomain.AddressDetails
+	if len(m.DropoffDetails) > 0 {
+		var d bookingDomain.AddressDetails
+		if err := json.Unmarshal(m.DropoffDetails, &d); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal dropoff details: %w", err)
+		}
+		dropoffDetails = &d
+	}
+
+	var eligibility *bookingDomain.EligibilityReport
+	if len(m.Eligibility) > 0 {
+		var e bookingDomain.EligibilityReport
+		if err := json.Unmarshal(m.Eligibility, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal eligibility: %w", err)
+		}
+		eligibility = &e
+	}
+
 	status, err := bookingDomain.ParseBookingStatus(m.Status)
 	if err != nil {
 		return nil, err
@@ -350,6 +758,7 @@ func toDomainBooking(m *BookingModel) (*bookingDomain.Booking, error) {
 		pickupAddress,
 		dropoffAddress,
 		routeSpec,
+		progress,
 		m.EstimatedPriceCents,
 		m.FinalPriceCents,
 		m.Currency,
@@ -359,6 +768,13 @@ func toDomainBooking(m *BookingModel) (*bookingDomain.Booking, error) {
 		m.CancelledAt,
 		m.CancelNote,
 		m.Notes,
+		m.SeriesID,
+		m.ParentID,
+		bookingDomain.TripLeg(m.Leg),
+		stops,
+		pickupDetails,
+		dropoffDetails,
+		eligibility,
 		m.Version,
 		m.CreatedAt,
 		m.UpdatedAt,
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunnerActivityRow aggregates a runner's booking activity over a time window.
+type RunnerActivityRow struct {
+	RunnerID              uuid.UUID
+	Accepted              int64
+	Declined              int64
+	Cancelled             int64
+	Completed             int64
+	AvgAcceptToDeclineSec *float64
+}
+
+// DeclineReasonCountRow is one decline reason with its frequency.
+type DeclineReasonCountRow struct {
+	Reason  string
+	Count   int64
+	Runners int64
+}
+
+// MultiDeclineBookingRow is a booking that was declined more than once.
+type MultiDeclineBookingRow struct {
+	BookingID      uuid.UUID
+	BookingNumber  string
+	Status         string
+	Declines       int64
+	Runners        int64
+	LastDeclinedAt time.Time
+}
+
+// DeclineSummaryRow holds window-wide decline totals.
+type DeclineSummaryRow struct {
+	TotalDeclines         int64
+	AvgAcceptToDeclineSec *float64
+}
+
+// runnerDeclineCond keeps the declines a runner made, leaving out the releases the
+// service records for them (suspension, going offline) under "system_" reasons.
+const runnerDeclineCond = `d.reason NOT LIKE 'system\_%'`
+
+// acceptToDeclineExpr is the seconds between a decline and the same runner's most
+// recent accept of that booking. NULL when the accept predates status history.
+const acceptToDeclineExpr = `extract(epoch FROM d.declined_at - (
+        SELECT max(h.created_at) FROM booking_status_history h
+        WHERE h.booking_id = d.booking_id AND h.to_status = 'accepted'
+          AND h.changed_by = d.runner_id AND h.created_at <= d.declined_at))`
+
+const runnerActivitySQL = `
+WITH acc AS (
+    SELECT changed_by AS runner_id, count(*) AS n
+    FROM booking_status_history
+    WHERE to_status = 'accepted' AND admin_action IS NULL AND changed_by IS NOT NULL
+      AND created_at >= @from AND created_at < @to
+    GROUP BY 1
+), dec AS (
+    SELECT d.runner_id, count(*) AS n, avg(` + acceptToDeclineExpr + `) AS accept_to_decline
+    FROM booking_decline_reasons d
+    WHERE d.declined_at >= @from AND d.declined_at < @to AND ` + runnerDeclineCond + `
+    GROUP BY 1
+), can AS (
+    SELECT h.changed_by AS runner_id, count(*) AS n
+    FROM booking_status_history h
+    JOIN bookings b ON b.id = h.booking_id
+    WHERE h.to_status = 'cancelled' AND h.admin_action IS NULL AND h.changed_by = b.runner_id
+      AND h.created_at >= @from AND h.created_at < @to
+    GROUP BY 1
+), com AS (
+    SELECT b.runner_id, count(*) AS n
+    FROM bookings b
+    WHERE b.status = 'completed' AND b.runner_id IS NOT NULL
+      AND coalesce((SELECT min(h.created_at) FROM booking_status_history h
+                    WHERE h.booking_id = b.id AND h.to_status = 'completed'), b.updated_at) >= @from
+      AND coalesce((SELECT min(h.created_at) FROM booking_status_history h
+                    WHERE h.booking_id = b.id AND h.to_status = 'completed'), b.updated_at) < @to
+    GROUP BY 1
+), runners AS (
+    SELECT runner_id FROM acc UNION SELECT runner_id FROM dec
+    UNION SELECT runner_id FROM can UNION SELECT runner_id FROM com
+)
+SELECT r.runner_id,
+       coalesce(acc.n, 0) AS accepted,
+       coalesce(dec.n, 0) AS declined,
+       coalesce(can.n, 0) AS cancelled,
+       coalesce(com.n, 0) AS completed,
+       dec.accept_to_decline AS avg_accept_to_decline_sec
+FROM runners r
+LEFT JOIN acc ON acc.runner_id = r.runner_id
+LEFT JOIN dec ON dec.runner_id = r.runner_id
+LEFT JOIN can ON can.runner_id = r.runner_id
+LEFT JOIN com ON com.runner_id = r.runner_id
+WHERE (CAST(@runner AS uuid) IS NULL OR r.runner_id = CAST(@runner AS uuid))
+ORDER BY declined DESC, r.runner_id
+LIMIT @limit`
+
+// RunnerActivity returns per-runner accept, decline, cancel and completion counts for
+// [from, to). A non-nil runnerID restricts the result to that runner.
+func (r *GormAnalyticsRepository) RunnerActivity(ctx context.Context, from, to time.Time, runnerID *uuid.UUID, limit int) ([]RunnerActivityRow, error) {
+	var rows []RunnerActivityRow
+	if err := r.db.WithContext(ctx).Raw(runnerActivitySQL, map[string]interface{}{
+		"from":   from,
+		"to":     to,
+		"runner": runnerID,
+		"limit":  limit,
+	}).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query runner activity: %w", err)
+	}
+	return rows, nil
+}
+
+// DeclineSummary returns the number of declines in [from, to) and the average time
+// from accept to decline.
+func (r *GormAnalyticsRepository) DeclineSummary(ctx context.Context, from, to time.Time) (*DeclineSummaryRow, error) {
+	var row DeclineSummaryRow
+	if err := r.db.WithContext(ctx).Raw(`
+SELECT count(*) AS total_declines, avg(`+acceptToDeclineExpr+`) AS avg_accept_to_decline_sec
+FROM booking_decline_reasons d
+WHERE d.declined_at >= ? AND d.declined_at < ? AND `+runnerDeclineCond, from, to).Scan(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to query decline summary: %w", err)
+	}
+	return &row, nil
+}
+
+// TopDeclineReasons returns the most frequent decline reasons in [from, to).
+func (r *GormAnalyticsRepository) TopDeclineReasons(ctx context.Context, from, to time.Time, limit int) ([]DeclineReasonCountRow, error) {
+	var rows []DeclineReasonCountRow
+	if err := r.db.WithContext(ctx).Table("booking_decline_reasons d").
+		Select("reason, count(*) AS count, count(DISTINCT runner_id) AS runners").
+		Where("declined_at >= ? AND declined_at < ?", from, to).
+		Where(runnerDeclineCond).
+		Group("reason").
+		Order("count DESC, reason").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query decline reasons: %w", err)
+	}
+	return rows, nil
+}
+
+// MultiDeclineBookings returns bookings declined at least minDeclines times in [from, to).
+func (r *GormAnalyticsRepository) MultiDeclineBookings(ctx context.Context, from, to time.Time, minDeclines, limit int) ([]MultiDeclineBookingRow, error) {
+	var rows []MultiDeclineBookingRow
+	if err := r.db.WithContext(ctx).Raw(`
+SELECT d.booking_id, b.booking_number, b.status,
+       count(*) AS declines, count(DISTINCT d.runner_id) AS runners, max(d.declined_at) AS last_declined_at
+FROM booking_decline_reasons d
+JOIN bookings b ON b.id = d.booking_id
+WHERE d.declined_at >= ? AND d.declined_at < ? AND `+runnerDeclineCond+`
+GROUP BY d.booking_id, b.booking_number, b.status
+HAVING count(*) >= ?
+ORDER BY declines DESC, last_declined_at DESC
+LIMIT ?`, from, to, minDeclines, limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query multi-decline bookings: %w", err)
+	}
+	return rows, nil
+}
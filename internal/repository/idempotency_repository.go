@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyModel is the GORM model for the idempotency_keys table. A row without
+// a status code is a reservation for a request that is still being handled.
+type IdempotencyKeyModel struct {
+	UserID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Key          string    `gorm:"primaryKey;size:255"`
+	RequestHash  string    `gorm:"type:char(64);not null"`
+	StatusCode   *int
+	ContentType  string    `gorm:"size:100;not null;default:''"`
+	ResponseBody []byte    `gorm:"type:bytea"`
+	CreatedAt    time.Time `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index:idx_idempotency_keys_expires_at"`
+}
+
+// TableName returns the table name for the GORM model.
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// GormIdempotencyRepository stores Idempotency-Key reservations and responses.
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIdempotencyRepository creates a new GormIdempotencyRepository.
+func NewGormIdempotencyRepository(db *gorm.DB) *GormIdempotencyRepository {
+	return &GormIdempotencyRepository{db: db}
+}
+
+// Reserve claims the user's key for a request. The claim succeeds if the key is unused,
+// expired, or reserved before staleBefore without a stored response. Otherwise it
+// returns the existing row and false.
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, userID uuid.UUID, key, requestHash string, now, expiresAt, staleBefore time.Time) (*IdempotencyKeyModel, bool, error) {
+	model := &IdempotencyKeyModel{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   expiresAt,
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"request_hash":  requestHash,
+			"status_code":   nil,
+			"content_type":  "",
+			"response_body": nil,
+			"created_at":    now,
+			"expires_at":    expiresAt,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{
+				SQL:  "idempotency_keys.expires_at <= ? OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < ?)",
+				Vars: []interface{}{now, staleBefore},
+			},
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil, true, nil
+	}
+
+	var existing IdempotencyKeyModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ?", userID, key).
+		First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Purged between the insert and the read; the caller may try again.
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+	return &existing, false, nil
+}
+
+// Complete stores the response for a key reserved with requestHash at reservedAt. It
+// returns false if the reservation is no longer there, e.g. a retry took the key over
+// after the reservation went stale.
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, userID uuid.UUID, key, requestHash string, reservedAt time.Time, statusCode int, contentType string, body []byte) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&IdempotencyKeyModel{}).
+		Where("user_id = ? AND key = ? AND request_hash = ? AND created_at = ? AND status_code IS NULL",
+			userID, key, requestHash, reservedAt).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"content_type":  contentType,
+			"response_body": body,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to store idempotent response: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release drops a reservation made with requestHash at reservedAt that has no stored
+// response, so the key can be retried. A reservation taken over since is left alone.
+func (r *GormIdempotencyRepository) Release(ctx context.Context, userID uuid.UUID, key, requestHash string, reservedAt time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ? AND request_hash = ? AND created_at = ? AND status_code IS NULL",
+			userID, key, requestHash, reservedAt).
+		Delete(&IdempotencyKeyModel{}).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys that expired before now and returns how many were removed.
+func (r *GormIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Delete(&IdempotencyKeyModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	trackingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/tracking"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LocationModel is the GORM model for the booking_locations table.
+type LocationModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BookingID  uuid.UUID `gorm:"type:uuid;not null;index:idx_booking_locations_booking_recorded,priority:1"`
+	RunnerID   uuid.UUID `gorm:"type:uuid;not null"`
+	Latitude   float64   `gorm:"type:double precision;not null"`
+	Longitude  float64   `gorm:"type:double precision;not null"`
+	AccuracyM  float64   `gorm:"type:double precision"`
+	HeadingDeg float64   `gorm:"type:double precision"`
+	SpeedKmh   float64   `gorm:"type:double precision"`
+	RecordedAt time.Time `gorm:"not null;index:idx_booking_locations_booking_recorded,priority:2;index"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName sets the table name.
+func (LocationModel) TableName() string { return "booking_locations" }
+
+// GormLocationRepository implements LocationRepository using GORM.
+type GormLocationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLocationRepository creates a new GormLocationRepository.
+func NewGormLocationRepository(db *gorm.DB) *GormLocationRepository {
+	return &GormLocationRepository{db: db}
+}
+
+// Save persists a new location ping.
+func (r *GormLocationRepository) Save(ctx context.Context, ping *trackingDomain.LocationPing) error {
+	model := toLocationModel(ping)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to save location ping: %w", err)
+	}
+	return nil
+}
+
+// FindLatest returns the most recent location ping for a booking.
+func (r *GormLocationRepository) FindLatest(ctx context.Context, bookingID uuid.UUID) (*trackingDomain.LocationPing, error) {
+	var model LocationModel
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("recorded_at DESC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("Location", bookingID.String())
+		}
+		return nil, fmt.Errorf("failed to find latest location: %w", err)
+	}
+	return toLocationDomain(&model), nil
+}
+
+// FindRecent returns up to limit of the most recent pings for a booking, newest first.
+func (r *GormLocationRepository) FindRecent(ctx context.Context, bookingID uuid.UUID, limit int) ([]*trackingDomain.LocationPing, error) {
+	var models []LocationModel
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("recorded_at DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find location history: %w", err)
+	}
+
+	pings := make([]*trackingDomain.LocationPing, len(models))
+	for i, m := range models {
+		pings[i] = toLocationDomain(&m)
+	}
+	return pings, nil
+}
+
+// DeleteRecordedBefore removes pings recorded before the cutoff (retention).
+func (r *GormLocationRepository) DeleteRecordedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("recorded_at < ?", cutoff).Delete(&LocationModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge location history: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func toLocationModel(l *trackingDomain.LocationPing) LocationModel {
+	return LocationModel{
+		ID:         l.ID(),
+		BookingID:  l.BookingID(),
+		RunnerID:   l.RunnerID(),
+		Latitude:   l.Latitude(),
+		Longitude:  l.Longitude(),
+		AccuracyM:  l.AccuracyM(),
+		HeadingDeg: l.HeadingDeg(),
+		SpeedKmh:   l.SpeedKmh(),
+		RecordedAt: l.RecordedAt(),
+		CreatedAt:  l.CreatedAt(),
+	}
+}
+
+func toLocationDomain(m *LocationModel) *trackingDomain.LocationPing {
+	return trackingDomain.Reconstruct(
+		m.ID,
+		m.BookingID,
+		m.RunnerID,
+		m.Latitude,
+		m.Longitude,
+		m.AccuracyM,
+		m.HeadingDeg,
+		m.SpeedKmh,
+		m.RecordedAt,
+		m.CreatedAt,
+	)
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	dispatchDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/dispatch"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// OfferModel is the GORM model for the booking_offers table.
+type OfferModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	BookingID   uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_booking_offers_open_booking,unique,where:state = 'offered'"`
+	RunnerID    uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_booking_offers_open_runner,unique,where:state = 'offered'"`
+	Round       int        `gorm:"not null"`
+	Score       float64    `gorm:"not null"`
+	DistanceKm  float64    `gorm:"type:decimal(8,3);not null"`
+	State       string     `gorm:"size:20;not null;index"`
+	OfferedAt   time.Time  `gorm:"not null"`
+	ExpiresAt   time.Time  `gorm:"not null"`
+	RespondedAt *time.Time `gorm:""`
+	Version     int64      `gorm:"not null;default:1"`
+}
+
+// TableName returns the table name for the GORM model.
+func (OfferModel) TableName() string {
+	return "booking_offers"
+}
+
+// DispatchAttemptModel is the GORM model for the booking_dispatch_attempts table.
+type DispatchAttemptModel struct {
+	BookingID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	AttemptedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (DispatchAttemptModel) TableName() string {
+	return "booking_dispatch_attempts"
+}
+
+// GormOfferRepository is the GORM-based implementation of OfferRepository.
+type GormOfferRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOfferRepository creates a new GormOfferRepository.
+func NewGormOfferRepository(db *gorm.DB) *GormOfferRepository {
+	return &GormOfferRepository{db: db}
+}
+
+// FindByID retrieves an offer by its unique identifier.
+func (r *GormOfferRepository) FindByID(ctx context.Context, id uuid.UUID) (*dispatchDomain.Offer, error) {
+	var model OfferModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("Offer", id.String())
+		}
+		return nil, fmt.Errorf("failed to find offer by ID: %w", err)
+	}
+	return toDomainOffer(&model), nil
+}
+
+// FindByBookingID returns every offer made for the booking, first round first.
+func (r *GormOfferRepository) FindByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*dispatchDomain.Offer, error) {
+	var models []OfferModel
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("round ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find offers by booking: %w", err)
+	}
+	return toDomainOffers(models), nil
+}
+
+// FindOpenByRunnerID returns the runner's offers still in the offered state.
+func (r *GormOfferRepository) FindOpenByRunnerID(ctx context.Context, runnerID uuid.UUID) ([]*dispatchDomain.Offer, error) {
+	var models []OfferModel
+	if err := r.db.WithContext(ctx).
+		Where("runner_id = ? AND state = ?", runnerID, string(dispatchDomain.OfferOffered)).
+		Order("offered_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find open offers by runner: %w", err)
+	}
+	return toDomainOffers(models), nil
+}
+
+// FindStale returns offered offers that have expired at now or whose booking is no
+// longer requested.
+func (r *GormOfferRepository) FindStale(ctx context.Context, now time.Time) ([]*dispatchDomain.Offer, error) {
+	var models []OfferModel
+	if err := r.db.WithContext(ctx).
+		Where("state = ?", string(dispatchDomain.OfferOffered)).
+		Where(
+			"expires_at <= ? OR NOT EXISTS (SELECT 1 FROM bookings b WHERE b.id = booking_offers.booking_id AND b.status = ?)",
+			now, string(bookingDomain.StatusRequested),
+		).
+		Order("expires_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find stale offers: %w", err)
+	}
+	return toDomainOffers(models), nil
+}
+
+// FindBookingsToDispatch returns requested bookings without an open offer that have
+// had fewer than maxRounds offers. Bookings never tried come first, then the ones
+// tried longest ago, so bookings no runner can take do not starve the rest.
+func (r *GormOfferRepository) FindBookingsToDispatch(ctx context.Context, maxRounds, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Table("bookings").
+		Joins("LEFT JOIN booking_dispatch_attempts a ON a.booking_id = bookings.id").
+		Where("status = ?", string(bookingDomain.StatusRequested)).
+		Where("NOT EXISTS (SELECT 1 FROM booking_offers o WHERE o.booking_id = bookings.id AND o.state = ?)",
+			string(dispatchDomain.OfferOffered)).
+		Where("(SELECT count(*) FROM booking_offers o WHERE o.booking_id = bookings.id) < ?", maxRounds).
+		Order("a.attempted_at ASC NULLS FIRST, bookings.created_at ASC, bookings.id ASC").
+		Limit(limit).
+		Pluck("bookings.id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find bookings to dispatch: %w", err)
+	}
+	return ids, nil
+}
+
+// MarkDispatchAttempted records that the dispatcher tried the booking at the given time.
+func (r *GormOfferRepository) MarkDispatchAttempted(ctx context.Context, bookingID uuid.UUID, at time.Time) error {
+	if err := r.db.WithContext(ctx).Exec(
+		`INSERT INTO booking_dispatch_attempts (booking_id, attempted_at) VALUES (?, ?)
+		 ON CONFLICT (booking_id) DO UPDATE SET attempted_at = EXCLUDED.attempted_at`,
+		bookingID, at).Error; err != nil {
+		return fmt.Errorf("failed to record dispatch attempt: %w", err)
+	}
+	return nil
+}
+
+// FindBusyRunnerIDs returns the runners holding an offered offer.
+func (r *GormOfferRepository) FindBusyRunnerIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&OfferModel{}).
+		Where("state = ?", string(dispatchDomain.OfferOffered)).
+		Pluck("runner_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find busy runners: %w", err)
+	}
+	return ids, nil
+}
+
+// FindDeclinedRunnerIDs returns the runners who declined the booking after accepting it.
+func (r *GormOfferRepository) FindDeclinedRunnerIDs(ctx context.Context, bookingID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&DeclineReasonModel{}).
+		Distinct("runner_id").
+		Where("booking_id = ?", bookingID).
+		Pluck("runner_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find runners who declined booking: %w", err)
+	}
+	return ids, nil
+}
+
+// CountRecentRefusals counts, per runner, declines, rejected offers and offers left to
+// time out since the given time. Offers withdrawn because the booking was taken or
+// cancelled also end expired, but before their deadline, so they are not counted.
+// Runners without refusals are left out.
+func (r *GormOfferRepository) CountRecentRefusals(ctx context.Context, runnerIDs []uuid.UUID, since time.Time) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(runnerIDs))
+	if len(runnerIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		RunnerID uuid.UUID
+		Refusals int
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT runner_id, count(*) AS refusals FROM (
+			SELECT runner_id FROM booking_decline_reasons d
+			WHERE runner_id IN ? AND declined_at >= ? AND `+runnerDeclineCond+`
+			UNION ALL
+			SELECT runner_id FROM booking_offers
+			WHERE runner_id IN ? AND responded_at >= ?
+			  AND (state = ? OR (state = ? AND responded_at >= expires_at))
+		) refusals
+		GROUP BY runner_id`,
+		runnerIDs, since,
+		runnerIDs, since, string(dispatchDomain.OfferRejected), string(dispatchDomain.OfferExpired),
+	).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count runner refusals: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.RunnerID] = row.Refusals
+	}
+	return counts, nil
+}
+
+// Save persists a new offer. The partial unique indexes on open offers reject a
+// second open offer for the booking or the runner, which is reported as false.
+func (r *GormOfferRepository) Save(ctx context.Context, o *dispatchDomain.Offer) (bool, error) {
+	if err := r.db.WithContext(ctx).Create(toOfferModel(o)).Error; err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save offer: %w", err)
+	}
+	return true, nil
+}
+
+// Update persists a state change with optimistic locking.
+func (r *GormOfferRepository) Update(ctx context.Context, o *dispatchDomain.Offer) error {
+	result := r.db.WithContext(ctx).
+		Model(&OfferModel{}).
+		Where("id = ? AND version = ?", o.ID(), o.Version()-1).
+		Updates(map[string]interface{}{
+			"state":        string(o.State()),
+			"responded_at": o.RespondedAt(),
+			"version":      o.Version(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update offer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewConflictError("offer was modified by another transaction")
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// --- Conversion Helpers ---
+
+func toOfferModel(o *dispatchDomain.Offer) *OfferModel {
+	return &OfferModel{
+		ID:          o.ID(),
+		BookingID:   o.BookingID(),
+		RunnerID:    o.RunnerID(),
+		Round:       o.Round(),
+		Score:       o.Score(),
+		DistanceKm:  o.DistanceKm(),
+		State:       string(o.State()),
+		OfferedAt:   o.OfferedAt(),
+		ExpiresAt:   o.ExpiresAt(),
+		RespondedAt: o.RespondedAt(),
+		Version:     o.Version(),
+	}
+}
+
+func toDomainOffer(m *OfferModel) *dispatchDomain.Offer {
+	return dispatchDomain.ReconstructOffer(
+		m.ID, m.BookingID, m.RunnerID,
+		m.Round,
+		m.Score, m.DistanceKm,
+		dispatchDomain.OfferState(m.State),
+		m.OfferedAt, m.ExpiresAt,
+		m.RespondedAt,
+		m.Version,
+	)
+}
+
+func toDomainOffers(models []OfferModel) []*dispatchDomain.Offer {
+	offers := make([]*dispatchDomain.Offer, len(models))
+	for i := range models {
+		offers[i] = toDomainOffer(&models[i])
+	}
+	return offers
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	dispatchDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/dispatch"
+	runnerDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/runner"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RunnerAvailabilityModel is the GORM model for the runner_availability table.
+type RunnerAvailabilityModel struct {
+	RunnerID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Online    bool      `gorm:"not null;default:false"`
+	Latitude  float64   `gorm:"not null"`
+	Longitude float64   `gorm:"not null"`
+	Rating    float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (RunnerAvailabilityModel) TableName() string {
+	return "runner_availability"
+}
+
+// GormRunnerAvailabilityRepository stores runner availability reports and locates
+// runners for the dispatcher.
+type GormRunnerAvailabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRunnerAvailabilityRepository creates a new GormRunnerAvailabilityRepository.
+func NewGormRunnerAvailabilityRepository(db *gorm.DB) *GormRunnerAvailabilityRepository {
+	return &GormRunnerAvailabilityRepository{db: db}
+}
+
+// Upsert stores the report unless the stored one is at least as recent.
+func (r *GormRunnerAvailabilityRepository) Upsert(ctx context.Context, a *runnerDomain.Availability) (bool, error) {
+	model := &RunnerAvailabilityModel{
+		RunnerID:  a.RunnerID(),
+		Online:    a.Online(),
+		Latitude:  a.Latitude(),
+		Longitude: a.Longitude(),
+		Rating:    a.Rating(),
+		UpdatedAt: a.UpdatedAt(),
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "runner_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"online", "latitude", "longitude", "rating", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "runner_availability.updated_at < EXCLUDED.updated_at"},
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to upsert runner availability: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindAvailableNear returns online runners seen since seenSince whose position lies
+// within radiusKm of the point. The bounding box is served by
+// idx_runner_availability_online_position; exact distances are left to the ranking.
+func (r *GormRunnerAvailabilityRepository) FindAvailableNear(ctx context.Context, lat, lng, radiusKm float64, seenSince time.Time) ([]dispatchDomain.Candidate, error) {
+	minLat, maxLat, minLng, maxLng := bookingDomain.NearbyQuery{
+		Latitude: lat, Longitude: lng, RadiusKm: radiusKm,
+	}.BoundingBox()
+
+	var models []RunnerAvailabilityModel
+	if err := r.db.WithContext(ctx).
+		Where("online AND updated_at >= ?", seenSince).
+		Where("latitude BETWEEN ? AND ?", minLat, maxLat).
+		Where("longitude BETWEEN ? AND ?", minLng, maxLng).
+		Order("runner_id").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find available runners: %w", err)
+	}
+
+	candidates := make([]dispatchDomain.Candidate, 0, len(models))
+	for _, m := range models {
+		if bookingDomain.HaversineDistanceKm(lat, lng, m.Latitude, m.Longitude) > radiusKm {
+			continue
+		}
+		candidates = append(candidates, dispatchDomain.Candidate{
+			RunnerID:  m.RunnerID,
+			Latitude:  m.Latitude,
+			Longitude: m.Longitude,
+			Rating:    m.Rating,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	runnerDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/runner"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RunnerCapabilityModel is the GORM model for the runner_capabilities table.
+type RunnerCapabilityModel struct {
+	RunnerID       uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	CrateSizes     json.RawMessage `gorm:"type:jsonb;not null"`
+	CoveredCarrier bool            `gorm:"not null;default:false"`
+	ClimateControl bool            `gorm:"not null;default:false"`
+	Certifications json.RawMessage `gorm:"type:jsonb;not null"`
+	MaxPetWeightKg float64         `gorm:"type:decimal(6,2);not null;default:0"`
+	UpdatedAt      time.Time       `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (RunnerCapabilityModel) TableName() string {
+	return "runner_capabilities"
+}
+
+// GormRunnerCapabilityRepository is the GORM-based implementation of CapabilitiesRepository.
+type GormRunnerCapabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRunnerCapabilityRepository creates a new GormRunnerCapabilityRepository.
+func NewGormRunnerCapabilityRepository(db *gorm.DB) *GormRunnerCapabilityRepository {
+	return &GormRunnerCapabilityRepository{db: db}
+}
+
+// FindByRunnerID retrieves a runner's capability profile, or nil if there is none.
+func (r *GormRunnerCapabilityRepository) FindByRunnerID(ctx context.Context, runnerID uuid.UUID) (*runnerDomain.Capabilities, error) {
+	var model RunnerCapabilityModel
+	if err := r.db.WithContext(ctx).Where("runner_id = ?", runnerID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find runner capabilities: %w", err)
+	}
+	return toDomainRunnerCapabilities(&model)
+}
+
+// Upsert stores the profile unless the stored one is at least as recent, so events
+// delivered out of order cannot roll a profile back.
+func (r *GormRunnerCapabilityRepository) Upsert(ctx context.Context, c *runnerDomain.Capabilities) (bool, error) {
+	model, err := toRunnerCapabilityModel(c)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert runner capabilities to model: %w", err)
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "runner_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"crate_sizes", "covered_carrier", "climate_control", "certifications", "max_pet_weight_kg", "updated_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "runner_capabilities.updated_at < EXCLUDED.updated_at"},
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to upsert runner capabilities: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// --- Conversion Helpers ---
+
+func toRunnerCapabilityModel(c *runnerDomain.Capabilities) (*RunnerCapabilityModel, error) {
+	sizesJSON, err := json.Marshal(c.CrateSizes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal crate sizes: %w", err)
+	}
+	certsJSON, err := json.Marshal(c.Certifications())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal certifications: %w", err)
+	}
+	return &RunnerCapabilityModel{
+		RunnerID:       c.RunnerID(),
+		CrateSizes:     sizesJSON,
+		CoveredCarrier: c.CoveredCarrier(),
+		ClimateControl: c.ClimateControl(),
+		Certifications: certsJSON,
+		MaxPetWeightKg: c.MaxPetWeightKg(),
+		UpdatedAt:      c.UpdatedAt(),
+	}, nil
+}
+
+func toDomainRunnerCapabilities(m *RunnerCapabilityModel) (*runnerDomain.Capabilities, error) {
+	var sizes []bookingDomain.CrateSize
+	if err := json.Unmarshal(m.CrateSizes, &sizes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal crate sizes for runner %s: %w", m.RunnerID, err)
+	}
+	var certs []string
+	if err := json.Unmarshal(m.Certifications, &certs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal certifications for runner %s: %w", m.RunnerID, err)
+	}
+	return runnerDomain.ReconstructCapabilities(
+		m.RunnerID,
+		sizes,
+		m.CoveredCarrier,
+		m.ClimateControl,
+		certs,
+		m.MaxPetWeightKg,
+		m.UpdatedAt,
+	), nil
+}
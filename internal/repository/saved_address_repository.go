@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	savedAddressDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/savedaddress"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedAddressModel is the GORM model for the saved_addresses table.
+type SavedAddressModel struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	OwnerID      uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Label        string          `gorm:"size:50;not null"`
+	Address      json.RawMessage `gorm:"type:jsonb;not null"`
+	AccessNotes  string          `gorm:"size:500;not null;default:''"`
+	ContactPhone string          `gorm:"size:20;not null;default:''"`
+	Version      int64           `gorm:"not null;default:1"`
+	CreatedAt    time.Time       `gorm:"not null"`
+	UpdatedAt    time.Time       `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (SavedAddressModel) TableName() string {
+	return "saved_addresses"
+}
+
+// GormSavedAddressRepository is the GORM-based implementation of SavedAddressRepository.
+type GormSavedAddressRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSavedAddressRepository creates a new GormSavedAddressRepository.
+func NewGormSavedAddressRepository(db *gorm.DB) *GormSavedAddressRepository {
+	return &GormSavedAddressRepository{db: db}
+}
+
+// FindByID retrieves a saved address by its unique identifier.
+func (r *GormSavedAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*savedAddressDomain.SavedAddress, error) {
+	var model SavedAddressModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("SavedAddress", id.String())
+		}
+		return nil, fmt.Errorf("failed to find saved address by ID: %w", err)
+	}
+	return toDomainSavedAddress(&model)
+}
+
+// FindByOwnerID retrieves the owner's saved addresses ordered by label.
+func (r *GormSavedAddressRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*savedAddressDomain.SavedAddress, error) {
+	var models []SavedAddressModel
+	if err := r.db.WithContext(ctx).
+		Where("owner_id = ?", ownerID).
+		Order("lower(label) ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find saved addresses by owner: %w", err)
+	}
+	list := make([]*savedAddressDomain.SavedAddress, len(models))
+	for i := range models {
+		a, err := toDomainSavedAddress(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		list[i] = a
+	}
+	return list, nil
+}
+
+// CountByOwnerID returns how many addresses the owner has saved.
+func (r *GormSavedAddressRepository) CountByOwnerID(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&SavedAddressModel{}).
+		Where("owner_id = ?", ownerID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count saved addresses: %w", err)
+	}
+	return count, nil
+}
+
+// ExistsByLabel reports whether the owner has another address with the label
+// (case-insensitive).
+func (r *GormSavedAddressRepository) ExistsByLabel(ctx context.Context, ownerID uuid.UUID, label string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&SavedAddressModel{}).
+		Where("owner_id = ? AND lower(label) = lower(?) AND id <> ?", ownerID, label, excludeID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check saved address label: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Save persists a new saved address.
+func (r *GormSavedAddressRepository) Save(ctx context.Context, a *savedAddressDomain.SavedAddress) error {
+	model, err := toSavedAddressModel(a)
+	if err != nil {
+		return fmt.Errorf("failed to convert saved address to model: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save saved address: %w", err)
+	}
+	return nil
+}
+
+// Update persists changes to a saved address with optimistic locking.
+func (r *GormSavedAddressRepository) Update(ctx context.Context, a *savedAddressDomain.SavedAddress) error {
+	model, err := toSavedAddressModel(a)
+	if err != nil {
+		return fmt.Errorf("failed to convert saved address to model: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&SavedAddressModel{}).
+		Where("id = ? AND version = ?", model.ID, a.Version()-1).
+		Updates(map[string]interface{}{
+			"label":         model.Label,
+			"address":       model.Address,
+			"access_notes":  model.AccessNotes,
+			"contact_phone": model.ContactPhone,
+			"version":       model.Version,
+			"updated_at":    model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update saved address: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewConflictError("saved address was modified by another transaction")
+	}
+	return nil
+}
+
+// Delete removes a saved address. Bookings made from it keep their snapshot.
+func (r *GormSavedAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&SavedAddressModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved address: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("SavedAddress", id.String())
+	}
+	return nil
+}
+
+// --- Conversion Helpers ---
+
+func toSavedAddressModel(a *savedAddressDomain.SavedAddress) (*SavedAddressModel, error) {
+	addressJSON, err := json.Marshal(a.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saved address: %w", err)
+	}
+	return &SavedAddressModel{
+		ID:           a.ID(),
+		OwnerID:      a.OwnerID(),
+		Label:        a.Label(),
+		Address:      addressJSON,
+		AccessNotes:  a.AccessNotes(),
+		ContactPhone: a.ContactPhone(),
+		Version:      a.Version(),
+		CreatedAt:    a.CreatedAt(),
+		UpdatedAt:    a.UpdatedAt(),
+	}, nil
+}
+
+func toDomainSavedAddress(m *SavedAddressModel) (*savedAddressDomain.SavedAddress, error) {
+	var address dto.AddressDTO
+	if err := json.Unmarshal(m.Address, &address); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal saved address %s: %w", m.ID, err)
+	}
+	return savedAddressDomain.ReconstructSavedAddress(
+		m.ID, m.OwnerID,
+		m.Label,
+		address,
+		m.AccessNotes, m.ContactPhone,
+		m.Version,
+		m.CreatedAt, m.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	seriesDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/series"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SeriesModel is the GORM model for the booking_series table.
+type SeriesModel struct {
+	ID               uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	OwnerID          uuid.UUID       `gorm:"type:uuid;index;not null"`
+	PetSpec          json.RawMessage `gorm:"type:jsonb;not null"`
+	PickupAddress    json.RawMessage `gorm:"type:jsonb;not null"`
+	DropoffAddress   json.RawMessage `gorm:"type:jsonb;not null"`
+	Notes            string          `gorm:"size:1000"`
+	RRule            string          `gorm:"column:rrule;size:255;not null"`
+	Timezone         string          `gorm:"size:64;not null"`
+	StartsAt         time.Time       `gorm:"not null"`
+	Status           string          `gorm:"size:20;not null;index"`
+	SkippedDates     json.RawMessage `gorm:"type:jsonb;not null;default:'[]'"`
+	GeneratedThrough *time.Time      `gorm:""`
+	EndedAt          *time.Time      `gorm:""`
+	Version          int64           `gorm:"not null;default:1"`
+	CreatedAt        time.Time       `gorm:"not null"`
+	UpdatedAt        time.Time       `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (SeriesModel) TableName() string {
+	return "booking_series"
+}
+
+// GormSeriesRepository is the GORM-based implementation of SeriesRepository.
+type GormSeriesRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSeriesRepository creates a new GormSeriesRepository.
+func NewGormSeriesRepository(db *gorm.DB) *GormSeriesRepository {
+	return &GormSeriesRepository{db: db}
+}
+
+// FindByID retrieves a series by its unique identifier.
+func (r *GormSeriesRepository) FindByID(ctx context.Context, id uuid.UUID) (*seriesDomain.Series, error) {
+	var model SeriesModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("BookingSeries", id.String())
+		}
+		return nil, fmt.Errorf("failed to find series by ID: %w", err)
+	}
+	return toDomainSeries(&model)
+}
+
+// FindByOwnerID retrieves every series of an owner, newest first.
+func (r *GormSeriesRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*seriesDomain.Series, error) {
+	var models []SeriesModel
+	if err := r.db.WithContext(ctx).
+		Where("owner_id = ?", ownerID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find owner series: %w", err)
+	}
+	return toDomainSeriesList(models)
+}
+
+// FindDue retrieves active series whose bookings have not been generated up to horizon.
+func (r *GormSeriesRepository) FindDue(ctx context.Context, horizon time.Time) ([]*seriesDomain.Series, error) {
+	var models []SeriesModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND (generated_through IS NULL OR generated_through < ?)",
+			string(seriesDomain.SeriesStatusActive), horizon).
+		Order("generated_through ASC NULLS FIRST").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find due series: %w", err)
+	}
+	return toDomainSeriesList(models)
+}
+
+// Save persists a new series.
+func (r *GormSeriesRepository) Save(ctx context.Context, s *seriesDomain.Series) error {
+	model, err := toSeriesModel(s)
+	if err != nil {
+		return fmt.Errorf("failed to convert series to model: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save series: %w", err)
+	}
+	return nil
+}
+
+// Update persists the mutable state of a series with optimistic locking.
+func (r *GormSeriesRepository) Update(ctx context.Context, s *seriesDomain.Series) error {
+	model, err := toSeriesModel(s)
+	if err != nil {
+		return fmt.Errorf("failed to convert series to model: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&SeriesModel{}).
+		Where("id = ? AND version = ?", model.ID, s.Version()-1).
+		Updates(map[string]interface{}{
+			"status":            model.Status,
+			"skipped_dates":     model.SkippedDates,
+			"generated_through": model.GeneratedThrough,
+			"ended_at":          model.EndedAt,
+			"version":           model.Version,
+			"updated_at":        model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update series: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewConflictError("booking series was modified by another transaction")
+	}
+	return nil
+}
+
+// --- Conversion Helpers ---
+
+func toSeriesModel(s *seriesDomain.Series) (*SeriesModel, error) {
+	petSpecJSON, err := json.Marshal(s.PetSpec())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pet spec: %w", err)
+	}
+	pickupJSON, err := json.Marshal(s.PickupAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pickup address: %w", err)
+	}
+	dropoffJSON, err := json.Marshal(s.DropoffAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dropoff address: %w", err)
+	}
+	skipped := s.SkippedDates()
+	if skipped == nil {
+		skipped = []string{}
+	}
+	skippedJSON, err := json.Marshal(skipped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal skipped dates: %w", err)
+	}
+
+	return &SeriesModel{
+		ID:               s.ID(),
+		OwnerID:          s.OwnerID(),
+		PetSpec:          petSpecJSON,
+		PickupAddress:    pickupJSON,
+		DropoffAddress:   dropoffJSON,
+		Notes:            s.Notes(),
+		RRule:            s.RRule(),
+		Timezone:         s.Timezone(),
+		StartsAt:         s.StartsAt(),
+		Status:           string(s.Status()),
+		SkippedDates:     skippedJSON,
+		GeneratedThrough: s.GeneratedThrough(),
+		EndedAt:          s.EndedAt(),
+		Version:          s.Version(),
+		CreatedAt:        s.CreatedAt(),
+		UpdatedAt:        s.UpdatedAt(),
+	}, nil
+}
+
+func toDomainSeries(m *SeriesModel) (*seriesDomain.Series, error) {
+	var petSpec dto.PetSpecDTO
+	if err := json.Unmarshal(m.PetSpec, &petSpec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pet spec: %w", err)
+	}
+	var pickupAddress dto.AddressDTO
+	if err := json.Unmarshal(m.PickupAddress, &pickupAddress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pickup address: %w", err)
+	}
+	var dropoffAddress dto.AddressDTO
+	if err := json.Unmarshal(m.DropoffAddress, &dropoffAddress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dropoff address: %w", err)
+	}
+	var skipped []string
+	if len(m.SkippedDates) > 0 {
+		if err := json.Unmarshal(m.SkippedDates, &skipped); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal skipped dates: %w", err)
+		}
+	}
+
+	return seriesDomain.ReconstructSeries(
+		m.ID,
+		m.OwnerID,
+		petSpec,
+		pickupAddress,
+		dropoffAddress,
+		m.Notes,
+		m.RRule,
+		m.Timezone,
+		m.StartsAt,
+		seriesDomain.SeriesStatus(m.Status),
+		skipped,
+		m.GeneratedThrough,
+		m.EndedAt,
+		m.Version,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+func toDomainSeriesList(models []SeriesModel) ([]*seriesDomain.Series, error) {
+	list := make([]*seriesDomain.Series, len(models))
+	for i := range models {
+		s, err := toDomainSeries(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		list[i] = s
+	}
+	return list, nil
+}
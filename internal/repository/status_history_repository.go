@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusHistoryModel is the GORM model for the booking_status_history table.
+type StatusHistoryModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	BookingID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FromStatus  string     `gorm:"size:30"`
+	ToStatus    string     `gorm:"size:30;not null"`
+	ChangedBy   *uuid.UUID `gorm:"type:uuid"`
+	Reason      string     `gorm:"size:500"`
+	AdminAction *string    `gorm:"size:40"`
+	CreatedAt   time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName returns the table name for the GORM model.
+func (StatusHistoryModel) TableName() string {
+	return "booking_status_history"
+}
+
+// StatusHistoryEntry describes one recorded booking status transition.
+type StatusHistoryEntry struct {
+	BookingID   uuid.UUID
+	FromStatus  string
+	ToStatus    string
+	ChangedBy   *uuid.UUID
+	Reason      string
+	AdminAction string // empty for normal transitions
+}
+
+// GormStatusHistoryRepository persists booking status history records.
+type GormStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormStatusHistoryRepository creates a new GormStatusHistoryRepository.
+func NewGormStatusHistoryRepository(db *gorm.DB) *GormStatusHistoryRepository {
+	return &GormStatusHistoryRepository{db: db}
+}
+
+// RecordTransition inserts a status history row using the provided db handle
+// (may be a transaction-scoped *gorm.DB or the main db).
+func (r *GormStatusHistoryRepository) RecordTransition(ctx context.Context, db *gorm.DB, entry StatusHistoryEntry) error {
+	model := &StatusHistoryModel{
+		ID:         uuid.New(),
+		BookingID:  entry.BookingID,
+		FromStatus: entry.FromStatus,
+		ToStatus:   entry.ToStatus,
+		ChangedBy:  entry.ChangedBy,
+		Reason:     entry.Reason,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if entry.AdminAction != "" {
+		action := entry.AdminAction
+		model.AdminAction = &action
+	}
+	if err := db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to record status history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/domain"
+	zoneDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/zone"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ZoneModel is the GORM model for the service_zones table.
+type ZoneModel struct {
+	ID                      uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Name                    string          `gorm:"size:100;not null"`
+	Geometry                json.RawMessage `gorm:"type:jsonb;not null"`
+	BaseFareCents           *int64          `gorm:""`
+	CrossZoneSurchargeCents int64           `gorm:"not null;default:0"`
+	Active                  bool            `gorm:"not null;default:true;index"`
+	Version                 int64           `gorm:"not null;default:1"`
+	CreatedAt               time.Time       `gorm:"not null"`
+	UpdatedAt               time.Time       `gorm:"not null"`
+}
+
+// TableName returns the table name for the GORM model.
+func (ZoneModel) TableName() string {
+	return "service_zones"
+}
+
+// GormZoneRepository is the GORM-based implementation of ZoneRepository.
+type GormZoneRepository struct {
+	db *gorm.DB
+}
+
+// NewGormZoneRepository creates a new GormZoneRepository.
+func NewGormZoneRepository(db *gorm.DB) *GormZoneRepository {
+	return &GormZoneRepository{db: db}
+}
+
+// FindByID retrieves a zone by its unique identifier.
+func (r *GormZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*zoneDomain.Zone, error) {
+	var model ZoneModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("ServiceZone", id.String())
+		}
+		return nil, fmt.Errorf("failed to find zone by ID: %w", err)
+	}
+	return toDomainZone(&model)
+}
+
+// FindAll retrieves every zone ordered by name.
+func (r *GormZoneRepository) FindAll(ctx context.Context) ([]*zoneDomain.Zone, error) {
+	var models []ZoneModel
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	return toDomainZoneList(models)
+}
+
+// FindActive retrieves the active zones, oldest first, so overlapping zones resolve
+// to the same one every time.
+func (r *GormZoneRepository) FindActive(ctx context.Context) ([]*zoneDomain.Zone, error) {
+	var models []ZoneModel
+	if err := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active zones: %w", err)
+	}
+	return toDomainZoneList(models)
+}
+
+// ExistsByName reports whether another zone already uses the name (case-insensitive).
+func (r *GormZoneRepository) ExistsByName(ctx context.Context, name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&ZoneModel{}).
+		Where("lower(name) = lower(?) AND id <> ?", name, excludeID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check zone name: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Save persists a new zone.
+func (r *GormZoneRepository) Save(ctx context.Context, z *zoneDomain.Zone) error {
+	model, err := toZoneModel(z)
+	if err != nil {
+		return fmt.Errorf("failed to convert zone to model: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save zone: %w", err)
+	}
+	return nil
+}
+
+// Update persists changes to a zone with optimistic locking.
+func (r *GormZoneRepository) Update(ctx context.Context, z *zoneDomain.Zone) error {
+	model, err := toZoneModel(z)
+	if err != nil {
+		return fmt.Errorf("failed to convert zone to model: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&ZoneModel{}).
+		Where("id = ? AND version = ?", model.ID, z.Version()-1).
+		Updates(map[string]interface{}{
+			"name":                       model.Name,
+			"geometry":                   model.Geometry,
+			"base_fare_cents":            model.BaseFareCents,
+			"cross_zone_surcharge_cents": model.CrossZoneSurchargeCents,
+			"active":                     model.Active,
+			"version":                    model.Version,
+			"updated_at":                 model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update zone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewConflictError("service zone was modified by another transaction")
+	}
+	return nil
+}
+
+// Delete removes a zone.
+func (r *GormZoneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&ZoneModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete zone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("ServiceZone", id.String())
+	}
+	return nil
+}
+
+// --- Conversion Helpers ---
+
+func toZoneModel(z *zoneDomain.Zone) (*ZoneModel, error) {
+	geometryJSON, err := json.Marshal(z.Geometry())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal zone geometry: %w", err)
+	}
+	return &ZoneModel{
+		ID:                      z.ID(),
+		Name:                    z.Name(),
+		Geometry:                geometryJSON,
+		BaseFareCents:           z.BaseFareCents(),
+		CrossZoneSurchargeCents: z.CrossZoneSurchargeCents(),
+		Active:                  z.Active(),
+		Version:                 z.Version(),
+		CreatedAt:               z.CreatedAt(),
+		UpdatedAt:               z.UpdatedAt(),
+	}, nil
+}
+
+func toDomainZone(m *ZoneModel) (*zoneDomain.Zone, error) {
+	geometry, err := zoneDomain.ParseGeometry(m.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse geometry of zone %s: %w", m.ID, err)
+	}
+	return zoneDomain.ReconstructZone(
+		m.ID,
+		m.Name,
+		geometry,
+		m.BaseFareCents,
+		m.CrossZoneSurchargeCents,
+		m.Active,
+		m.Version,
+		m.CreatedAt,
+		m.UpdatedAt,
+	), nil
+}
+
+func toDomainZoneList(models []ZoneModel) ([]*zoneDomain.Zone, error) {
+	list := make([]*zoneDomain.Zone, len(models))
+	for i := range models {
+		z, err := toDomainZone(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		list[i] = z
+	}
+	return list, nil
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_booking_locations_recorded_at;
+DROP INDEX IF EXISTS idx_booking_locations_booking_recorded;
+DROP TABLE IF EXISTS booking_locations;
@@ -0,0 +1,21 @@
+-- 004_create_booking_locations.sql
+-- Recent GPS history of the assigned runner for in-progress bookings.
+-- Rows older than the configured retention window are purged by the service.
+
+CREATE TABLE IF NOT EXISTS booking_locations (
+    id          UUID             PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id  UUID             NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    runner_id   UUID             NOT NULL,
+    latitude    DOUBLE PRECISION NOT NULL,
+    longitude   DOUBLE PRECISION NOT NULL,
+    accuracy_m  DOUBLE PRECISION,
+    heading_deg DOUBLE PRECISION,
+    speed_kmh   DOUBLE PRECISION,
+    recorded_at TIMESTAMPTZ      NOT NULL,
+    created_at  TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+-- Latest / recent pings per booking
+CREATE INDEX IF NOT EXISTS idx_booking_locations_booking_recorded ON booking_locations(booking_id, recorded_at DESC);
+-- Retention purge
+CREATE INDEX IF NOT EXISTS idx_booking_locations_recorded_at ON booking_locations(recorded_at);
@@ -0,0 +1,4 @@
+-- 005_add_bookings_delivery_progress.sql
+-- Live remaining distance / ETA, recomputed from runner location pings.
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS delivery_progress JSONB;
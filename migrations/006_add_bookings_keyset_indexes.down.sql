@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_runner_created_id;
+DROP INDEX IF EXISTS idx_bookings_owner_created_id;
+DROP INDEX IF EXISTS idx_bookings_created_id;
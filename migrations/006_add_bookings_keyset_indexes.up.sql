@@ -0,0 +1,6 @@
+-- 006_add_bookings_keyset_indexes.sql
+-- Supports keyset pagination on (created_at, id) for owner, runner and admin listings.
+
+CREATE INDEX IF NOT EXISTS idx_bookings_created_id ON bookings(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_bookings_owner_created_id ON bookings(owner_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_bookings_runner_created_id ON bookings(runner_id, created_at DESC, id DESC);
@@ -0,0 +1,19 @@
+-- 007_add_bookings_search_index.sql
+-- Trigram index backing the admin free-text booking search. The indexed
+-- expression must stay identical to bookingSearchExpr in
+-- internal/repository/booking_repository.go for the planner to use it.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_search_trgm ON bookings USING GIN ((
+    lower(
+        booking_number || ' ' ||
+        owner_id::text || ' ' ||
+        coalesce(runner_id::text, '') || ' ' ||
+        coalesce(pet_spec->>'name', '') || ' ' ||
+        coalesce(pickup_address->>'line1', '') || ' ' ||
+        coalesce(pickup_address->>'line2', '') || ' ' ||
+        coalesce(dropoff_address->>'line1', '') || ' ' ||
+        coalesce(dropoff_address->>'line2', '')
+    )
+) gin_trgm_ops);
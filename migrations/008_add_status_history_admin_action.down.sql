@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_booking_status_history_admin_action;
+ALTER TABLE booking_status_history DROP COLUMN IF EXISTS admin_action;
@@ -0,0 +1,7 @@
+-- 008_add_status_history_admin_action.sql
+-- Marks status history rows written by admin overrides. NULL for normal transitions.
+
+ALTER TABLE booking_status_history ADD COLUMN IF NOT EXISTS admin_action VARCHAR(40);
+
+CREATE INDEX IF NOT EXISTS idx_booking_status_history_admin_action
+    ON booking_status_history(created_at DESC) WHERE admin_action IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_cancelled_at;
+DROP INDEX IF EXISTS idx_booking_status_history_to_status;
+DROP TABLE IF EXISTS booking_daily_rollups;
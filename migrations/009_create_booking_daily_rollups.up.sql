@@ -0,0 +1,23 @@
+-- 009_create_booking_daily_rollups.sql
+-- Optional pre-aggregated daily booking metrics for the admin analytics dashboard.
+-- Averages are stored as sums and counts so days can be re-aggregated into weeks.
+
+CREATE TABLE IF NOT EXISTS booking_daily_rollups (
+    day              DATE        NOT NULL,
+    pet_type         VARCHAR(20) NOT NULL,
+    created          BIGINT      NOT NULL DEFAULT 0,
+    completed        BIGINT      NOT NULL DEFAULT 0,
+    cancelled        BIGINT      NOT NULL DEFAULT 0,
+    gmv_cents        BIGINT      NOT NULL DEFAULT 0,
+    accept_seconds   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    accept_count     BIGINT      NOT NULL DEFAULT 0,
+    delivery_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    delivery_count   BIGINT      NOT NULL DEFAULT 0,
+    refreshed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, pet_type)
+);
+
+-- Supports first-accepted / completed lookups in the live analytics queries.
+CREATE INDEX IF NOT EXISTS idx_booking_status_history_to_status
+    ON booking_status_history(to_status, booking_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bookings_cancelled_at ON bookings(cancelled_at) WHERE cancelled_at IS NOT NULL;
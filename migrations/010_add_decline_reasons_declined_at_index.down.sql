@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_decline_runner_declined_at;
+DROP INDEX IF EXISTS idx_decline_declined_at;
@@ -0,0 +1,5 @@
+-- 010_add_decline_reasons_declined_at_index.sql
+-- Supports the windowed decline analytics and runner reliability queries.
+
+CREATE INDEX IF NOT EXISTS idx_decline_declined_at ON booking_decline_reasons(declined_at);
+CREATE INDEX IF NOT EXISTS idx_decline_runner_declined_at ON booking_decline_reasons(runner_id, declined_at);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS booking_series (
+    id                UUID PRIMARY KEY,
+    owner_id          UUID NOT NULL,
+    pet_spec          JSONB NOT NULL,
+    pickup_address    JSONB NOT NULL,
+    dropoff_address   JSONB NOT NULL,
+    notes             VARCHAR(1000),
+    rrule             VARCHAR(255) NOT NULL,
+    timezone          VARCHAR(64) NOT NULL,
+    starts_at         TIMESTAMPTZ NOT NULL,
+    status            VARCHAR(20) NOT NULL DEFAULT 'active',
+    skipped_dates     JSONB NOT NULL DEFAULT '[]',
+    generated_through TIMESTAMPTZ,
+    ended_at          TIMESTAMPTZ,
+    version           BIGINT NOT NULL DEFAULT 1,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_series_owner_id ON booking_series (owner_id);
+
+-- Serves the generator's scan for active series that still need bookings.
+CREATE INDEX IF NOT EXISTS idx_booking_series_due
+    ON booking_series (generated_through)
+    WHERE status = 'active';
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES booking_series(id);
+
+-- At most one live booking per series occurrence, so a generator run that races
+-- or repeats cannot double-book the same trip.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_series_occurrence
+    ON bookings (series_id, scheduled_at)
+    WHERE series_id IS NOT NULL AND status <> 'cancelled';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_parent_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS leg;
+ALTER TABLE bookings DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+-- Both legs of a round trip share parent_id; leg is 'outbound' or 'return'.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS parent_id UUID;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS leg VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_parent_id
+    ON bookings (parent_id)
+    WHERE parent_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Ordered intermediate stops of a multi-stop booking; NULL for a direct trip.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS stops JSONB;
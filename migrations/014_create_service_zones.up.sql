@@ -0,0 +1,16 @@
+-- Operating areas as GeoJSON Polygon/MultiPolygon geometries. Containment is
+-- checked in the service, so no PostGIS extension is required.
+CREATE TABLE IF NOT EXISTS service_zones (
+    id                         UUID PRIMARY KEY,
+    name                       VARCHAR(100) NOT NULL,
+    geometry                   JSONB NOT NULL,
+    base_fare_cents            BIGINT,
+    cross_zone_surcharge_cents BIGINT NOT NULL DEFAULT 0,
+    active                     BOOLEAN NOT NULL DEFAULT TRUE,
+    version                    BIGINT NOT NULL DEFAULT 1,
+    created_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_zones_name ON service_zones (lower(name));
+CREATE INDEX IF NOT EXISTS idx_service_zones_active ON service_zones (active);
@@ -0,0 +1,14 @@
+-- Owners' address books of named pickup and dropoff addresses.
+CREATE TABLE IF NOT EXISTS saved_addresses (
+    id            UUID PRIMARY KEY,
+    owner_id      UUID NOT NULL,
+    label         VARCHAR(50) NOT NULL,
+    address       JSONB NOT NULL,
+    access_notes  VARCHAR(500) NOT NULL DEFAULT '',
+    contact_phone VARCHAR(20) NOT NULL DEFAULT '',
+    version       BIGINT NOT NULL DEFAULT 1,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_addresses_owner_label ON saved_addresses (owner_id, lower(label));
@@ -0,0 +1,2 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS dropoff_details;
+ALTER TABLE bookings DROP COLUMN IF EXISTS pickup_details;
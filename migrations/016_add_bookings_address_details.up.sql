@@ -0,0 +1,4 @@
+-- Snapshots of the saved addresses a booking was made from (label, access notes,
+-- contact phone); NULL when the address was entered directly.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS pickup_details JSONB;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS dropoff_details JSONB;
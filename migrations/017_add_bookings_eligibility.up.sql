@@ -0,0 +1,3 @@
+-- Result of the pet eligibility check at booking time: warnings for the runner and
+-- the owner's vet note; NULL for bookings made before the check existed.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS eligibility JSONB;
@@ -0,0 +1,9 @@
+-- Runner capability profiles, kept in step with runner.capabilities_updated events.
+CREATE TABLE IF NOT EXISTS runner_capabilities (
+    runner_id         UUID PRIMARY KEY,
+    crate_sizes       JSONB NOT NULL DEFAULT '[]',
+    climate_control   BOOLEAN NOT NULL DEFAULT FALSE,
+    certifications    JSONB NOT NULL DEFAULT '[]',
+    max_pet_weight_kg DECIMAL(6,2) NOT NULL DEFAULT 0,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
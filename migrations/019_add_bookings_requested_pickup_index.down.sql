@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_bookings_requested_pickup;
@@ -0,0 +1,11 @@
+-- 019_add_bookings_requested_pickup_index.sql
+-- Backs the runner job board (GET /api/v1/bookings/available) on plain Postgres:
+-- a B-tree on the pickup coordinates of open bookings serves the bounding-box
+-- prefilter before exact distances are computed. The indexed expressions must stay
+-- identical to pickupLatExpr and pickupLngExpr in
+-- internal/repository/booking_repository.go for the planner to use it.
+
+CREATE INDEX IF NOT EXISTS idx_bookings_requested_pickup ON bookings (
+    ((pickup_address->>'latitude')::double precision),
+    ((pickup_address->>'longitude')::double precision)
+) WHERE status = 'requested';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS runner_availability;
+DROP TABLE IF EXISTS booking_offers;
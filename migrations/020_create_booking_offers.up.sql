@@ -0,0 +1,35 @@
+-- 020_create_booking_offers.sql
+-- Automatic dispatch: offers of a requested booking to one runner at a time, and the
+-- runner positions the dispatcher ranks runners by.
+
+CREATE TABLE IF NOT EXISTS booking_offers (
+    id           UUID PRIMARY KEY,
+    booking_id   UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    runner_id    UUID NOT NULL,
+    round        INT NOT NULL,
+    score        DOUBLE PRECISION NOT NULL,
+    distance_km  DECIMAL(8,3) NOT NULL,
+    state        VARCHAR(20) NOT NULL CHECK (state IN ('offered', 'accepted', 'rejected', 'expired')),
+    offered_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    version      BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_offers_booking ON booking_offers (booking_id, round);
+CREATE INDEX IF NOT EXISTS idx_booking_offers_runner_responded ON booking_offers (runner_id, responded_at);
+-- At most one open offer per booking and per runner, even with several dispatchers.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_booking_offers_open_booking ON booking_offers (booking_id) WHERE state = 'offered';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_booking_offers_open_runner ON booking_offers (runner_id) WHERE state = 'offered';
+
+CREATE TABLE IF NOT EXISTS runner_availability (
+    runner_id  UUID PRIMARY KEY,
+    online     BOOLEAN NOT NULL DEFAULT FALSE,
+    latitude   DOUBLE PRECISION NOT NULL,
+    longitude  DOUBLE PRECISION NOT NULL,
+    rating     DECIMAL(3,2) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_runner_availability_online_position
+    ON runner_availability (latitude, longitude) WHERE online;
@@ -0,0 +1,16 @@
+-- 021_create_idempotency_keys.sql
+-- Idempotency-Key reservations and the responses replayed for retried requests.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id       UUID         NOT NULL,
+    key           VARCHAR(255) NOT NULL,
+    request_hash  CHAR(64)     NOT NULL,
+    status_code   INTEGER,
+    content_type  VARCHAR(100) NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
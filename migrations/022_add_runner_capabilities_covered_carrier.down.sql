@@ -0,0 +1 @@
+ALTER TABLE runner_capabilities DROP COLUMN IF EXISTS covered_carrier;
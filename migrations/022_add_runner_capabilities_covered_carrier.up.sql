@@ -0,0 +1,4 @@
+-- 022_add_runner_capabilities_covered_carrier.sql
+-- Whether the runner has a covered carrier, for pets whose crate requirement asks for one.
+
+ALTER TABLE runner_capabilities ADD COLUMN IF NOT EXISTS covered_carrier BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- 023_create_booking_dispatch_attempts.sql
+-- When the dispatcher last tried each booking, so bookings no runner can take do not
+-- hold up the ones behind them.
+
+CREATE TABLE IF NOT EXISTS booking_dispatch_attempts (
+    booking_id   UUID PRIMARY KEY REFERENCES bookings(id) ON DELETE CASCADE,
+    attempted_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,138 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// groomingRunRequest is a trip from home to the dropoff via the vet and the groomer.
+func groomingRunRequest() application.CreateBookingRequest {
+	return application.CreateBookingRequest{
+		PetSpec: dto.PetSpecDTO{PetType: "dog", Name: "Bobo", WeightKg: 12},
+		PickupAddress: dto.AddressDTO{
+			Line1: "10 Jalan Rumah", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.139, Longitude: 101.6869,
+		},
+		DropoffAddress: dto.AddressDTO{
+			Line1: "30 Jalan Daycare", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.18, Longitude: 101.70,
+		},
+		Stops: []application.StopRequest{
+			{
+				Address: dto.AddressDTO{Line1: "20 Jalan Vet", City: "KL", State: "WP", Country: "MY", Latitude: 3.16, Longitude: 101.72},
+				Purpose: "vet",
+				WaitMin: 30,
+			},
+			{
+				Address: dto.AddressDTO{Line1: "25 Jalan Groom", City: "KL", State: "WP", Country: "MY", Latitude: 3.17, Longitude: 101.69},
+				Purpose: "grooming",
+				WaitMin: 10,
+			},
+		},
+	}
+}
+
+func TestMultiStop_RoutePricedPerLegWithWaitFees(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	direct := groomingRunRequest()
+	direct.Stops = nil
+	directBk, err := stack.Service.CreateBooking(ctx, ownerID, direct)
+	require.NoError(t, err)
+	require.Len(t, directBk.RouteSpec.Legs, 1)
+
+	multi, err := stack.Service.CreateBooking(ctx, ownerID, groomingRunRequest())
+	require.NoError(t, err)
+	require.Len(t, multi.Stops, 2)
+	assert.Equal(t, 1, multi.Stops[0].Sequence)
+	assert.Equal(t, 2, multi.Stops[1].Sequence)
+
+	route := multi.RouteSpec
+	require.Len(t, route.Legs, 3)
+	var legKm float64
+	legMin := 0
+	for _, leg := range route.Legs {
+		assert.NotEmpty(t, leg.Polyline)
+		legKm += leg.DistanceKm
+		legMin += leg.EstimatedDurationMin
+	}
+	assert.InDelta(t, legKm, route.DistanceKm, 1e-9)
+	assert.Equal(t, legMin+40, route.EstimatedDurationMin)
+	assert.Greater(t, route.DistanceKm, directBk.RouteSpec.DistanceKm)
+
+	// Longer route plus MYR 2.00 per stop and MYR 0.30 per minute of wait.
+	distanceDelta := int64(route.DistanceKm*250) - int64(directBk.RouteSpec.DistanceKm*250)
+	assert.Equal(t, directBk.EstimatedPriceCents+distanceDelta+2*200+40*30, multi.EstimatedPriceCents)
+
+	_, err = stack.Service.CreateBooking(ctx, ownerID, func() application.CreateBookingRequest {
+		req := groomingRunRequest()
+		req.Stops[1].Purpose = "sightseeing"
+		return req
+	}())
+	require.Error(t, err)
+}
+
+func TestMultiStop_RunnerConfirmsStopsInOrderBeforeDelivery(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	runnerID := uuid.New()
+	bk, err := stack.Service.CreateBooking(ctx, uuid.New(), groomingRunRequest())
+	require.NoError(t, err)
+
+	_, err = stack.Service.AcceptBooking(ctx, bk.ID, runnerID)
+	require.NoError(t, err)
+
+	// Stops cannot be confirmed before pickup.
+	_, err = stack.Service.ConfirmStop(ctx, bk.ID, runnerID, 1)
+	require.Error(t, err)
+
+	_, err = stack.Service.StartDelivery(ctx, bk.ID)
+	require.NoError(t, err)
+
+	// Only the assigned runner, and only in order.
+	_, err = stack.Service.ConfirmStop(ctx, bk.ID, uuid.New(), 1)
+	require.Error(t, err)
+	_, err = stack.Service.ConfirmStop(ctx, bk.ID, runnerID, 2)
+	require.Error(t, err)
+
+	first, err := stack.Service.ConfirmStop(ctx, bk.ID, runnerID, 1)
+	require.NoError(t, err)
+	assert.NotNil(t, first.Stops[0].ConfirmedAt)
+	assert.Nil(t, first.Stops[1].ConfirmedAt)
+
+	_, err = stack.Service.ConfirmStop(ctx, bk.ID, runnerID, 1)
+	require.Error(t, err, "a stop is confirmed once")
+
+	_, err = stack.Service.ConfirmDelivery(ctx, bk.ID)
+	require.Error(t, err, "delivery requires every stop to be confirmed")
+
+	_, err = stack.Service.ConfirmStop(ctx, bk.ID, runnerID, 2)
+	require.NoError(t, err)
+
+	delivered, err := stack.Service.ConfirmDelivery(ctx, bk.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "delivered", delivered.Status)
+
+	stored, err := stack.Service.GetBooking(ctx, bk.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Stops, 2)
+	assert.NotNil(t, stored.Stops[1].ConfirmedAt)
+}
@@ -0,0 +1,136 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// racingBookingRepository simulates a concurrent writer: after each of the next
+// `races` loads it bumps the stored version, so the caller's update loses the race.
+type racingBookingRepository struct {
+	bookingDomain.BookingRepository
+	db *gorm.DB
+
+	mu    sync.Mutex
+	races int
+}
+
+func (r *racingBookingRepository) FindByID(ctx context.Context, id uuid.UUID) (*bookingDomain.Booking, error) {
+	bk, err := r.BookingRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.races > 0 {
+		r.races--
+		if err := r.db.Exec("UPDATE bookings SET version = version + 1 WHERE id = ?", id).Error; err != nil {
+			return nil, err
+		}
+	}
+	return bk, nil
+}
+
+func (r *racingBookingRepository) setRaces(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.races = n
+}
+
+func setupRacingStack(t *testing.T, infra *testInfra) (*application.BookingService, *racingBookingRepository, func()) {
+	t.Helper()
+	repo := &racingBookingRepository{
+		BookingRepository: repository.NewGormBookingRepository(infra.DB),
+		db:                infra.DB,
+	}
+	stack := setupBookingStackWith(t, infra.DB, bookingStackConfig{Brokers: infra.KafkaBrokers, Repo: repo})
+	return stack.Service, repo, stack.CleanupProducer
+}
+
+func TestCompleteBookingAfterEscrowRelease_RetriesVersionRace(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, repo, cleanup := setupRacingStack(t, infra)
+	defer cleanup()
+
+	ctx := context.Background()
+	bookingID := uuid.New()
+	seedBookingInDeliveredState(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	repo.setRaces(2)
+	result, err := svc.CompleteBookingAfterEscrowRelease(ctx, bookingID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusCompleted), result.Status)
+
+	// A genuine state conflict is not retried away.
+	repo.setRaces(0)
+	_, err = svc.CompleteBookingAfterEscrowRelease(ctx, bookingID)
+	require.Error(t, err, "a completed booking cannot be completed again")
+}
+
+func TestCompleteBookingAfterEscrowRelease_GivesUpAfterAttempts(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, repo, cleanup := setupRacingStack(t, infra)
+	defer cleanup()
+
+	bookingID := uuid.New()
+	seedBookingInDeliveredState(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	repo.setRaces(100)
+	_, err := svc.CompleteBookingAfterEscrowRelease(context.Background(), bookingID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "modified by another transaction")
+
+	stored, err := svc.GetBooking(context.Background(), bookingID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusDelivered), stored.Status)
+}
+
+func TestCompleteBooking_UserFacingCallSurfacesRace(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, repo, cleanup := setupRacingStack(t, infra)
+	defer cleanup()
+
+	bookingID := uuid.New()
+	seedBookingInDeliveredState(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	repo.setRaces(1)
+	_, err := svc.CompleteBooking(context.Background(), bookingID)
+	require.Error(t, err, "HTTP calls report the conflict instead of retrying")
+	assert.Contains(t, err.Error(), "modified by another transaction")
+}
+
+func TestReleaseRunnerBookings_RetriesVersionRace(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, repo, cleanup := setupRacingStack(t, infra)
+	defer cleanup()
+
+	ctx := context.Background()
+	runnerID := uuid.New()
+	bookingID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, uuid.New(), runnerID)
+
+	repo.setRaces(1)
+	released, err := svc.ReleaseRunnerBookings(ctx, runnerID, "system_suspended")
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	stored, err := svc.GetBooking(ctx, bookingID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusRequested), stored.Status)
+	assert.Nil(t, stored.RunnerID)
+}
@@ -0,0 +1,131 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vetVisitRequest is a scheduled trip from home to the vet, with a return leg
+// returnAfter the outbound pickup (none when zero).
+func vetVisitRequest(returnAfter time.Duration) application.CreateBookingRequest {
+	pickupAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Minute)
+	req := application.CreateBookingRequest{
+		PetSpec: dto.PetSpecDTO{PetType: "cat", Name: "Kiki", WeightKg: 4},
+		PickupAddress: dto.AddressDTO{
+			Line1: "10 Jalan Rumah", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.139, Longitude: 101.6869,
+		},
+		DropoffAddress: dto.AddressDTO{
+			Line1: "20 Jalan Vet", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.16, Longitude: 101.72,
+		},
+		ScheduledAt: &pickupAt,
+		Notes:       "vet visit",
+	}
+	if returnAfter > 0 {
+		req.ReturnLeg = &application.ReturnLegRequest{ScheduledAt: pickupAt.Add(returnAfter)}
+	}
+	return req
+}
+
+func TestRoundTrip_CreatesLinkedDiscountedLegs(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	oneWay, err := stack.Service.CreateBooking(ctx, ownerID, vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Nil(t, oneWay.ParentID)
+	assert.Empty(t, oneWay.Leg)
+
+	outbound, err := stack.Service.CreateBooking(ctx, ownerID, vetVisitRequest(3*time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, outbound.ReturnLeg)
+	ret := outbound.ReturnLeg
+
+	require.NotNil(t, outbound.ParentID)
+	require.NotNil(t, ret.ParentID)
+	assert.Equal(t, *outbound.ParentID, *ret.ParentID)
+	assert.Equal(t, "outbound", outbound.Leg)
+	assert.Equal(t, "return", ret.Leg)
+
+	assert.Equal(t, outbound.DropoffAddress, ret.PickupAddress)
+	assert.Equal(t, outbound.PickupAddress, ret.DropoffAddress)
+	assert.Equal(t, "vet visit", ret.Notes)
+
+	assert.Equal(t, oneWay.EstimatedPriceCents-oneWay.EstimatedPriceCents*10/100, outbound.EstimatedPriceCents)
+	assert.Less(t, ret.EstimatedPriceCents, oneWay.EstimatedPriceCents)
+
+	stored, err := stack.Service.GetBooking(ctx, ret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *outbound.ParentID, *stored.ParentID)
+	assert.Equal(t, "return", stored.Leg)
+}
+
+func TestRoundTrip_RejectsReturnBeforeOutboundArrives(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	_, err := stack.Service.CreateBooking(context.Background(), uuid.New(), vetVisitRequest(time.Minute))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "return_leg.scheduled_at")
+}
+
+func TestRoundTrip_CancellingOutboundBeforePickupCancelsReturn(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	outbound, err := stack.Service.CreateBooking(ctx, ownerID, vetVisitRequest(3*time.Hour))
+	require.NoError(t, err)
+
+	_, err = stack.Service.AcceptBooking(ctx, outbound.ID, uuid.New())
+	require.NoError(t, err)
+
+	cancelled, err := stack.Service.CancelBooking(ctx, outbound.ID, ownerID, "vet rescheduled")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", cancelled.Status)
+	require.NotNil(t, cancelled.ReturnLeg)
+	assert.Equal(t, "cancelled", cancelled.ReturnLeg.Status)
+
+	model := waitForBookingStatus(t, infra.DB, outbound.ReturnLeg.ID, "cancelled", 5*time.Second)
+	assert.Equal(t, "outbound leg cancelled", model.CancelNote)
+}
+
+func TestRoundTrip_CancellingReturnLeavesOutbound(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	outbound, err := stack.Service.CreateBooking(ctx, ownerID, vetVisitRequest(3*time.Hour))
+	require.NoError(t, err)
+
+	cancelled, err := stack.Service.CancelBooking(ctx, outbound.ReturnLeg.ID, ownerID, "staying overnight")
+	require.NoError(t, err)
+	assert.Nil(t, cancelled.ReturnLeg)
+
+	stored, err := stack.Service.GetBooking(ctx, outbound.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "requested", stored.Status)
+}
@@ -0,0 +1,139 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupCapabilityStack wires a BookingService that checks runner capabilities on accept.
+func setupCapabilityStack(t *testing.T, infra *testInfra, required bool) (*application.BookingService, *application.RunnerCapabilityService, func()) {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.RunnerCapabilityModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	capabilities := application.NewRunnerCapabilityService(
+		repository.NewGormRunnerCapabilityRepository(infra.DB), required, logger,
+	)
+	stack := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{Capabilities: capabilities},
+	})
+	return stack.Service, capabilities, stack.CleanupProducer
+}
+
+func TestRunnerCapabilities_AcceptRequiresMatchingProfile(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, capabilities, cleanup := setupCapabilityStack(t, infra, false)
+	defer cleanup()
+
+	ctx := context.Background()
+	req := vetVisitRequest(0)
+	req.PetSpec = dto.PetSpecDTO{PetType: "reptile", Name: "Iggy", WeightKg: 2}
+	bk, err := bookings.CreateBooking(ctx, uuid.New(), req)
+	require.NoError(t, err)
+
+	basic := uuid.New()
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: basic, CrateSizes: []string{"medium"}, MaxPetWeightKg: 20,
+		UpdatedAt: time.Now().UTC(),
+	}))
+	_, err = bookings.AcceptBooking(ctx, bk.ID, basic)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a climate-controlled vehicle")
+	assert.Contains(t, err.Error(), "reptile_handling certification")
+
+	stored, err := bookings.GetBooking(ctx, bk.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusRequested), stored.Status, "a rejected accept leaves the booking open")
+
+	// A profile older than the stored one is ignored.
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: basic, CrateSizes: []string{"small"}, ClimateControl: true,
+		Certifications: []string{"reptile_handling"}, MaxPetWeightKg: 20,
+		UpdatedAt: time.Now().UTC().Add(-time.Hour),
+	}))
+	profile, err := capabilities.GetCapabilities(ctx, basic)
+	require.NoError(t, err)
+	assert.False(t, profile.ClimateControl)
+
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: basic, CrateSizes: []string{"medium"}, ClimateControl: true,
+		Certifications: []string{"Reptile_Handling"}, MaxPetWeightKg: 20,
+		UpdatedAt: time.Now().UTC(),
+	}))
+	accepted, err := bookings.AcceptBooking(ctx, bk.ID, basic)
+	require.NoError(t, err, "a larger crate than required will do")
+	assert.Equal(t, string(bookingDomain.StatusAccepted), accepted.Status)
+
+	// Runners without a profile are not checked unless profiles are required.
+	other, err := bookings.CreateBooking(ctx, uuid.New(), req)
+	require.NoError(t, err)
+	_, err = bookings.AcceptBooking(ctx, other.ID, uuid.New())
+	require.NoError(t, err)
+}
+
+func TestRunnerCapabilities_RequiredRejectsUnknownRunners(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, _, cleanup := setupCapabilityStack(t, infra, true)
+	defer cleanup()
+
+	ctx := context.Background()
+	bk, err := bookings.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+
+	_, err = bookings.AcceptBooking(ctx, bk.ID, uuid.New())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no capability profile")
+}
+
+func TestRunnerCapabilities_CoverAndInvalidProfiles(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	bookings, capabilities, cleanup := setupCapabilityStack(t, infra, false)
+	defer cleanup()
+
+	ctx := context.Background()
+	req := vetVisitRequest(0)
+	req.PetSpec = dto.PetSpecDTO{PetType: "bird", Name: "Kiwi", WeightKg: 0.2}
+	bk, err := bookings.CreateBooking(ctx, uuid.New(), req)
+	require.NoError(t, err)
+
+	runnerID := uuid.New()
+	profile := application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: runnerID, CrateSizes: []string{"small"},
+		Certifications: []string{"bird_handling"}, MaxPetWeightKg: 5,
+		UpdatedAt: time.Now().UTC(),
+	}
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, profile))
+	_, err = bookings.AcceptBooking(ctx, bk.ID, runnerID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a covered carrier")
+
+	profile.CoveredCarrier = true
+	profile.UpdatedAt = time.Now().UTC()
+	require.NoError(t, capabilities.UpdateCapabilities(ctx, profile))
+	_, err = bookings.AcceptBooking(ctx, bk.ID, runnerID)
+	require.NoError(t, err)
+
+	// A profile the domain rejects is reported as such, so the consumer drops it.
+	err = capabilities.UpdateCapabilities(ctx, application.UpdateRunnerCapabilitiesRequest{
+		RunnerID: runnerID, CrateSizes: []string{"enormous"}, UpdatedAt: time.Now().UTC(),
+	})
+	var invalid *application.InvalidCapabilitiesError
+	require.True(t, errors.As(err, &invalid), "got %v", err)
+}
@@ -0,0 +1,146 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingDomain "github.com/Kilat-Pet-Delivery/service-booking/internal/domain/booking"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRunnerLoadService wires a BookingService that caps each runner at maxActive bookings.
+func setupRunnerLoadService(t *testing.T, infra *testInfra, maxActive int) (*application.BookingService, func()) {
+	t.Helper()
+	stack := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{MaxActivePerRunner: maxActive},
+	})
+	return stack.Service, stack.CleanupProducer
+}
+
+// immediateRequest is vetVisitRequest without a scheduled time.
+func immediateRequest() application.CreateBookingRequest {
+	req := vetVisitRequest(0)
+	req.ScheduledAt = nil
+	return req
+}
+
+func TestAcceptBooking_CapsActiveBookingsPerRunner(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupRunnerLoadService(t, infra, 2)
+	defer cleanup()
+
+	ctx := context.Background()
+	runnerID := uuid.New()
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		bk, err := svc.CreateBooking(ctx, uuid.New(), immediateRequest())
+		require.NoError(t, err)
+		ids = append(ids, bk.ID)
+	}
+
+	for _, id := range ids[:2] {
+		_, err := svc.AcceptBooking(ctx, id, runnerID)
+		require.NoError(t, err)
+	}
+	_, err := svc.AcceptBooking(ctx, ids[2], runnerID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 active bookings")
+
+	stored, err := svc.GetBooking(ctx, ids[2])
+	require.NoError(t, err)
+	assert.Equal(t, string(bookingDomain.StatusRequested), stored.Status, "a rejected accept leaves the booking open")
+
+	// Handing one back frees a slot.
+	_, err = svc.DeclineBooking(ctx, ids[0], runnerID, "vehicle trouble")
+	require.NoError(t, err)
+	_, err = svc.AcceptBooking(ctx, ids[2], runnerID)
+	require.NoError(t, err)
+}
+
+func TestAcceptBooking_RejectsOverlappingScheduledBookings(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupRunnerLoadService(t, infra, 0)
+	defer cleanup()
+
+	ctx := context.Background()
+	runnerID := uuid.New()
+	first, err := svc.CreateBooking(ctx, uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+	_, err = svc.AcceptBooking(ctx, first.ID, runnerID)
+	require.NoError(t, err)
+
+	clash := vetVisitRequest(0)
+	clashAt := clash.ScheduledAt.Add(5 * time.Minute)
+	clash.ScheduledAt = &clashAt
+	overlapping, err := svc.CreateBooking(ctx, uuid.New(), clash)
+	require.NoError(t, err)
+	_, err = svc.AcceptBooking(ctx, overlapping.ID, runnerID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), first.BookingNumber)
+
+	// Another runner is free at that time.
+	_, err = svc.AcceptBooking(ctx, overlapping.ID, uuid.New())
+	require.NoError(t, err)
+
+	later := vetVisitRequest(0)
+	laterAt := later.ScheduledAt.Add(3 * time.Hour)
+	later.ScheduledAt = &laterAt
+	afterwards, err := svc.CreateBooking(ctx, uuid.New(), later)
+	require.NoError(t, err)
+	_, err = svc.AcceptBooking(ctx, afterwards.ID, runnerID)
+	require.NoError(t, err, "a booking after the first one ends does not overlap")
+}
+
+func TestAcceptBooking_ConcurrentAcceptsRespectCap(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	svc, cleanup := setupRunnerLoadService(t, infra, 1)
+	defer cleanup()
+
+	ctx := context.Background()
+	runnerID := uuid.New()
+	const attempts = 8
+	ids := make([]uuid.UUID, attempts)
+	for i := range ids {
+		bk, err := svc.CreateBooking(ctx, uuid.New(), immediateRequest())
+		require.NoError(t, err)
+		ids[i] = bk.ID
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	start := make(chan struct{})
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			<-start
+			if _, err := svc.AcceptBooking(ctx, id, runnerID); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(id)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 1, accepted)
+	active, err := repository.NewGormBookingRepository(infra.DB).FindActiveByRunnerID(ctx, runnerID)
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+}
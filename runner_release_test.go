@@ -0,0 +1,67 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-proto/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	bookingEvents "github.com/Kilat-Pet-Delivery/service-booking/internal/events"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunnerSuspended_ReleasesAcceptedBooking verifies that a runner.suspended event
+// moves the runner's accepted booking back to "requested", records a system decline
+// reason, and emits a BookingRunnerReleasedEvent for the owner.
+func TestRunnerSuspended_ReleasesAcceptedBooking(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	defer stack.CleanupProducer()
+	defer func() { _ = stack.RunnerConsumer.Close() }()
+
+	bookingID := uuid.New()
+	ownerID := uuid.New()
+	runnerID := uuid.New()
+	seedAcceptedBooking(t, infra.DB, bookingID, ownerID, runnerID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = stack.RunnerConsumer.Start(ctx) }()
+	time.Sleep(3 * time.Second) // Wait for consumer group join.
+
+	evt := bookingEvents.RunnerStatusEvent{
+		RunnerID:   runnerID,
+		Reason:     "policy violation",
+		OccurredAt: time.Now().UTC(),
+	}
+	publishTestEvent(t, infra.KafkaBrokers, bookingEvents.TopicRunnerEvents,
+		"service-runner", bookingEvents.RunnerSuspended, evt)
+
+	// Assert: booking is back to "requested" with no runner.
+	model := waitForBookingStatus(t, infra.DB, bookingID, "requested", 15*time.Second)
+	assert.Nil(t, model.RunnerID, "runner_id should be cleared after release")
+
+	// Assert: a system decline reason was recorded.
+	var declineRow repository.DeclineReasonModel
+	require.NoError(t, infra.DB.Where("booking_id = ? AND runner_id = ?", bookingID, runnerID).
+		First(&declineRow).Error)
+	assert.Equal(t, bookingEvents.ReleaseReasonRunnerSuspended, declineRow.Reason)
+
+	// Assert: owner notification event on booking.events.
+	ce := consumeOneEvent(t, infra.KafkaBrokers, events.TopicBookingEvents,
+		application.BookingRunnerReleased, 15*time.Second)
+
+	var released application.BookingRunnerReleasedEvent
+	require.NoError(t, ce.ParseData(&released))
+	assert.Equal(t, bookingID, released.BookingID)
+	assert.Equal(t, ownerID, released.OwnerID)
+	assert.Equal(t, runnerID, released.RunnerID)
+}
@@ -0,0 +1,169 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// savedAddressTestStack wires a BookingService that resolves saved addresses.
+type savedAddressTestStack struct {
+	Addresses  *application.SavedAddressService
+	Bookings   *application.BookingService
+	Router     *gin.Engine
+	JWTManager *auth.JWTManager
+	cleanup    func()
+}
+
+func setupSavedAddressStack(t *testing.T, infra *testInfra) *savedAddressTestStack {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.SavedAddressModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	addresses := application.NewSavedAddressService(repository.NewGormSavedAddressRepository(infra.DB), nil, logger)
+	bookings := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{SavedAddresses: addresses},
+	})
+
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewSavedAddressHandler(addresses).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	return &savedAddressTestStack{
+		Addresses:  addresses,
+		Bookings:   bookings.Service,
+		Router:     router,
+		JWTManager: jwtManager,
+		cleanup:    bookings.CleanupProducer,
+	}
+}
+
+func TestSavedAddress_HTTP_CRUD(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSavedAddressStack(t, infra)
+	defer stack.cleanup()
+
+	ownerToken, err := stack.JWTManager.GenerateAccessToken(uuid.New(), "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	otherToken, err := stack.JWTManager.GenerateAccessToken(uuid.New(), "other@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+
+	home := map[string]interface{}{
+		"label":         "Home",
+		"address":       vetVisitRequest(0).PickupAddress,
+		"access_notes":  "Gate code 4821",
+		"contact_phone": "+60 12-345 6789",
+	}
+	w := doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/addresses", ownerToken, home)
+	require.Equal(t, http.StatusCreated, w.Code, "create failed: %s", w.Body.String())
+	var created struct {
+		Data application.SavedAddressDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "Gate code 4821", created.Data.AccessNotes)
+
+	home["label"] = "home"
+	w = doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/addresses", ownerToken, home)
+	assert.Equal(t, http.StatusConflict, w.Code, "duplicate label: %s", w.Body.String())
+
+	home["label"] = "Office"
+	home["contact_phone"] = "call me"
+	w = doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/addresses", ownerToken, home)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "invalid phone: %s", w.Body.String())
+
+	path := "/api/v1/addresses/" + created.Data.ID.String()
+	w = doJSONRequest(t, stack.Router, http.MethodGet, path, otherToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	home["label"] = "Home"
+	home["contact_phone"] = ""
+	home["access_notes"] = "Ring twice"
+	w = doJSONRequest(t, stack.Router, http.MethodPut, path, ownerToken, home)
+	require.Equal(t, http.StatusOK, w.Code, "update failed: %s", w.Body.String())
+
+	w = doJSONRequest(t, stack.Router, http.MethodGet, "/api/v1/addresses", ownerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data []application.SavedAddressDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "Ring twice", list.Data[0].AccessNotes)
+	assert.Equal(t, int64(2), list.Data[0].Version)
+
+	w = doJSONRequest(t, stack.Router, http.MethodDelete, path, ownerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, "delete failed: %s", w.Body.String())
+	w = doJSONRequest(t, stack.Router, http.MethodGet, path, ownerToken, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSavedAddress_BookingSnapshotsSavedAddresses(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSavedAddressStack(t, infra)
+	defer stack.cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	template := vetVisitRequest(0)
+
+	home, err := stack.Addresses.CreateAddress(ctx, ownerID, application.SavedAddressRequest{
+		Label: "Home", Address: template.PickupAddress, AccessNotes: "Gate code 4821", ContactPhone: "+60123456789",
+	})
+	require.NoError(t, err)
+	vet, err := stack.Addresses.CreateAddress(ctx, ownerID, application.SavedAddressRequest{
+		Label: "Vet", Address: template.DropoffAddress,
+	})
+	require.NoError(t, err)
+
+	req := vetVisitRequest(2 * time.Hour)
+	req.PickupAddress, req.DropoffAddress = dto.AddressDTO{}, dto.AddressDTO{}
+	req.PickupAddressID, req.DropoffAddressID = &home.ID, &vet.ID
+	bk, err := stack.Bookings.CreateBooking(ctx, ownerID, req)
+	require.NoError(t, err)
+	assert.Equal(t, template.PickupAddress.Line1, bk.PickupAddress.Line1)
+	require.NotNil(t, bk.PickupDetails)
+	assert.Equal(t, "Home", bk.PickupDetails.Label)
+	assert.Equal(t, "Gate code 4821", bk.PickupDetails.AccessNotes)
+	require.NotNil(t, bk.ReturnLeg)
+	require.NotNil(t, bk.ReturnLeg.DropoffDetails)
+	assert.Equal(t, home.ID, bk.ReturnLeg.DropoffDetails.SavedAddressID, "the return leg goes back home")
+
+	// Later edits to the address book do not reach existing bookings.
+	_, err = stack.Addresses.UpdateAddress(ctx, ownerID, home.ID, application.SavedAddressRequest{
+		Label: "Home", Address: template.PickupAddress, AccessNotes: "New gate code 9999",
+	})
+	require.NoError(t, err)
+	stored, err := stack.Bookings.GetBooking(ctx, bk.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.PickupDetails)
+	assert.Equal(t, "Gate code 4821", stored.PickupDetails.AccessNotes)
+
+	// Another owner's addresses cannot be booked from.
+	_, err = stack.Bookings.CreateBooking(ctx, uuid.New(), req)
+	require.Error(t, err)
+
+	both := vetVisitRequest(0)
+	both.PickupAddressID = &home.ID
+	_, err = stack.Bookings.CreateBooking(ctx, ownerID, both)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not both")
+}
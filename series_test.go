@@ -0,0 +1,208 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/lib-proto/dto"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seriesTestStack wires a SeriesService on top of a full booking stack.
+type seriesTestStack struct {
+	Service    *application.SeriesService
+	Router     *gin.Engine
+	JWTManager *auth.JWTManager
+	cleanup    func()
+}
+
+func setupSeriesStack(t *testing.T, infra *testInfra) *seriesTestStack {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.SeriesModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	stack := setupBookingStack(t, infra.DB, infra.KafkaBrokers)
+	svc := application.NewSeriesService(
+		repository.NewGormSeriesRepository(infra.DB),
+		stack.Service,
+		infra.DB,
+		14*24*time.Hour,
+		logger,
+	)
+
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewSeriesHandler(svc).RegisterRoutes(&router.RouterGroup, jwtManager)
+
+	return &seriesTestStack{Service: svc, Router: router, JWTManager: jwtManager, cleanup: stack.CleanupProducer}
+}
+
+// dailySeriesRequest returns a daily series starting tomorrow at 08:00 Kuala Lumpur time.
+func dailySeriesRequest(rrule string) application.CreateSeriesRequest {
+	loc, _ := time.LoadLocation("Asia/Kuala_Lumpur")
+	tomorrow := time.Now().In(loc).AddDate(0, 0, 1)
+	return application.CreateSeriesRequest{
+		PetSpec: dto.PetSpecDTO{PetType: "dog", Name: "Bobo", WeightKg: 12},
+		PickupAddress: dto.AddressDTO{
+			Line1: "1 Jalan Daycare", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.139, Longitude: 101.6869,
+		},
+		DropoffAddress: dto.AddressDTO{
+			Line1: "2 Jalan Home", City: "KL", State: "WP", Country: "MY",
+			Latitude: 3.15, Longitude: 101.71,
+		},
+		RRule:    rrule,
+		StartsAt: time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 8, 0, 0, 0, loc),
+		Timezone: "Asia/Kuala_Lumpur",
+	}
+}
+
+func seriesBookings(t *testing.T, db *gorm.DB, seriesID uuid.UUID, status string) []repository.BookingModel {
+	t.Helper()
+	var models []repository.BookingModel
+	require.NoError(t, db.Where("series_id = ? AND status = ?", seriesID, status).
+		Order("scheduled_at ASC").Find(&models).Error)
+	return models
+}
+
+func TestSeries_CreateGeneratesScheduledBookingsOnce(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSeriesStack(t, infra)
+	defer stack.cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	series, err := stack.Service.CreateSeries(ctx, ownerID, dailySeriesRequest("FREQ=DAILY;COUNT=5"))
+	require.NoError(t, err)
+
+	generated := seriesBookings(t, infra.DB, series.ID, "requested")
+	require.Len(t, generated, 5)
+	for i, m := range generated {
+		require.NotNil(t, m.ScheduledAt)
+		assert.True(t, m.ScheduledAt.Equal(series.StartsAt.AddDate(0, 0, i)), "occurrence %d at %s", i, m.ScheduledAt)
+		assert.Equal(t, ownerID, m.OwnerID)
+	}
+
+	// A second run must not duplicate anything; the series stays active until its last trip.
+	created, err := stack.Service.GenerateDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, created)
+	assert.Len(t, seriesBookings(t, infra.DB, series.ID, "requested"), 5)
+
+	got, err := stack.Service.GetSeries(ctx, ownerID, series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", got.Status)
+	assert.Len(t, got.NextOccurrences, 5)
+}
+
+func TestSeries_SkipDateCancelsItsBooking(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSeriesStack(t, infra)
+	defer stack.cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	series, err := stack.Service.CreateSeries(ctx, ownerID, dailySeriesRequest("FREQ=DAILY"))
+	require.NoError(t, err)
+
+	loc, _ := time.LoadLocation(series.Timezone)
+	skipped := series.StartsAt.AddDate(0, 0, 2).In(loc).Format("2006-01-02")
+	result, err := stack.Service.SkipSeriesDate(ctx, ownerID, series.ID, application.SkipSeriesDateRequest{Date: skipped})
+	require.NoError(t, err)
+	assert.Equal(t, []string{skipped}, result.SkippedDates)
+
+	cancelled := seriesBookings(t, infra.DB, series.ID, "cancelled")
+	require.Len(t, cancelled, 1)
+	assert.Equal(t, skipped, cancelled[0].ScheduledAt.In(loc).Format("2006-01-02"))
+
+	// Skipping a date with no occurrence is rejected.
+	_, err = stack.Service.SkipSeriesDate(ctx, ownerID, series.ID, application.SkipSeriesDateRequest{Date: "2020-01-01"})
+	require.Error(t, err)
+}
+
+func TestSeries_PauseCancelsFutureBookingsAndResumeRegenerates(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSeriesStack(t, infra)
+	defer stack.cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+	series, err := stack.Service.CreateSeries(ctx, ownerID, dailySeriesRequest("FREQ=WEEKLY;BYDAY=MO,WE,FR"))
+	require.NoError(t, err)
+	generated := len(seriesBookings(t, infra.DB, series.ID, "requested"))
+	require.NotZero(t, generated)
+
+	// Someone else's owner ID cannot touch the series.
+	_, err = stack.Service.PauseSeries(ctx, uuid.New(), series.ID)
+	require.Error(t, err)
+
+	paused, err := stack.Service.PauseSeries(ctx, ownerID, series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "paused", paused.Status)
+	assert.Empty(t, paused.NextOccurrences)
+	assert.Empty(t, seriesBookings(t, infra.DB, series.ID, "requested"))
+	assert.Len(t, seriesBookings(t, infra.DB, series.ID, "cancelled"), generated)
+
+	created, err := stack.Service.GenerateDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, created, "paused series must not generate")
+
+	resumed, err := stack.Service.ResumeSeries(ctx, ownerID, series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", resumed.Status)
+	assert.Len(t, seriesBookings(t, infra.DB, series.ID, "requested"), generated)
+
+	ended, err := stack.Service.EndSeries(ctx, ownerID, series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ended", ended.Status)
+	assert.Empty(t, seriesBookings(t, infra.DB, series.ID, "requested"))
+}
+
+func TestSeries_HTTP_CreateRejectsUnsupportedRule(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupSeriesStack(t, infra)
+	defer stack.cleanup()
+
+	token := ownerToken(t, stack.JWTManager, uuid.New())
+	req := dailySeriesRequest("FREQ=MONTHLY;BYMONTHDAY=1")
+	body := map[string]interface{}{
+		"pet_spec":        map[string]interface{}{"pet_type": "dog", "name": "Bobo", "weight_kg": 12},
+		"pickup_address":  req.PickupAddress,
+		"dropoff_address": req.DropoffAddress,
+		"rrule":           req.RRule,
+		"starts_at":       req.StartsAt,
+	}
+
+	w := doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/booking-series", token, body)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "expected 400, got: %s", w.Body.String())
+
+	body["rrule"] = "FREQ=WEEKLY;COUNT=2"
+	w = doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/booking-series", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, "create failed: %s", w.Body.String())
+
+	var resp struct {
+		Data application.SeriesDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Asia/Kuala_Lumpur", resp.Data.Timezone)
+	assert.Len(t, resp.Data.NextOccurrences, 2)
+}
@@ -0,0 +1,164 @@
+//go:build integration
+
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// zoneTestStack wires a BookingService that checks trips against service zones.
+type zoneTestStack struct {
+	Zones    *application.ServiceAreaService
+	Bookings *application.BookingService
+	Router   *gin.Engine
+	Token    string
+	cleanup  func()
+}
+
+func setupZoneStack(t *testing.T, infra *testInfra) *zoneTestStack {
+	t.Helper()
+	require.NoError(t, infra.DB.AutoMigrate(&repository.ZoneModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	zones := application.NewServiceAreaService(repository.NewGormZoneRepository(infra.DB), logger)
+	bookings := setupBookingStackWith(t, infra.DB, bookingStackConfig{
+		Brokers: infra.KafkaBrokers,
+		Options: application.BookingServiceOptions{ServiceArea: zones},
+	})
+
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewZoneHandler(zones).RegisterRoutes(&router.RouterGroup, jwtManager)
+	token, err := jwtManager.GenerateAccessToken(uuid.New(), "admin@test.com", auth.RoleAdmin)
+	require.NoError(t, err)
+
+	return &zoneTestStack{
+		Zones:    zones,
+		Bookings: bookings.Service,
+		Router:   router,
+		Token:    token,
+		cleanup:  bookings.CleanupProducer,
+	}
+}
+
+// boxGeometry returns a GeoJSON Polygon covering the given longitude/latitude box.
+func boxGeometry(minLng, minLat, maxLng, maxLat float64) json.RawMessage {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "Polygon",
+		"coordinates": [][][2]float64{{
+			{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}, {minLng, minLat},
+		}},
+	})
+	return data
+}
+
+func TestServiceZone_RejectsTripsOutsideCoverageAndAppliesZoneFares(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupZoneStack(t, infra)
+	defer stack.cleanup()
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	// Without zones every trip is accepted at the standard fare.
+	standard, err := stack.Bookings.CreateBooking(ctx, ownerID, vetVisitRequest(0))
+	require.NoError(t, err)
+
+	baseFare := int64(700)
+	_, err = stack.Zones.CreateZone(ctx, application.ZoneRequest{
+		Name:                    "Kuala Lumpur",
+		Geometry:                boxGeometry(101.60, 3.10, 101.75, 3.20),
+		BaseFareCents:           &baseFare,
+		CrossZoneSurchargeCents: 150,
+	})
+	require.NoError(t, err)
+	_, err = stack.Zones.CreateZone(ctx, application.ZoneRequest{
+		Name:                    "Petaling Jaya",
+		Geometry:                boxGeometry(101.55, 3.05, 101.60, 3.15),
+		CrossZoneSurchargeCents: 300,
+	})
+	require.NoError(t, err)
+
+	inZone, err := stack.Bookings.CreateBooking(ctx, ownerID, vetVisitRequest(0))
+	require.NoError(t, err)
+	assert.Equal(t, standard.EstimatedPriceCents+baseFare-500, inZone.EstimatedPriceCents)
+
+	crossZone := vetVisitRequest(0)
+	crossZone.DropoffAddress.Latitude, crossZone.DropoffAddress.Longitude = 3.12, 101.58
+	crossBk, err := stack.Bookings.CreateBooking(ctx, ownerID, crossZone)
+	require.NoError(t, err)
+	distancePrice := int64(crossBk.RouteSpec.DistanceKm * 250)
+	inZoneDistancePrice := int64(inZone.RouteSpec.DistanceKm * 250)
+	assert.Equal(t, inZone.EstimatedPriceCents-inZoneDistancePrice+distancePrice+300, crossBk.EstimatedPriceCents,
+		"cross-zone trips pay the higher of the two surcharges")
+
+	// Coordinates omitted by the client land at 0,0, far outside coverage.
+	missing := vetVisitRequest(0)
+	missing.DropoffAddress.Latitude, missing.DropoffAddress.Longitude = 0, 0
+	_, err = stack.Bookings.CreateBooking(ctx, ownerID, missing)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dropoff address")
+
+	withStop := vetVisitRequest(0)
+	withStop.Stops = []application.StopRequest{{Address: missing.DropoffAddress, Purpose: "vet"}}
+	withStop.Stops[0].Address.Line1 = "Nowhere"
+	_, err = stack.Bookings.CreateBooking(ctx, ownerID, withStop)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop 1")
+}
+
+func TestServiceZone_HTTP_AdminCRUD(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+	stack := setupZoneStack(t, infra)
+	defer stack.cleanup()
+
+	unclosed := json.RawMessage(`{"type":"Polygon","coordinates":[[[101.6,3.1],[101.7,3.1],[101.7,3.2],[101.6,3.2]]]}`)
+	w := doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/admin/service-zones", stack.Token,
+		map[string]interface{}{"name": "KL", "geometry": unclosed})
+	assert.Equal(t, http.StatusBadRequest, w.Code, "unclosed ring: %s", w.Body.String())
+
+	body := map[string]interface{}{"name": "KL", "geometry": boxGeometry(101.60, 3.10, 101.75, 3.20)}
+	w = doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/admin/service-zones", stack.Token, body)
+	require.Equal(t, http.StatusCreated, w.Code, "create failed: %s", w.Body.String())
+	var created struct {
+		Data application.ZoneDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, created.Data.Active)
+	assert.Equal(t, "Polygon", created.Data.Geometry.Type())
+
+	w = doJSONRequest(t, stack.Router, http.MethodPost, "/api/v1/admin/service-zones", stack.Token,
+		map[string]interface{}{"name": "kl", "geometry": boxGeometry(101.0, 3.0, 101.1, 3.1)})
+	assert.Equal(t, http.StatusConflict, w.Code, "duplicate name: %s", w.Body.String())
+
+	path := "/api/v1/admin/service-zones/" + created.Data.ID.String()
+	body["active"] = false
+	w = doJSONRequest(t, stack.Router, http.MethodPut, path, stack.Token, body)
+	require.Equal(t, http.StatusOK, w.Code, "update failed: %s", w.Body.String())
+
+	// An inactive zone gives no coverage, so trips are accepted anywhere again.
+	_, err := stack.Bookings.CreateBooking(context.Background(), uuid.New(), vetVisitRequest(0))
+	require.NoError(t, err)
+
+	w = doJSONRequest(t, stack.Router, http.MethodDelete, path, stack.Token, nil)
+	require.Equal(t, http.StatusOK, w.Code, "delete failed: %s", w.Body.String())
+	w = doJSONRequest(t, stack.Router, http.MethodGet, path, stack.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -36,8 +36,9 @@ type testInfra struct {
 
 // bookingStack holds wired-up booking service components.
 type bookingStack struct {
-	Service        *application.BookingService
-	Consumer       *bookingEvents.PaymentEventConsumer
+	Service         *application.BookingService
+	Consumer        *bookingEvents.PaymentEventConsumer
+	RunnerConsumer  *bookingEvents.RunnerEventConsumer
 	CleanupProducer func()
 }
 
@@ -89,7 +90,7 @@ func setupContainers(t *testing.T) *testInfra {
 
 	// Enable uuid-ossp and auto-migrate.
 	require.NoError(t, db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error)
-	require.NoError(t, db.AutoMigrate(&repository.BookingModel{}))
+	require.NoError(t, db.AutoMigrate(&repository.BookingModel{}, &repository.DeclineReasonModel{}, &repository.StatusHistoryModel{}))
 
 	// Start Kafka container using confluent-local (supports KRaft natively).
 	kafkaContainer, err := kafkamodule.Run(ctx, "confluentinc/confluent-local:7.5.0")
@@ -99,7 +100,7 @@ func setupContainers(t *testing.T) *testInfra {
 	require.NoError(t, err, "failed to get Kafka brokers")
 
 	// Pre-create required topics.
-	createTopics(t, kafkaBrokers, "booking.events", "payment.events")
+	createTopics(t, kafkaBrokers, "booking.events", "payment.events", "runner.events")
 
 	cleanup := func() {
 		if err := kafkaContainer.Terminate(ctx); err != nil {
@@ -117,25 +118,50 @@ func setupContainers(t *testing.T) *testInfra {
 	}
 }
 
+// bookingStackConfig configures setupBookingStackWith.
+type bookingStackConfig struct {
+	// Brokers connects the Kafka producer and consumers; nil leaves them out.
+	Brokers []string
+	// Repo replaces the GORM booking repository, e.g. to inject write races.
+	Repo bookingDomain.BookingRepository
+	// Options wires the BookingService's optional collaborators.
+	Options application.BookingServiceOptions
+}
+
 // setupBookingStack wires up the full booking service stack.
 func setupBookingStack(t *testing.T, db *gorm.DB, brokers []string) *bookingStack {
+	return setupBookingStackWith(t, db, bookingStackConfig{Brokers: brokers})
+}
+
+// setupBookingStackWith wires up the booking service stack as configured. Tests that
+// need extra collaborators build them and pass them in through cfg.Options.
+func setupBookingStackWith(t *testing.T, db *gorm.DB, cfg bookingStackConfig) *bookingStack {
 	t.Helper()
 	logger, _ := zap.NewDevelopment()
 
-	bookingRepo := repository.NewGormBookingRepository(db)
+	bookingRepo := cfg.Repo
+	if bookingRepo == nil {
+		bookingRepo = repository.NewGormBookingRepository(db)
+	}
 	declineRepo := repository.NewGormDeclineReasonRepository(db)
 	pricing := bookingDomain.NewStandardPricingStrategy()
-	producer := kafka.NewProducer(brokers, logger)
-	bookingSvc := application.NewBookingService(bookingRepo, pricing, producer, logger, db, declineRepo)
-
-	groupID := fmt.Sprintf("test-booking-%s", uuid.New().String()[:8])
-	consumer := bookingEvents.NewPaymentEventConsumer(brokers, groupID, bookingSvc, logger)
+	var producer *kafka.Producer
+	if cfg.Brokers != nil {
+		producer = kafka.NewProducer(cfg.Brokers, logger)
+	}
+	bookingSvc := application.NewBookingService(bookingRepo, pricing, producer, logger, db, declineRepo, cfg.Options)
 
-	return &bookingStack{
+	stack := &bookingStack{
 		Service:         bookingSvc,
-		Consumer:        consumer,
-		CleanupProducer: func() { _ = producer.Close() },
+		CleanupProducer: func() {},
+	}
+	if producer != nil {
+		groupID := fmt.Sprintf("test-booking-%s", uuid.New().String()[:8])
+		stack.Consumer = bookingEvents.NewPaymentEventConsumer(cfg.Brokers, groupID, bookingSvc, logger)
+		stack.RunnerConsumer = bookingEvents.NewRunnerEventConsumer(cfg.Brokers, groupID+"-runner", bookingSvc, nil, nil, logger)
+		stack.CleanupProducer = func() { _ = producer.Close() }
 	}
+	return stack
 }
 
 // seedBookingInDeliveredState inserts a booking in "delivered" state for testing.
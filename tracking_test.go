@@ -0,0 +1,128 @@
+//go:build integration
+
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kilat-Pet-Delivery/lib-common/auth"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/application"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/handler"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/realtime"
+	"github.com/Kilat-Pet-Delivery/service-booking/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupTrackingRouter(t *testing.T, db *gorm.DB) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+	require.NoError(t, db.AutoMigrate(&repository.LocationModel{}))
+
+	logger, _ := zap.NewDevelopment()
+	jwtManager := auth.NewJWTManager("test-secret-key", 15*time.Minute, 24*time.Hour)
+
+	svc := application.NewTrackingService(
+		repository.NewGormBookingRepository(db),
+		repository.NewGormLocationRepository(db),
+		nil,
+		realtime.NewHub(realtime.NewLocalBroadcaster(), logger),
+		time.Hour,
+		10*time.Minute,
+		logger,
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.NewTrackingHandler(svc).RegisterRoutes(&router.RouterGroup, jwtManager)
+	return router, jwtManager
+}
+
+// ownerToken generates a valid JWT for an owner.
+func ownerToken(t *testing.T, jwtManager *auth.JWTManager, ownerID uuid.UUID) string {
+	t.Helper()
+	token, err := jwtManager.GenerateAccessToken(ownerID, "owner@test.com", auth.RoleOwner)
+	require.NoError(t, err)
+	return token
+}
+
+func doJSONRequest(t *testing.T, router *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestTracking_RunnerPing_OwnerReadsLatest verifies that the assigned runner can report a
+// location for an in-progress booking and the owner reads it back as the latest position.
+func TestTracking_RunnerPing_OwnerReadsLatest(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, jwtManager := setupTrackingRouter(t, infra.DB)
+
+	bookingID := uuid.New()
+	ownerID := uuid.New()
+	runnerID := uuid.New()
+	seedInProgressBooking(t, infra.DB, bookingID, ownerID, runnerID)
+
+	path := fmt.Sprintf("/api/v1/bookings/%s/location", bookingID)
+	w := doJSONRequest(t, router, http.MethodPost, path, runnerToken(t, jwtManager, runnerID),
+		map[string]interface{}{"latitude": 3.145, "longitude": 101.70, "speed_kmh": 32.5})
+	require.Equal(t, http.StatusCreated, w.Code, "expected 201, got: %s", w.Body.String())
+
+	w = doJSONRequest(t, router, http.MethodGet, path, ownerToken(t, jwtManager, ownerID), nil)
+	require.Equal(t, http.StatusOK, w.Code, "expected 200, got: %s", w.Body.String())
+
+	var body struct {
+		Data application.LocationDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.InDelta(t, 3.145, body.Data.Latitude, 1e-9)
+	assert.InDelta(t, 101.70, body.Data.Longitude, 1e-9)
+	assert.Equal(t, runnerID, body.Data.RunnerID)
+
+	var count int64
+	require.NoError(t, infra.DB.Model(&repository.LocationModel{}).
+		Where("booking_id = ?", bookingID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// The ping also recomputes the booking's remaining distance / ETA.
+	var bm repository.BookingModel
+	require.NoError(t, infra.DB.Where("id = ?", bookingID).First(&bm).Error)
+	assert.NotEmpty(t, bm.DeliveryProgress, "delivery_progress should be set after a ping")
+}
+
+// TestTracking_StrangerCannotReadLocation verifies that users other than the owner
+// and assigned runner get 403.
+func TestTracking_StrangerCannotReadLocation(t *testing.T) {
+	infra := setupContainers(t)
+	defer infra.Cleanup()
+
+	router, jwtManager := setupTrackingRouter(t, infra.DB)
+
+	bookingID := uuid.New()
+	seedInProgressBooking(t, infra.DB, bookingID, uuid.New(), uuid.New())
+
+	path := fmt.Sprintf("/api/v1/bookings/%s/location", bookingID)
+	w := doJSONRequest(t, router, http.MethodGet, path, ownerToken(t, jwtManager, uuid.New()), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "expected 403, got: %s", w.Body.String())
+}